@@ -85,6 +85,11 @@ type ServerOption struct {
 	// WorkerThreadsForGC is the number of threads for recycling jobs
 	// The larger the number, the faster the job recycling, but requires more CPU load.
 	WorkerThreadsForGC uint32
+	// GCRetentionConfig is the path of the retention configuration of the garbage collector,
+	// it defines per phase TTLs, history limits and archival of finished jobs.
+	GCRetentionConfig string
+	// ExternalPluginConfig is the path of the configuration of the endpoints called by the external job plugin.
+	ExternalPluginConfig string
 	// Controllers specify controllers to set up.
 	// Case1: Use '*' for all controllers,
 	// Case2: "+gc-controller,+job-controller,+jobflow-controller,+jobtemplate-controller,+pg-controller,+queue-controller"
@@ -126,6 +131,8 @@ func (s *ServerOption) AddFlags(fs *pflag.FlagSet, knownControllers []string) {
 	fs.BoolVar(&s.InheritOwnerAnnotations, "inherit-owner-annotations", true, "Enable inherit owner annotations for pods when create podgroup; it is enabled by default")
 	fs.Uint32Var(&s.WorkerThreadsForPG, "worker-threads-for-podgroup", defaultPodGroupWorkers, "The number of threads syncing podgroup operations. The larger the number, the faster the podgroup processing, but requires more CPU load.")
 	fs.Uint32Var(&s.WorkerThreadsForGC, "worker-threads-for-gc", defaultGCWorkers, "The number of threads for recycling jobs. The larger the number, the faster the job recycling, but requires more CPU load.")
+	fs.StringVar(&s.GCRetentionConfig, "gc-retention-config", "", "The path of the retention configuration of the garbage collector, which defines per phase TTLs, history limits and archival of finished jobs.")
+	fs.StringVar(&s.ExternalPluginConfig, "external-plugin-config", "", "The path of the configuration of the HTTP(S) endpoints the external job plugin calls on job and pod hooks.")
 	fs.Uint32Var(&s.WorkerThreadsForQueue, "worker-threads-for-queue", defaultQueueWorkers, "The number of threads syncing queue operations. The larger the number, the faster the queue processing, but requires more CPU load.")
 	fs.StringSliceVar(&s.Controllers, "controllers", []string{defaultControllers}, fmt.Sprintf("Specify controller gates. Use '*' for all controllers, all knownController: %s ,and we can use "+
 		"'-' to disable controllers, e.g. \"-job-controller,-queue-controller\" to disable job and queue controllers.", knownControllers))
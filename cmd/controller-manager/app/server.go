@@ -147,6 +147,8 @@ func startControllers(config *rest.Config, opt *options.ServerOption) func(ctx c
 	controllerOpt.WorkerThreadsForPG = opt.WorkerThreadsForPG
 	controllerOpt.WorkerThreadsForQueue = opt.WorkerThreadsForQueue
 	controllerOpt.WorkerThreadsForGC = opt.WorkerThreadsForGC
+	controllerOpt.GCRetentionConfig = opt.GCRetentionConfig
+	controllerOpt.ExternalPluginConfig = opt.ExternalPluginConfig
 	controllerOpt.Config = config
 
 	return func(ctx context.Context) {
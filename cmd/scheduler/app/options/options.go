@@ -27,6 +27,7 @@ import (
 	componentbaseconfigvalidation "k8s.io/component-base/config/validation"
 
 	"volcano.sh/volcano/pkg/kube"
+	"volcano.sh/volcano/pkg/scheduler/sharding"
 )
 
 const (
@@ -47,6 +48,7 @@ const (
 	defaultPercentageOfNodesToFind    = 0
 	defaultLockObjectNamespace        = "volcano-system"
 	defaultNodeWorkers                = 20
+	defaultShardingLeaseDuration      = 15 * time.Second
 )
 
 // ServerOption is the main context object for the controller manager.
@@ -93,6 +95,13 @@ type ServerOption struct {
 	// not be counted in pod pvc resource request and node.Allocatable, because the spec.drivers of csinode resource
 	// is always null, these provisioners usually are host path csi controllers like rancher.io/local-path and hostpath.csi.k8s.io.
 	IgnoredCSIProvisioners []string
+
+	// ShardingMode enables several active schedulers, each of them owns a shard of queues or node pools.
+	ShardingMode string
+	// ShardingLeaseDuration is the duration of the lease held by each active scheduler of the shard group.
+	ShardingLeaseDuration time.Duration
+	// ShardingNodeLabel is the label of nodes whose value is the node pool sharded as a whole in node sharding mode.
+	ShardingNodeLabel string
 }
 
 // DecryptFunc is custom function to parse ca file
@@ -151,10 +160,19 @@ func (s *ServerOption) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&s.CacheDumpFileDir, "cache-dump-dir", "/tmp", "The target dir where the json file put at when dump cache info to json file")
 	fs.Uint32Var(&s.NodeWorkerThreads, "node-worker-threads", defaultNodeWorkers, "The number of threads syncing node operations.")
 	fs.StringSliceVar(&s.IgnoredCSIProvisioners, "ignored-provisioners", nil, "The provisioners that will be ignored during pod pvc request computation and preemption.")
+	fs.StringVar(&s.ShardingMode, "sharding-mode", sharding.ModeNone, "Run several active schedulers, each of them owns a shard of queues (queue) or node pools (node); leader election is skipped when it is set")
+	fs.DurationVar(&s.ShardingLeaseDuration, "sharding-lease-duration", defaultShardingLeaseDuration, "The duration of the lease held by each active scheduler, the shard of a scheduler is taken over by others once its lease expires")
+	fs.StringVar(&s.ShardingNodeLabel, "sharding-node-label", "", "The label of nodes whose value identifies the node pool in node sharding mode; each node is a pool on its own if it is empty")
 }
 
 // CheckOptionOrDie check leader election flag when LeaderElection is enabled.
 func (s *ServerOption) CheckOptionOrDie() error {
+	if !sharding.ValidMode(s.ShardingMode) {
+		return fmt.Errorf("invalid sharding mode %q, must be one of %q, %q", s.ShardingMode, sharding.ModeQueue, sharding.ModeNode)
+	}
+	if s.ShardingMode != sharding.ModeNone && s.ShardingLeaseDuration < time.Second {
+		return fmt.Errorf("sharding lease duration must be at least 1s, got %v", s.ShardingLeaseDuration)
+	}
 	return componentbaseconfigvalidation.ValidateLeaderElectionConfiguration(&s.LeaderElection, field.NewPath("leaderElection")).ToAggregate()
 }
 
@@ -29,6 +29,7 @@ import (
 	"volcano.sh/volcano/pkg/scheduler"
 	"volcano.sh/volcano/pkg/scheduler/framework"
 	"volcano.sh/volcano/pkg/scheduler/metrics"
+	"volcano.sh/volcano/pkg/scheduler/sharding"
 	"volcano.sh/volcano/pkg/signals"
 	commonutil "volcano.sh/volcano/pkg/util"
 
@@ -92,6 +93,14 @@ func Run(opt *options.ServerOption) error {
 		return fmt.Errorf("finished without leader elect")
 	}
 
+	// All schedulers of a shard group are active, the coordinator in the cache assigns the shards among them,
+	// and the cache rejects the binds which conflict with the other schedulers instead of a leader.
+	if opt.ShardingMode != sharding.ModeNone {
+		klog.Infof("Leader election is skipped in %s sharding mode", opt.ShardingMode)
+		run(ctx)
+		return fmt.Errorf("finished in %s sharding mode", opt.ShardingMode)
+	}
+
 	leaderElectionClient, err := clientset.NewForConfig(restclient.AddUserAgent(config, "leader-election"))
 	if err != nil {
 		return err
@@ -18,12 +18,17 @@ package job
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	"volcano.sh/apis/pkg/apis/bus/v1alpha1"
+	"volcano.sh/apis/pkg/client/clientset/versioned"
 	"volcano.sh/volcano/pkg/cli/util"
+	"volcano.sh/volcano/pkg/controllers/apis"
 )
 
 type suspendFlags struct {
@@ -31,6 +36,12 @@ type suspendFlags struct {
 
 	Namespace string
 	JobName   string
+
+	// ReleaseResources keeps the PodGroup and releases its resources back to the queue,
+	// the job is resumed in place with the same pod names.
+	ReleaseResources bool
+	// PreferPreviousNodes prefers the nodes the pods ran on when the job is resumed.
+	PreferPreviousNodes bool
 }
 
 var suspendJobFlags = &suspendFlags{}
@@ -41,6 +52,10 @@ func InitSuspendFlags(cmd *cobra.Command) {
 
 	cmd.Flags().StringVarP(&suspendJobFlags.Namespace, "namespace", "n", "default", "the namespace of job")
 	cmd.Flags().StringVarP(&suspendJobFlags.JobName, "name", "N", "", "the name of job")
+	cmd.Flags().BoolVarP(&suspendJobFlags.ReleaseResources, "release-resources", "r", false,
+		"keep the podgroup of the job and release its resources to the queue, the job is resumed in place")
+	cmd.Flags().BoolVar(&suspendJobFlags.PreferPreviousNodes, "prefer-previous-nodes", false,
+		"prefer the nodes the pods ran on when the job is resumed, only valid with --release-resources")
 }
 
 // SuspendJob suspends the job.
@@ -55,7 +70,34 @@ func SuspendJob(ctx context.Context) error {
 		return err
 	}
 
+	if !suspendJobFlags.ReleaseResources {
+		if suspendJobFlags.PreferPreviousNodes {
+			return fmt.Errorf("--prefer-previous-nodes is only valid with --release-resources")
+		}
+		return util.CreateJobCommand(ctx, config,
+			suspendJobFlags.Namespace, suspendJobFlags.JobName,
+			v1alpha1.AbortJobAction)
+	}
+
+	preferPreviousNodes := "false"
+	if suspendJobFlags.PreferPreviousNodes {
+		preferPreviousNodes = "true"
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{apis.ResumeOnPreviousNodesAnnotationKey: preferPreviousNodes},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	jobClient := versioned.NewForConfigOrDie(config)
+	if _, err := jobClient.BatchV1alpha1().Jobs(suspendJobFlags.Namespace).Patch(ctx,
+		suspendJobFlags.JobName, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return err
+	}
+
 	return util.CreateJobCommand(ctx, config,
 		suspendJobFlags.Namespace, suspendJobFlags.JobName,
-		v1alpha1.AbortJobAction)
+		apis.SuspendJobAction)
 }
@@ -60,19 +60,32 @@ func TestSuspendJobJob(t *testing.T) {
 	suspendJobFlags.JobName = "testjob"
 
 	testCases := []struct {
-		Name        string
-		ExpectValue error
+		Name                string
+		ReleaseResources    bool
+		PreferPreviousNodes bool
+		ExpectErr           bool
 	}{
 		{
-			Name:        "SuspendJob",
-			ExpectValue: nil,
+			Name: "SuspendJob",
+		},
+		{
+			Name:                "SuspendJobReleasingResources",
+			ReleaseResources:    true,
+			PreferPreviousNodes: true,
+		},
+		{
+			Name:                "PreferPreviousNodesWithoutReleasingResources",
+			PreferPreviousNodes: true,
+			ExpectErr:           true,
 		},
 	}
 
 	for i, testcase := range testCases {
+		suspendJobFlags.ReleaseResources = testcase.ReleaseResources
+		suspendJobFlags.PreferPreviousNodes = testcase.PreferPreviousNodes
 		err := SuspendJob(context.TODO())
-		if err != nil {
-			t.Errorf("case %d (%s): expected: %v, got %v ", i, testcase.Name, testcase.ExpectValue, err)
+		if (err != nil) != testcase.ExpectErr {
+			t.Errorf("case %d (%s): expected error: %v, got %v ", i, testcase.Name, testcase.ExpectErr, err)
 		}
 	}
 
@@ -88,5 +101,8 @@ func TestInitSuspendFlags(t *testing.T) {
 	if cmd.Flag("name") == nil {
 		t.Errorf("Could not find the flag name")
 	}
+	if cmd.Flag("release-resources") == nil {
+		t.Errorf("Could not find the flag release-resources")
+	}
 
 }
@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apis
+
+import (
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	"volcano.sh/apis/pkg/apis/bus/v1alpha1"
+)
+
+const (
+	// SuspendJobAction suspends the job and releases its resources back to the queue,
+	// unlike AbortJobAction the PodGroup is kept so that the job keeps its position in the queue.
+	SuspendJobAction v1alpha1.Action = "SuspendJob"
+
+	// JobSuspensionAnnotationKey is the annotation key of the SuspensionRecord of a job.
+	JobSuspensionAnnotationKey = "volcano.sh/suspension"
+	// ResumeOnPreviousNodesAnnotationKey is the annotation key to prefer the nodes the pods
+	// ran on before the job was suspended when the job is resumed, e.g. to reuse local caches.
+	ResumeOnPreviousNodesAnnotationKey = "volcano.sh/resume-on-previous-nodes"
+)
+
+// SuspensionRecord records the progress of a job when it is suspended.
+type SuspensionRecord struct {
+	SuspendedAt metav1.Time `json:"suspendedAt"`
+	// ResumedAt is set once the job is resumed, the record is kept until the job is suspended again.
+	ResumedAt *metav1.Time `json:"resumedAt,omitempty"`
+	// RetryCount is the retry count of the job when it is suspended.
+	RetryCount int32 `json:"retryCount"`
+	// Tasks is the progress of each task.
+	Tasks map[string]TaskProgress `json:"tasks,omitempty"`
+}
+
+// TaskProgress records the pods of a task when the job is suspended.
+type TaskProgress struct {
+	// Succeeded is the indices of the succeeded pods, they are kept while the job is suspended.
+	Succeeded []int `json:"succeeded,omitempty"`
+	// Failed is the indices of the failed pods.
+	Failed []int `json:"failed,omitempty"`
+	// Nodes is the node each pod ran on, keyed by pod name.
+	Nodes map[string]string `json:"nodes,omitempty"`
+}
+
+// GetSuspensionRecord returns the suspension record of the job, or nil if the job has never been suspended.
+func GetSuspensionRecord(job *batch.Job) (*SuspensionRecord, error) {
+	value, found := job.Annotations[JobSuspensionAnnotationKey]
+	if !found {
+		return nil, nil
+	}
+	record := &SuspensionRecord{}
+	if err := json.Unmarshal([]byte(value), record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// SetSuspensionRecord writes the suspension record to the annotations of the job.
+func SetSuspensionRecord(job *batch.Job, record *SuspensionRecord) error {
+	value, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if job.Annotations == nil {
+		job.Annotations = map[string]string{}
+	}
+	job.Annotations[JobSuspensionAnnotationKey] = string(value)
+	return nil
+}
+
+// IsSuspended returns whether the job is suspended and not resumed yet.
+func IsSuspended(job *batch.Job) bool {
+	record, err := GetSuspensionRecord(job)
+	return err == nil && record != nil && record.ResumedAt == nil
+}
+
+// IsResuming returns whether the job is suspended or is being resumed in place,
+// i.e. it has not transitioned to another phase since it was resumed.
+func IsResuming(job *batch.Job) bool {
+	record, err := GetSuspensionRecord(job)
+	if err != nil || record == nil {
+		return false
+	}
+	return record.ResumedAt == nil || !record.ResumedAt.Before(&job.Status.State.LastTransitionTime)
+}
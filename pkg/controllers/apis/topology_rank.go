@@ -0,0 +1,56 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apis
+
+import (
+	"strings"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+)
+
+const (
+	// TopologyAwareRankAnnotationKey is the annotation key to assign the ranks of the pods of a job after they are
+	// scheduled, so that neighboring ranks share a HyperNode. The value is the tasks to rank in rank order, e.g. "master,worker".
+	TopologyAwareRankAnnotationKey = "volcano.sh/topology-aware-rank"
+	// TopologyRankAnnotationKey is the annotation key of the rank assigned to a pod.
+	TopologyRankAnnotationKey = "volcano.sh/topology-rank"
+
+	// TopologyRankMountPath is the directory the rank and the hostfile are mounted to.
+	TopologyRankMountPath = "/etc/volcano/topology"
+	// TopologyRankFile is the file of the rank of the pod, projected from TopologyRankAnnotationKey.
+	TopologyRankFile = "rank"
+	// TopologyHostfile is the file of the hosts of the ranked pods, one host per line in rank order.
+	TopologyHostfile = "hostfile"
+
+	// EnvTopologyRankFile is the env name of the path of the rank file.
+	EnvTopologyRankFile = "VC_TOPOLOGY_RANK_FILE"
+	// EnvTopologyHostfile is the env name of the path of the hostfile.
+	EnvTopologyHostfile = "VC_TOPOLOGY_HOSTFILE"
+)
+
+// TopologyRankedTasks returns the tasks whose pods are ranked by topology in rank order,
+// or nil if topology aware rank is not enabled for the job.
+func TopologyRankedTasks(job *batch.Job) []string {
+	value := job.Annotations[TopologyAwareRankAnnotationKey]
+	var tasks []string
+	for _, task := range strings.Split(value, ",") {
+		if task = strings.TrimSpace(task); task != "" {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks
+}
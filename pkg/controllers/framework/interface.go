@@ -39,6 +39,10 @@ type ControllerOption struct {
 	WorkerThreadsForPG      uint32
 	WorkerThreadsForQueue   uint32
 	WorkerThreadsForGC      uint32
+	// GCRetentionConfig is the path of the retention configuration of the garbage collector.
+	GCRetentionConfig string
+	// ExternalPluginConfig is the path of the configuration of the endpoints called by the external job plugin.
+	ExternalPluginConfig string
 
 	// Config holds the common attributes that can be passed to a Kubernetes client
 	// and controllers registered by the users can use it.
@@ -0,0 +1,75 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+)
+
+// Record is the archived state of a Job, it is written to the sink before the Job is deleted.
+type Record struct {
+	// ArchivedAt is the time the record is created.
+	ArchivedAt metav1.Time `json:"archivedAt"`
+	// Job holds the spec and status of the Job.
+	Job *v1alpha1.Job `json:"job"`
+	// Events are the events whose involved object is the Job.
+	Events []v1.Event `json:"events,omitempty"`
+}
+
+// Sink stores the records of Jobs removed by the garbage collector,
+// e.g. on the local filesystem, in a ConfigMap or in an object store.
+type Sink interface {
+	// Name returns the name of the sink.
+	Name() string
+	// Archive stores the record, the Job is only deleted after Archive succeeds.
+	Archive(ctx context.Context, record *Record) error
+}
+
+// Factory builds a Sink with the options from the retention configuration.
+type Factory func(options map[string]string) (Sink, error)
+
+var (
+	mutex     sync.RWMutex
+	factories = map[string]Factory{}
+)
+
+// RegisterSink registers a sink factory with the name used in the retention configuration.
+func RegisterSink(name string, factory Factory) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	factories[name] = factory
+}
+
+// New builds the sink registered with name.
+func New(name string, options map[string]string) (Sink, error) {
+	mutex.RLock()
+	factory, found := factories[name]
+	mutex.RUnlock()
+
+	if !found {
+		return nil, fmt.Errorf("archive sink <%s> is not registered", name)
+	}
+	return factory(options)
+}
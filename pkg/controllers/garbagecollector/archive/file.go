@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// FileSinkName is the name of the local filesystem sink.
+	FileSinkName = "file"
+	// DirectoryOption is the option of the directory records are written to.
+	DirectoryOption = "directory"
+)
+
+func init() {
+	RegisterSink(FileSinkName, NewFileSink)
+}
+
+// fileSink writes each record as a JSON file named <namespace>/<name>-<uid>.json under the directory.
+type fileSink struct {
+	directory string
+}
+
+// NewFileSink creates a sink writing records to the local filesystem.
+func NewFileSink(options map[string]string) (Sink, error) {
+	directory := options[DirectoryOption]
+	if directory == "" {
+		return nil, fmt.Errorf("option <%s> is required by archive sink <%s>", DirectoryOption, FileSinkName)
+	}
+	if err := os.MkdirAll(directory, 0o750); err != nil {
+		return nil, err
+	}
+	return &fileSink{directory: directory}, nil
+}
+
+func (f *fileSink) Name() string {
+	return FileSinkName
+}
+
+func (f *fileSink) Archive(_ context.Context, record *Record) error {
+	job := record.Job
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(f.directory, job.Namespace)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so that a partially written record is never left behind.
+	tmp, err := os.CreateTemp(dir, ".archive-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(dir, fmt.Sprintf("%s-%s.json", job.Name, job.UID)))
+}
@@ -19,11 +19,16 @@ package garbagecollector
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
+	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
@@ -34,6 +39,7 @@ import (
 	batchinformers "volcano.sh/apis/pkg/client/informers/externalversions/batch/v1alpha1"
 	batchlisters "volcano.sh/apis/pkg/client/listers/batch/v1alpha1"
 	"volcano.sh/volcano/pkg/controllers/framework"
+	"volcano.sh/volcano/pkg/controllers/garbagecollector/archive"
 )
 
 func init() {
@@ -42,15 +48,18 @@ func init() {
 
 // gccontroller runs reflectors to watch for changes of managed API
 // objects. Currently it only watches Jobs. Triggered by Job creation
-// and updates, it enqueues Jobs that have non-nil `.spec.ttlSecondsAfterFinished`
-// to the `queue`. The gccontroller has workers who consume `queue`, check whether
+// and updates, it enqueues finished Jobs that have non-nil `.spec.ttlSecondsAfterFinished`
+// or match a retention policy to the `queue`. The gccontroller has workers who consume `queue`, check whether
 // the Job TTL has expired or not; if the Job TTL hasn't expired, it will add the
 // Job to the queue after the TTL is expected to expire; if the TTL has expired, the
 // worker will send requests to the API server to delete the Jobs accordingly.
+// Retention policies add per phase TTLs and history limits, and archive Jobs
+// to a sink before they are deleted.
 // This is implemented outside of Job controller for separation of concerns, and
 // because it will be extended to handle other finishable resource types.
 type gccontroller struct {
-	vcClient vcclientset.Interface
+	kubeClient kubernetes.Interface
+	vcClient   vcclientset.Interface
 
 	jobInformer batchinformers.JobInformer
 
@@ -64,6 +73,9 @@ type gccontroller struct {
 	queue workqueue.TypedRateLimitingInterface[string]
 
 	workers uint32
+
+	retention *RetentionConfiguration
+	sink      archive.Sink
 }
 
 func (gc *gccontroller) Name() string {
@@ -72,6 +84,18 @@ func (gc *gccontroller) Name() string {
 
 // Initialize creates an instance of gccontroller.
 func (gc *gccontroller) Initialize(opt *framework.ControllerOption) error {
+	retention, err := loadRetentionConfiguration(opt.GCRetentionConfig)
+	if err != nil {
+		return err
+	}
+	gc.retention = retention
+	if retention != nil && retention.Archive != nil {
+		if gc.sink, err = archive.New(retention.Archive.Sink, retention.Archive.Options); err != nil {
+			return err
+		}
+	}
+
+	gc.kubeClient = opt.KubeClient
 	gc.vcClient = opt.VolcanoClient
 
 	factory := opt.VCSharedInformerFactory
@@ -118,7 +142,7 @@ func (gc *gccontroller) addJob(obj interface{}) {
 	job := obj.(*v1alpha1.Job)
 	klog.V(4).Infof("Adding job %s/%s", job.Namespace, job.Name)
 
-	if job.DeletionTimestamp == nil && needsCleanup(job) {
+	if job.DeletionTimestamp == nil && needsCleanup(job, gc.retention.policyFor(job)) {
 		gc.enqueue(job)
 	}
 }
@@ -127,7 +151,7 @@ func (gc *gccontroller) updateJob(old, cur interface{}) {
 	job := cur.(*v1alpha1.Job)
 	klog.V(4).Infof("Updating job %s/%s", job.Namespace, job.Name)
 
-	if job.DeletionTimestamp == nil && needsCleanup(job) {
+	if job.DeletionTimestamp == nil && needsCleanup(job, gc.retention.policyFor(job)) {
 		gc.enqueue(job)
 	}
 }
@@ -182,9 +206,9 @@ func (gc *gccontroller) handleErr(err error, key string) {
 }
 
 // processJob will check the Job's state and TTL and delete the Job when it
-// finishes and its TTL after finished has expired. If the Job hasn't finished or
-// its TTL hasn't expired, it will be added to the queue after the TTL is expected
-// to expire.
+// finishes and its TTL after finished has expired, or when it exceeds the history
+// limit of its retention policy. If the Job hasn't finished or its TTL hasn't expired,
+// it will be added to the queue after the TTL is expected to expire.
 // This function is not meant to be invoked concurrently with the same key.
 func (gc *gccontroller) processJob(key string) error {
 	namespace, name, err := cache.SplitMetaNamespaceKey(key)
@@ -202,7 +226,7 @@ func (gc *gccontroller) processJob(key string) error {
 		return err
 	}
 
-	if expired, err := gc.processTTL(job); err != nil {
+	if expired, err := gc.processRetention(job); err != nil {
 		return err
 	} else if !expired {
 		return nil
@@ -220,11 +244,15 @@ func (gc *gccontroller) processJob(key string) error {
 		return err
 	}
 	// Use the latest Job TTL to see if the TTL truly expires.
-	if expired, err := gc.processTTL(fresh); err != nil {
+	if expired, err := gc.processRetention(fresh); err != nil {
 		return err
 	} else if !expired {
 		return nil
 	}
+	// Keep the Job until it is archived, the archival is retried with the Job.
+	if err := gc.archive(fresh); err != nil {
+		return fmt.Errorf("failed to archive Job %s/%s: %v", namespace, name, err)
+	}
 	// Cascade deletes the Jobs if TTL truly expires.
 	policy := metav1.DeletePropagationForeground
 	options := metav1.DeleteOptions{
@@ -240,16 +268,25 @@ func (gc *gccontroller) processJob(key string) error {
 	return err
 }
 
+// processRetention checks whether a given Job's TTL has expired or the Job exceeds the history limit.
+func (gc *gccontroller) processRetention(job *v1alpha1.Job) (bool, error) {
+	if expired, err := gc.processTTL(job); err != nil || expired {
+		return expired, err
+	}
+	return gc.processHistoryLimit(job), nil
+}
+
 // processTTL checks whether a given Job's TTL has expired, and add it to the queue after the TTL is expected to expire
 // if the TTL will expire later.
 func (gc *gccontroller) processTTL(job *v1alpha1.Job) (expired bool, err error) {
 	// We don't care about the Jobs that are going to be deleted, or the ones that don't need clean up.
-	if job.DeletionTimestamp != nil || !needsCleanup(job) {
+	ttl := ttlAfterFinished(job, gc.retention.policyFor(job))
+	if job.DeletionTimestamp != nil || ttl == nil || !isJobFinished(job) {
 		return false, nil
 	}
 
 	now := time.Now()
-	t, err := timeLeft(job, &now)
+	t, err := timeLeft(job, ttl, &now)
 	if err != nil {
 		return false, err
 	}
@@ -263,9 +300,99 @@ func (gc *gccontroller) processTTL(job *v1alpha1.Job) (expired bool, err error)
 	return false, nil
 }
 
-// needsCleanup checks whether a Job has finished and has a TTL set.
-func needsCleanup(j *v1alpha1.Job) bool {
-	return j.Spec.TTLSecondsAfterFinished != nil && isJobFinished(j)
+// processHistoryLimit checks whether a given Job is older than the finished Jobs kept by its retention policy.
+// The other Jobs exceeding the limit are added to the queue, e.g. when a newer Job finishes.
+func (gc *gccontroller) processHistoryLimit(job *v1alpha1.Job) bool {
+	policy := gc.retention.policyFor(job)
+	limit := historyLimit(job, policy)
+	if job.DeletionTimestamp != nil || limit == nil || !isJobFinished(job) {
+		return false
+	}
+
+	jobs, err := gc.jobLister.Jobs(job.Namespace).List(labels.Everything())
+	if err != nil {
+		klog.Errorf("Failed to list Jobs in namespace %s: %v", job.Namespace, err)
+		return false
+	}
+	var history []*v1alpha1.Job
+	for _, j := range jobs {
+		if j.UID == job.UID || j.DeletionTimestamp != nil || !isJobFinished(j) || !sameHistory(j, job) {
+			continue
+		}
+		if gc.retention.policyFor(j) == policy {
+			history = append(history, j)
+		}
+	}
+	history = append(history, job)
+	// Newest first, the Jobs after the limit are deleted.
+	sort.SliceStable(history, func(i, k int) bool {
+		ti, tk := history[i].Status.State.LastTransitionTime, history[k].Status.State.LastTransitionTime
+		if !ti.Equal(&tk) {
+			return tk.Before(&ti)
+		}
+		return history[i].Name < history[k].Name
+	})
+
+	exceeded := false
+	for i := int(*limit); i < len(history); i++ {
+		if history[i].UID == job.UID {
+			exceeded = true
+			continue
+		}
+		gc.enqueue(history[i])
+	}
+	if exceeded {
+		klog.V(4).Infof("Job %s/%s exceeds the history limit %d of retention policy <%s>", job.Namespace, job.Name, *limit, policy.Name)
+	}
+	return exceeded
+}
+
+// archive writes the Job and its events to the sink if its retention policy requires it.
+func (gc *gccontroller) archive(job *v1alpha1.Job) error {
+	policy := gc.retention.policyFor(job)
+	if policy == nil || !policy.Archive || gc.sink == nil {
+		return nil
+	}
+
+	record := &archive.Record{
+		ArchivedAt: metav1.Now(),
+		Job:        job.DeepCopy(),
+	}
+	record.Job.APIVersion = v1alpha1.SchemeGroupVersion.String()
+	record.Job.Kind = "Job"
+	if gc.kubeClient != nil {
+		events, err := gc.kubeClient.CoreV1().Events(job.Namespace).List(context.TODO(), metav1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector("involvedObject.uid", string(job.UID)).String(),
+		})
+		if err != nil {
+			return err
+		}
+		record.Events = events.Items
+		sort.SliceStable(record.Events, func(i, k int) bool {
+			return eventTime(&record.Events[i]).Before(eventTime(&record.Events[k]))
+		})
+	}
+
+	klog.V(4).Infof("Archiving Job %s/%s to sink <%s>", job.Namespace, job.Name, gc.sink.Name())
+	return gc.sink.Archive(context.TODO(), record)
+}
+
+func eventTime(e *v1.Event) time.Time {
+	if !e.LastTimestamp.IsZero() {
+		return e.LastTimestamp.Time
+	}
+	if !e.EventTime.IsZero() {
+		return e.EventTime.Time
+	}
+	return e.CreationTimestamp.Time
+}
+
+// needsCleanup checks whether a Job has finished and has a TTL set or a history limit from its retention policy.
+func needsCleanup(j *v1alpha1.Job, policy *RetentionPolicy) bool {
+	if !isJobFinished(j) {
+		return false
+	}
+	return ttlAfterFinished(j, policy) != nil || historyLimit(j, policy) != nil
 }
 
 func isJobFinished(job *v1alpha1.Job) bool {
@@ -274,8 +401,8 @@ func isJobFinished(job *v1alpha1.Job) bool {
 		job.Status.State.Phase == v1alpha1.Terminated
 }
 
-func getFinishAndExpireTime(j *v1alpha1.Job) (*time.Time, *time.Time, error) {
-	if !needsCleanup(j) {
+func getFinishAndExpireTime(j *v1alpha1.Job, ttl *int32) (*time.Time, *time.Time, error) {
+	if ttl == nil || !isJobFinished(j) {
 		return nil, nil, fmt.Errorf("job %s/%s should not be cleaned up", j.Namespace, j.Name)
 	}
 	finishAt, err := jobFinishTime(j)
@@ -283,12 +410,12 @@ func getFinishAndExpireTime(j *v1alpha1.Job) (*time.Time, *time.Time, error) {
 		return nil, nil, err
 	}
 	finishAtUTC := finishAt.UTC()
-	expireAtUTC := finishAtUTC.Add(time.Duration(*j.Spec.TTLSecondsAfterFinished) * time.Second)
+	expireAtUTC := finishAtUTC.Add(time.Duration(*ttl) * time.Second)
 	return &finishAtUTC, &expireAtUTC, nil
 }
 
-func timeLeft(j *v1alpha1.Job, since *time.Time) (*time.Duration, error) {
-	finishAt, expireAt, err := getFinishAndExpireTime(j)
+func timeLeft(j *v1alpha1.Job, ttl *int32, since *time.Time) (*time.Duration, error) {
+	finishAt, expireAt, err := getFinishAndExpireTime(j, ttl)
 	if err != nil {
 		return nil, err
 	}
@@ -160,7 +160,7 @@ func TestGarbageCollector_NeedsCleanup(t *testing.T) {
 	}
 
 	for i, testcase := range testcases {
-		finished := needsCleanup(testcase.Job)
+		finished := needsCleanup(testcase.Job, nil)
 		if finished != testcase.ExpectedVal {
 			t.Errorf("Expected value to be %t, but got: %t in case %d", testcase.ExpectedVal, finished, i)
 		}
@@ -269,7 +269,7 @@ func TestGarbageCollector_GetFinishAndExpireTime(t *testing.T) {
 	}
 
 	for i, testcase := range testcases {
-		finishTime, expireTime, err := getFinishAndExpireTime(testcase.Job)
+		finishTime, expireTime, err := getFinishAndExpireTime(testcase.Job, testcase.Job.Spec.TTLSecondsAfterFinished)
 		if err != nil && err.Error() != testcase.ExpectedErr.Error() {
 			t.Errorf("Expected Error to be: %s but got: %s in case %d", testcase.ExpectedErr, err, i)
 		}
@@ -342,7 +342,7 @@ func TestGarbageCollector_TimeLeft(t *testing.T) {
 	}
 
 	for i, testcase := range testcases {
-		timeDuration, err := timeLeft(testcase.Job, testcase.Time)
+		timeDuration, err := timeLeft(testcase.Job, testcase.Job.Spec.TTLSecondsAfterFinished, testcase.Time)
 		if err != nil && err.Error() != testcase.ExpectedErr.Error() {
 			t.Errorf("Expected Error to be: %s but got: %s in case %d", testcase.ExpectedErr, err, i)
 		}
@@ -0,0 +1,181 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package garbagecollector
+
+import (
+	"fmt"
+	"os"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	"volcano.sh/volcano/pkg/controllers/garbagecollector/archive"
+)
+
+// RetentionConfiguration is the retention configuration of the garbage collector.
+type RetentionConfiguration struct {
+	// Archive configures the sink finished Jobs are archived to before deletion.
+	Archive *ArchiveConfiguration `json:"archive,omitempty"`
+	// Policies are matched against finished Jobs in order, the first matching one applies.
+	Policies []RetentionPolicy `json:"policies,omitempty"`
+}
+
+// ArchiveConfiguration selects a registered archive sink and its options.
+type ArchiveConfiguration struct {
+	// Sink is the name of the sink, defaults to the local filesystem sink.
+	Sink    string            `json:"sink,omitempty"`
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// RetentionPolicy defines how long the finished Jobs it matches are kept.
+// A Job that sets `.spec.ttlSecondsAfterFinished` keeps its own TTL,
+// the history limits and archival still apply to it.
+type RetentionPolicy struct {
+	Name string `json:"name"`
+	// Namespaces, Queues and Selector restrict the Jobs the policy matches, an empty field matches all.
+	Namespaces []string              `json:"namespaces,omitempty"`
+	Queues     []string              `json:"queues,omitempty"`
+	Selector   *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// TTLSecondsAfterCompleted is the TTL of Completed Jobs.
+	TTLSecondsAfterCompleted *int32 `json:"ttlSecondsAfterCompleted,omitempty"`
+	// TTLSecondsAfterFailed is the TTL of Failed and Terminated Jobs.
+	TTLSecondsAfterFailed *int32 `json:"ttlSecondsAfterFailed,omitempty"`
+
+	// CompletedJobsHistoryLimit is the number of Completed Jobs kept per namespace, older ones are deleted.
+	CompletedJobsHistoryLimit *int32 `json:"completedJobsHistoryLimit,omitempty"`
+	// FailedJobsHistoryLimit is the number of Failed and Terminated Jobs kept per namespace, older ones are deleted.
+	FailedJobsHistoryLimit *int32 `json:"failedJobsHistoryLimit,omitempty"`
+
+	// Archive the Job spec, status and events to the sink before deleting the Job.
+	Archive bool `json:"archive,omitempty"`
+
+	namespaces sets.Set[string]
+	queues     sets.Set[string]
+	selector   labels.Selector
+}
+
+// loadRetentionConfiguration reads the retention configuration from file,
+// it returns nil if no file is set.
+func loadRetentionConfiguration(file string) (*RetentionConfiguration, error) {
+	if file == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read retention configuration %s: %v", file, err)
+	}
+	return parseRetentionConfiguration(data)
+}
+
+func parseRetentionConfiguration(data []byte) (*RetentionConfiguration, error) {
+	conf := &RetentionConfiguration{}
+	if err := yaml.UnmarshalStrict(data, conf); err != nil {
+		return nil, fmt.Errorf("failed to parse retention configuration: %v", err)
+	}
+
+	archiveRequired := false
+	for i := range conf.Policies {
+		policy := &conf.Policies[i]
+		if policy.Name == "" {
+			return nil, fmt.Errorf("the name of retention policy %d is empty", i)
+		}
+		for field, value := range map[string]*int32{
+			"ttlSecondsAfterCompleted":  policy.TTLSecondsAfterCompleted,
+			"ttlSecondsAfterFailed":     policy.TTLSecondsAfterFailed,
+			"completedJobsHistoryLimit": policy.CompletedJobsHistoryLimit,
+			"failedJobsHistoryLimit":    policy.FailedJobsHistoryLimit,
+		} {
+			if value != nil && *value < 0 {
+				return nil, fmt.Errorf("%s of retention policy <%s> must not be negative", field, policy.Name)
+			}
+		}
+
+		policy.namespaces = sets.New(policy.Namespaces...)
+		policy.queues = sets.New(policy.Queues...)
+		policy.selector = labels.Everything()
+		if policy.Selector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(policy.Selector)
+			if err != nil {
+				return nil, fmt.Errorf("invalid selector of retention policy <%s>: %v", policy.Name, err)
+			}
+			policy.selector = selector
+		}
+		archiveRequired = archiveRequired || policy.Archive
+	}
+
+	if archiveRequired && conf.Archive == nil {
+		return nil, fmt.Errorf("archive sink is not configured but required by retention policies")
+	}
+	if conf.Archive != nil && conf.Archive.Sink == "" {
+		conf.Archive.Sink = archive.FileSinkName
+	}
+	return conf, nil
+}
+
+// policyFor returns the first policy matching the Job, or nil if none matches.
+func (c *RetentionConfiguration) policyFor(job *v1alpha1.Job) *RetentionPolicy {
+	if c == nil {
+		return nil
+	}
+	for i := range c.Policies {
+		if c.Policies[i].matches(job) {
+			return &c.Policies[i]
+		}
+	}
+	return nil
+}
+
+func (p *RetentionPolicy) matches(job *v1alpha1.Job) bool {
+	if p.namespaces.Len() > 0 && !p.namespaces.Has(job.Namespace) {
+		return false
+	}
+	if p.queues.Len() > 0 && !p.queues.Has(job.Spec.Queue) {
+		return false
+	}
+	return p.selector.Matches(labels.Set(job.Labels))
+}
+
+// ttlAfterFinished returns the TTL of a finished Job, the Job's own TTL takes precedence over the policy.
+func ttlAfterFinished(job *v1alpha1.Job, policy *RetentionPolicy) *int32 {
+	if job.Spec.TTLSecondsAfterFinished != nil || policy == nil {
+		return job.Spec.TTLSecondsAfterFinished
+	}
+	if job.Status.State.Phase == v1alpha1.Completed {
+		return policy.TTLSecondsAfterCompleted
+	}
+	return policy.TTLSecondsAfterFailed
+}
+
+// historyLimit returns the number of finished Jobs in the same phase kept by the policy.
+func historyLimit(job *v1alpha1.Job, policy *RetentionPolicy) *int32 {
+	if policy == nil {
+		return nil
+	}
+	if job.Status.State.Phase == v1alpha1.Completed {
+		return policy.CompletedJobsHistoryLimit
+	}
+	return policy.FailedJobsHistoryLimit
+}
+
+// sameHistory returns whether two finished Jobs are counted against the same history limit.
+func sameHistory(a, b *v1alpha1.Job) bool {
+	return (a.Status.State.Phase == v1alpha1.Completed) == (b.Status.State.Phase == v1alpha1.Completed)
+}
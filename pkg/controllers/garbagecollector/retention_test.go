@@ -0,0 +1,293 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package garbagecollector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kubeclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	volcanoclient "volcano.sh/apis/pkg/client/clientset/versioned/fake"
+	informerfactory "volcano.sh/apis/pkg/client/informers/externalversions"
+	"volcano.sh/volcano/pkg/controllers/framework"
+	"volcano.sh/volcano/pkg/controllers/garbagecollector/archive"
+)
+
+func newFinishedJob(name, queue string, phase v1alpha1.JobPhase, finishedAt time.Time) *v1alpha1.Job {
+	return &v1alpha1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+			UID:       types.UID(name),
+			Labels:    map[string]string{"team": "audit"},
+		},
+		Spec: v1alpha1.JobSpec{Queue: queue},
+		Status: v1alpha1.JobStatus{
+			State: v1alpha1.JobState{
+				Phase:              phase,
+				LastTransitionTime: metav1.NewTime(finishedAt),
+			},
+		},
+	}
+}
+
+func newRetentionController(t *testing.T, config string, jobs ...*v1alpha1.Job) (*gccontroller, *volcanoclient.Clientset) {
+	file := filepath.Join(t.TempDir(), "retention.yaml")
+	if err := os.WriteFile(file, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	volcanoClientSet := volcanoclient.NewSimpleClientset()
+	vcSharedInformers := informerfactory.NewSharedInformerFactory(volcanoClientSet, 0)
+	controller := &gccontroller{}
+	opt := &framework.ControllerOption{
+		KubeClient:              kubeclient.NewSimpleClientset(),
+		VolcanoClient:           volcanoClientSet,
+		VCSharedInformerFactory: vcSharedInformers,
+		GCRetentionConfig:       file,
+	}
+	if err := controller.Initialize(opt); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, job := range jobs {
+		if _, err := volcanoClientSet.BatchV1alpha1().Jobs(job.Namespace).Create(context.TODO(), job, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if err := controller.jobInformer.Informer().GetIndexer().Add(job); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return controller, volcanoClientSet
+}
+
+func TestParseRetentionConfiguration(t *testing.T) {
+	testcases := []struct {
+		Name        string
+		Config      string
+		ExpectedErr bool
+	}{
+		{
+			Name: "valid policies",
+			Config: `
+archive:
+  options:
+    directory: /tmp
+policies:
+- name: audit
+  queues: [default]
+  selector:
+    matchLabels:
+      team: audit
+  ttlSecondsAfterCompleted: 60
+  ttlSecondsAfterFailed: 3600
+  archive: true
+`,
+		},
+		{
+			Name: "negative history limit",
+			Config: `
+policies:
+- name: audit
+  failedJobsHistoryLimit: -1
+`,
+			ExpectedErr: true,
+		},
+		{
+			Name: "archive without sink",
+			Config: `
+policies:
+- name: audit
+  archive: true
+`,
+			ExpectedErr: true,
+		},
+		{
+			Name: "unknown field",
+			Config: `
+policies:
+- name: audit
+  ttl: 10
+`,
+			ExpectedErr: true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		conf, err := parseRetentionConfiguration([]byte(testcase.Config))
+		if (err != nil) != testcase.ExpectedErr {
+			t.Errorf("%s: expected error %v but got %v", testcase.Name, testcase.ExpectedErr, err)
+		}
+		if err == nil && conf.Archive != nil && conf.Archive.Sink != archive.FileSinkName {
+			t.Errorf("%s: expected default sink %s but got %s", testcase.Name, archive.FileSinkName, conf.Archive.Sink)
+		}
+	}
+}
+
+func TestGarbageCollector_TTLPerPhase(t *testing.T) {
+	conf, err := parseRetentionConfiguration([]byte(`
+policies:
+- name: audit
+  queues: [default]
+  ttlSecondsAfterCompleted: 60
+  ttlSecondsAfterFailed: 3600
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	testcases := []struct {
+		Name        string
+		Job         *v1alpha1.Job
+		ExpectedTTL *int32
+	}{
+		{
+			Name:        "completed job",
+			Job:         newFinishedJob("job1", "default", v1alpha1.Completed, now),
+			ExpectedTTL: ptr.To[int32](60),
+		},
+		{
+			Name:        "failed job",
+			Job:         newFinishedJob("job2", "default", v1alpha1.Failed, now),
+			ExpectedTTL: ptr.To[int32](3600),
+		},
+		{
+			Name:        "terminated job",
+			Job:         newFinishedJob("job3", "default", v1alpha1.Terminated, now),
+			ExpectedTTL: ptr.To[int32](3600),
+		},
+		{
+			Name: "job ttl takes precedence",
+			Job: func() *v1alpha1.Job {
+				job := newFinishedJob("job4", "default", v1alpha1.Failed, now)
+				job.Spec.TTLSecondsAfterFinished = ptr.To[int32](5)
+				return job
+			}(),
+			ExpectedTTL: ptr.To[int32](5),
+		},
+		{
+			Name: "job of other queue",
+			Job:  newFinishedJob("job5", "other", v1alpha1.Failed, now),
+		},
+	}
+
+	for _, testcase := range testcases {
+		policy := conf.policyFor(testcase.Job)
+		ttl := ttlAfterFinished(testcase.Job, policy)
+		if !ptr.Equal(ttl, testcase.ExpectedTTL) {
+			t.Errorf("%s: expected ttl %v but got %v", testcase.Name, ptr.Deref(testcase.ExpectedTTL, -1), ptr.Deref(ttl, -1))
+		}
+		if needsCleanup(testcase.Job, policy) != (testcase.ExpectedTTL != nil) {
+			t.Errorf("%s: expected needsCleanup to be %v", testcase.Name, testcase.ExpectedTTL != nil)
+		}
+	}
+}
+
+func TestGarbageCollector_HistoryLimit(t *testing.T) {
+	now := time.Now()
+	var jobs []*v1alpha1.Job
+	for i := 0; i < 3; i++ {
+		jobs = append(jobs, newFinishedJob(fmt.Sprintf("completed-%d", i), "default", v1alpha1.Completed, now.Add(time.Duration(i)*time.Minute)))
+		jobs = append(jobs, newFinishedJob(fmt.Sprintf("failed-%d", i), "default", v1alpha1.Failed, now.Add(time.Duration(i)*time.Minute)))
+	}
+	gc, client := newRetentionController(t, `
+policies:
+- name: audit
+  completedJobsHistoryLimit: 1
+  failedJobsHistoryLimit: 2
+`, jobs...)
+
+	for _, job := range jobs {
+		if err := gc.processJob(job.Namespace + "/" + job.Name); err != nil {
+			t.Fatalf("failed to process job %s: %v", job.Name, err)
+		}
+	}
+
+	remaining, err := client.BatchV1alpha1().Jobs("test").List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, job := range remaining.Items {
+		names = append(names, job.Name)
+	}
+	expected := []string{"completed-2", "failed-1", "failed-2"}
+	if fmt.Sprint(names) != fmt.Sprint(expected) {
+		t.Errorf("expected remaining jobs %v but got %v", expected, names)
+	}
+}
+
+func TestGarbageCollector_Archive(t *testing.T) {
+	dir := t.TempDir()
+	job := newFinishedJob("job1", "default", v1alpha1.Failed, time.Now().Add(-time.Hour))
+	gc, client := newRetentionController(t, fmt.Sprintf(`
+archive:
+  sink: file
+  options:
+    directory: %s
+policies:
+- name: audit
+  selector:
+    matchLabels:
+      team: audit
+  ttlSecondsAfterFailed: 60
+  archive: true
+`, dir), job)
+
+	event := &v1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "job1.event", Namespace: "test"},
+		InvolvedObject: v1.ObjectReference{Kind: "Job", Namespace: "test", Name: "job1", UID: job.UID},
+		Reason:         "PodGroupPending",
+	}
+	if _, err := gc.kubeClient.CoreV1().Events("test").Create(context.TODO(), event, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := gc.processJob("test/job1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.BatchV1alpha1().Jobs("test").Get(context.TODO(), "job1", metav1.GetOptions{}); err == nil {
+		t.Errorf("expected job1 to be deleted")
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "test", "job1-job1.json"))
+	if err != nil {
+		t.Fatalf("expected job1 to be archived: %v", err)
+	}
+	record := &archive.Record{}
+	if err := json.Unmarshal(data, record); err != nil {
+		t.Fatal(err)
+	}
+	if record.Job.Status.State.Phase != v1alpha1.Failed || record.Job.Spec.Queue != "default" {
+		t.Errorf("unexpected archived job %v", record.Job)
+	}
+	if len(record.Events) != 1 || record.Events[0].Reason != "PodGroupPending" {
+		t.Errorf("unexpected archived events %v", record.Events)
+	}
+}
@@ -30,4 +30,10 @@ const (
 	// SuccessfulDeletePodReason is added in an event when a pod for a replica set
 	// is successfully deleted.
 	SuccessfulDeletePodReason = "SuccessfulDelete"
+	// TaskRestartBudgetExceededReason is added in an event when a task exceeds its restart
+	// budget or is crash looping, and the restart is escalated to another action.
+	TaskRestartBudgetExceededReason = "TaskRestartBudgetExceeded"
+	// TaskStartupTimeoutReason is added in an event when the dependencies of a task
+	// are not started within the timeout of its startup policy.
+	TaskStartupTimeoutReason = "TaskStartupTimeout"
 )
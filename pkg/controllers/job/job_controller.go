@@ -47,13 +47,16 @@ import (
 	batchinformer "volcano.sh/apis/pkg/client/informers/externalversions/batch/v1alpha1"
 	businformer "volcano.sh/apis/pkg/client/informers/externalversions/bus/v1alpha1"
 	schedulinginformers "volcano.sh/apis/pkg/client/informers/externalversions/scheduling/v1beta1"
+	topologyinformers "volcano.sh/apis/pkg/client/informers/externalversions/topology/v1alpha1"
 	batchlister "volcano.sh/apis/pkg/client/listers/batch/v1alpha1"
 	buslister "volcano.sh/apis/pkg/client/listers/bus/v1alpha1"
 	schedulinglisters "volcano.sh/apis/pkg/client/listers/scheduling/v1beta1"
+	topologylisters "volcano.sh/apis/pkg/client/listers/topology/v1alpha1"
 
 	"volcano.sh/volcano/pkg/controllers/apis"
 	jobcache "volcano.sh/volcano/pkg/controllers/cache"
 	"volcano.sh/volcano/pkg/controllers/framework"
+	"volcano.sh/volcano/pkg/controllers/job/plugins/external"
 	"volcano.sh/volcano/pkg/controllers/job/state"
 	"volcano.sh/volcano/pkg/features"
 )
@@ -101,6 +104,8 @@ type jobcontroller struct {
 	cmdInformer   businformer.CommandInformer
 	pcInformer    kubeschedulinginformers.PriorityClassInformer
 	queueInformer schedulinginformers.QueueInformer
+	nodeInformer  coreinformers.NodeInformer
+	hnInformer    topologyinformers.HyperNodeInformer
 
 	informerFactory   informers.SharedInformerFactory
 	vcInformerFactory vcinformer.SharedInformerFactory
@@ -133,6 +138,12 @@ type jobcontroller struct {
 	queueLister schedulinglisters.QueueLister
 	queueSynced func() bool
 
+	// A store of nodes and hyperNodes, used to rank pods by topology
+	nodeLister corelisters.NodeLister
+	nodeSynced func() bool
+	hnLister   topologylisters.HyperNodeLister
+	hnSynced   func() bool
+
 	// queue that need to sync up
 	queueList    []workqueue.TypedRateLimitingInterface[any]
 	commandQueue workqueue.TypedRateLimitingInterface[any]
@@ -148,6 +159,11 @@ type jobcontroller struct {
 	// delayActionMap stores delayed actions for jobs, where outer map key is job key (namespace/name),
 	// inner map key is pod name, and value is the delayed action to be performed
 	delayActionMap map[string]map[string]*delayAction
+
+	startupTimeoutsLock sync.Mutex
+	// startupTimeouts stores the creation time of the dependencies of each task whose startup
+	// timeout has been raised, where key is job key/task name
+	startupTimeouts map[string]time.Time
 }
 
 func (cc *jobcontroller) Name() string {
@@ -156,6 +172,10 @@ func (cc *jobcontroller) Name() string {
 
 // Initialize creates the new Job controller.
 func (cc *jobcontroller) Initialize(opt *framework.ControllerOption) error {
+	if err := external.LoadConfiguration(opt.ExternalPluginConfig); err != nil {
+		return err
+	}
+
 	cc.kubeClient = opt.KubeClient
 	cc.vcClient = opt.VolcanoClient
 
@@ -259,12 +279,23 @@ func (cc *jobcontroller) Initialize(opt *framework.ControllerOption) error {
 	cc.queueLister = cc.queueInformer.Lister()
 	cc.queueSynced = cc.queueInformer.Informer().HasSynced
 
+	cc.nodeInformer = sharedInformers.Core().V1().Nodes()
+	cc.nodeLister = cc.nodeInformer.Lister()
+	cc.nodeSynced = cc.nodeInformer.Informer().HasSynced
+
+	cc.hnInformer = factory.Topology().V1alpha1().HyperNodes()
+	cc.hnLister = cc.hnInformer.Lister()
+	cc.hnSynced = cc.hnInformer.Informer().HasSynced
+
 	cc.delayActionMap = make(map[string]map[string]*delayAction)
+	cc.startupTimeouts = make(map[string]time.Time)
 
 	// Register actions
 	state.SyncJob = cc.syncJob
 	state.KillJob = cc.killJob
 	state.KillTarget = cc.killTarget
+	state.SuspendJob = cc.suspendJob
+	state.ResumeJob = cc.resumeJob
 	return nil
 }
 
@@ -369,7 +400,10 @@ func (cc *jobcontroller) processNextReq(count uint32) bool {
 		return true
 	}
 
+	cc.notifyPluginsOnRequest(jobInfo.Job, &req)
+
 	delayAct := applyPolicies(jobInfo.Job, &req)
+	cc.applyRestartBudget(jobInfo.Job, delayAct)
 
 	if delayAct.delay != 0 {
 		klog.V(3).Infof("Execute <%v> on Job <%s/%s> after %s",
@@ -37,6 +37,7 @@ import (
 	"volcano.sh/volcano/pkg/controllers/apis"
 	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
 	"volcano.sh/volcano/pkg/controllers/job/state"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
 )
 
 var calMutex sync.Mutex
@@ -72,6 +73,10 @@ func (cc *jobcontroller) killTarget(jobInfo *apis.JobInfo, target state.Target,
 		klog.V(3).Infof("Killing pod <%s> of Job <%s/%s>, current version %d", target.PodName, jobInfo.Namespace, jobInfo.Name, jobInfo.Job.Status.Version)
 		defer klog.V(3).Infof("Finished pod <%s> of Job <%s/%s> killing, current version %d", target.PodName, jobInfo.Namespace, jobInfo.Name, jobInfo.Job.Status.Version)
 	}
+	jobInfo, err := cc.recordTaskRestart(jobInfo, target.TaskName)
+	if err != nil {
+		return err
+	}
 	return cc.killPods(jobInfo, nil, &target, updateStatus)
 }
 
@@ -214,6 +219,7 @@ func (cc *jobcontroller) killPods(jobInfo *apis.JobInfo, podRetainPhase state.Ph
 			newJob.Namespace, newJob.Name, e)
 		return e
 	}
+	cc.notifyPluginsOnJobPhaseChange(newJob, jobInfo.Job.Status.State.Phase)
 
 	// Delete PodGroup
 	pg, err := cc.getPodGroupByJob(job)
@@ -221,6 +227,10 @@ func (cc *jobcontroller) killPods(jobInfo *apis.JobInfo, podRetainPhase state.Ph
 		klog.Errorf("Failed to find PodGroup of Job: %s/%s, error: %s", job.Namespace, job.Name, err.Error())
 		return err
 	}
+	// Keep the PodGroup of suspended Job to keep its position in the queue.
+	if pg != nil && apis.IsSuspended(job) {
+		return cc.suspendPodGroup(pg, true)
+	}
 	if pg != nil {
 		if err := cc.vcClient.SchedulingV1beta1().PodGroups(job.Namespace).Delete(context.TODO(), pg.Name, metav1.DeleteOptions{}); err != nil {
 			if !apierrors.IsNotFound(err) {
@@ -387,6 +397,7 @@ func (cc *jobcontroller) syncJob(jobInfo *apis.JobInfo, updateStatus state.Updat
 				newJob.Namespace, newJob.Name, e)
 			return e
 		}
+		cc.notifyPluginsOnJobPhaseChange(newJob, jobInfo.Job.Status.State.Phase)
 		return nil
 	}
 
@@ -394,6 +405,7 @@ func (cc *jobcontroller) syncJob(jobInfo *apis.JobInfo, updateStatus state.Updat
 	taskStatusCount := make(map[string]batch.TaskState)
 
 	podToCreate := make(map[string][]*v1.Pod)
+	previousNodes := previousNodesOfJob(job)
 	var podToDelete []*v1.Pod
 	var creationErrs []error
 	var deletionErrs []error
@@ -422,6 +434,8 @@ func (cc *jobcontroller) syncJob(jobInfo *apis.JobInfo, updateStatus state.Updat
 			podName := fmt.Sprintf(jobhelpers.PodNameFmt, job.Name, name, i)
 			if pod, found := pods[podName]; !found {
 				newPod := createJobPod(job, tc, ts.TopologyPolicy, i, jobForwarding)
+				preferPreviousNode(newPod, previousNodes[podName])
+				mountTopologyRank(newPod, job, name)
 				if err := cc.pluginOnPodCreate(job, newPod); err != nil {
 					return err
 				}
@@ -524,6 +538,10 @@ func (cc *jobcontroller) syncJob(jobInfo *apis.JobInfo, updateStatus state.Updat
 		return fmt.Errorf("failed to delete %d pods of %d", len(deletionErrs), len(podToDelete))
 	}
 
+	if err := cc.assignTopologyRanks(jobInfo); err != nil {
+		return err
+	}
+
 	newStatus := batch.JobStatus{
 		State: job.Status.State,
 
@@ -564,6 +582,7 @@ func (cc *jobcontroller) syncJob(jobInfo *apis.JobInfo, updateStatus state.Updat
 			newJob.Namespace, newJob.Name, e)
 		return e
 	}
+	cc.notifyPluginsOnJobPhaseChange(newJob, jobInfo.Job.Status.State.Phase)
 
 	return nil
 }
@@ -572,6 +591,13 @@ func (cc *jobcontroller) waitDependsOnTaskMeetCondition(taskIndex int, job *batc
 	if job.Spec.Tasks[taskIndex].DependsOn == nil {
 		return true
 	}
+	policy, err := state.GetStartupPolicy(job)
+	if err != nil {
+		klog.Warningf("Failed to get startup policy of Job <%s/%s>: %v", job.Namespace, job.Name, err)
+	}
+	if policy != nil {
+		return cc.waitDependsOnTaskStarted(taskIndex, job, policy.ForTask(job.Spec.Tasks[taskIndex].Name))
+	}
 	dependsOn := *job.Spec.Tasks[taskIndex].DependsOn
 	if len(dependsOn.Name) > 1 && dependsOn.Iteration == batch.IterationAny {
 		// any ready to create task, return true
@@ -801,6 +827,10 @@ func (cc *jobcontroller) createOrUpdatePodGroup(job *batch.Job) error {
 
 func (cc *jobcontroller) shouldUpdateExistingPodGroup(pg *scheduling.PodGroup, job *batch.Job) bool {
 	pgShouldUpdate := false
+	if _, found := pg.Annotations[schedulingapi.PodGroupSuspendedAnnotationKey]; found && !apis.IsSuspended(job) {
+		delete(pg.Annotations, schedulingapi.PodGroupSuspendedAnnotationKey)
+		pgShouldUpdate = true
+	}
 	if pg.Spec.PriorityClassName != job.Spec.PriorityClassName {
 		pg.Spec.PriorityClassName = job.Spec.PriorityClassName
 		pgShouldUpdate = true
@@ -135,6 +135,8 @@ func (cc *jobcontroller) deleteJob(obj interface{}) {
 			job.Namespace, job.Name, err)
 	}
 
+	cc.cleanupStartupTimeouts(jobcache.JobKeyByName(job.Namespace, job.Name))
+
 	// Delete job metrics
 	state.DeleteJobMetrics(fmt.Sprintf("%s/%s", job.Namespace, job.Name), job.Spec.Queue)
 }
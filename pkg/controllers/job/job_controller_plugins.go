@@ -23,12 +23,15 @@ import (
 	"k8s.io/klog/v2"
 
 	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	busv1alpha1 "volcano.sh/apis/pkg/apis/bus/v1alpha1"
+
+	"volcano.sh/volcano/pkg/controllers/apis"
 	"volcano.sh/volcano/pkg/controllers/job/plugins"
 	pluginsinterface "volcano.sh/volcano/pkg/controllers/job/plugins/interface"
 )
 
 func (cc *jobcontroller) pluginOnPodCreate(job *batch.Job, pod *v1.Pod) error {
-	client := pluginsinterface.PluginClientset{KubeClients: cc.kubeClient}
+	client := pluginsinterface.PluginClientset{KubeClients: cc.kubeClient, PodLister: cc.podLister}
 	for name, args := range job.Spec.Plugins {
 		pb, found := plugins.GetPluginBuilder(name)
 		if !found {
@@ -46,7 +49,7 @@ func (cc *jobcontroller) pluginOnPodCreate(job *batch.Job, pod *v1.Pod) error {
 }
 
 func (cc *jobcontroller) pluginOnJobAdd(job *batch.Job) error {
-	client := pluginsinterface.PluginClientset{KubeClients: cc.kubeClient}
+	client := pluginsinterface.PluginClientset{KubeClients: cc.kubeClient, PodLister: cc.podLister}
 	if job.Status.ControlledResources == nil {
 		job.Status.ControlledResources = make(map[string]string)
 	}
@@ -71,7 +74,7 @@ func (cc *jobcontroller) pluginOnJobDelete(job *batch.Job) error {
 	if job.Status.ControlledResources == nil {
 		job.Status.ControlledResources = make(map[string]string)
 	}
-	client := pluginsinterface.PluginClientset{KubeClients: cc.kubeClient}
+	client := pluginsinterface.PluginClientset{KubeClients: cc.kubeClient, PodLister: cc.podLister}
 	for name, args := range job.Spec.Plugins {
 		pb, found := plugins.GetPluginBuilder(name)
 		if !found {
@@ -90,7 +93,7 @@ func (cc *jobcontroller) pluginOnJobDelete(job *batch.Job) error {
 }
 
 func (cc *jobcontroller) pluginOnJobUpdate(job *batch.Job) error {
-	client := pluginsinterface.PluginClientset{KubeClients: cc.kubeClient}
+	client := pluginsinterface.PluginClientset{KubeClients: cc.kubeClient, PodLister: cc.podLister}
 	if job.Status.ControlledResources == nil {
 		job.Status.ControlledResources = make(map[string]string)
 	}
@@ -110,3 +113,101 @@ func (cc *jobcontroller) pluginOnJobUpdate(job *batch.Job) error {
 
 	return nil
 }
+
+func (cc *jobcontroller) pluginOnPodDelete(job *batch.Job, taskName, podName string) error {
+	client := pluginsinterface.PluginClientset{KubeClients: cc.kubeClient, PodLister: cc.podLister}
+	for name, args := range job.Spec.Plugins {
+		pb, found := plugins.GetPluginBuilder(name)
+		if !found {
+			err := fmt.Errorf("failed to get plugin %s", name)
+			klog.Error(err)
+			return err
+		}
+		handler, ok := pb(client, args).(pluginsinterface.PodDeleteHandler)
+		if !ok {
+			continue
+		}
+		klog.Infof("Starting to execute plugin at <pluginOnPodDelete>: %s on job: <%s/%s>", name, job.Namespace, job.Name)
+		if err := handler.OnPodDelete(job, taskName, podName); err != nil {
+			klog.Errorf("Failed to process on pod delete plugin %s, err %v.", name, err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cc *jobcontroller) pluginOnTaskFailed(job *batch.Job, taskName string) error {
+	client := pluginsinterface.PluginClientset{KubeClients: cc.kubeClient, PodLister: cc.podLister}
+	for name, args := range job.Spec.Plugins {
+		pb, found := plugins.GetPluginBuilder(name)
+		if !found {
+			err := fmt.Errorf("failed to get plugin %s", name)
+			klog.Error(err)
+			return err
+		}
+		handler, ok := pb(client, args).(pluginsinterface.TaskFailureHandler)
+		if !ok {
+			continue
+		}
+		klog.Infof("Starting to execute plugin at <pluginOnTaskFailed>: %s on job: <%s/%s>", name, job.Namespace, job.Name)
+		if err := handler.OnTaskFailed(job, taskName); err != nil {
+			klog.Errorf("Failed to process on task failed plugin %s, err %v.", name, err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cc *jobcontroller) pluginOnJobPhaseChange(job *batch.Job, oldPhase batch.JobPhase) error {
+	if job.Status.State.Phase == oldPhase {
+		return nil
+	}
+	client := pluginsinterface.PluginClientset{KubeClients: cc.kubeClient, PodLister: cc.podLister}
+	for name, args := range job.Spec.Plugins {
+		pb, found := plugins.GetPluginBuilder(name)
+		if !found {
+			err := fmt.Errorf("failed to get plugin %s", name)
+			klog.Error(err)
+			return err
+		}
+		handler, ok := pb(client, args).(pluginsinterface.JobPhaseChangeHandler)
+		if !ok {
+			continue
+		}
+		klog.Infof("Starting to execute plugin at <pluginOnJobPhaseChange>: %s on job: <%s/%s>", name, job.Namespace, job.Name)
+		if err := handler.OnJobPhaseChange(job, oldPhase); err != nil {
+			klog.Errorf("Failed to process on job phase change plugin %s, err %v.", name, err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// notifyPluginsOnJobPhaseChange calls the plugins after the job status is updated, the status
+// is already persisted, so the error is only recorded instead of failing the action.
+func (cc *jobcontroller) notifyPluginsOnJobPhaseChange(job *batch.Job, oldPhase batch.JobPhase) {
+	if err := cc.pluginOnJobPhaseChange(job, oldPhase); err != nil {
+		cc.recorder.Event(job, v1.EventTypeWarning, string(batch.PluginError),
+			fmt.Sprintf("Execute plugin when job phase changed from %s to %s failed, err: %v", oldPhase, job.Status.State.Phase, err))
+	}
+}
+
+// notifyPluginsOnRequest calls the plugins interested in the pod and task events of the request.
+func (cc *jobcontroller) notifyPluginsOnRequest(job *batch.Job, req *apis.Request) {
+	var err error
+	switch req.Event {
+	case busv1alpha1.PodEvictedEvent:
+		err = cc.pluginOnPodDelete(job, req.TaskName, req.PodName)
+	case busv1alpha1.PodFailedEvent, busv1alpha1.TaskFailedEvent:
+		err = cc.pluginOnTaskFailed(job, req.TaskName)
+	default:
+		return
+	}
+	if err != nil {
+		cc.recorder.Event(job, v1.EventTypeWarning, string(batch.PluginError),
+			fmt.Sprintf("Execute plugin on event %s of task %s failed, err: %v", req.Event, req.TaskName, err))
+	}
+}
@@ -22,14 +22,19 @@ import (
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/informers"
 	kubeclient "k8s.io/client-go/kubernetes/fake"
 
 	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	busv1alpha1 "volcano.sh/apis/pkg/apis/bus/v1alpha1"
 	volcanoclient "volcano.sh/apis/pkg/client/clientset/versioned/fake"
 	informerfactory "volcano.sh/apis/pkg/client/informers/externalversions"
+	"volcano.sh/volcano/pkg/controllers/apis"
 	"volcano.sh/volcano/pkg/controllers/framework"
+	"volcano.sh/volcano/pkg/controllers/job/plugins"
+	pluginsinterface "volcano.sh/volcano/pkg/controllers/job/plugins/interface"
 )
 
 func newFakeController() *jobcontroller {
@@ -313,3 +318,63 @@ func TestPluginOnJobDelete(t *testing.T) {
 		})
 	}
 }
+
+// hooksPlugin records the optional hooks it is called with.
+type hooksPlugin struct {
+	calls *[]string
+}
+
+func (hp *hooksPlugin) Name() string                                  { return "hooks" }
+func (hp *hooksPlugin) OnPodCreate(pod *v1.Pod, job *batch.Job) error { return nil }
+func (hp *hooksPlugin) OnJobAdd(job *batch.Job) error                 { return nil }
+func (hp *hooksPlugin) OnJobDelete(job *batch.Job) error              { return nil }
+func (hp *hooksPlugin) OnJobUpdate(job *batch.Job) error              { return nil }
+
+func (hp *hooksPlugin) OnPodDelete(job *batch.Job, taskName, podName string) error {
+	*hp.calls = append(*hp.calls, fmt.Sprintf("PodDelete %s/%s", taskName, podName))
+	return nil
+}
+
+func (hp *hooksPlugin) OnTaskFailed(job *batch.Job, taskName string) error {
+	*hp.calls = append(*hp.calls, fmt.Sprintf("TaskFailed %s", taskName))
+	return nil
+}
+
+func (hp *hooksPlugin) OnJobPhaseChange(job *batch.Job, oldPhase batch.JobPhase) error {
+	*hp.calls = append(*hp.calls, fmt.Sprintf("JobPhaseChange %s->%s", oldPhase, job.Status.State.Phase))
+	return nil
+}
+
+func TestPluginOptionalHooks(t *testing.T) {
+	var calls []string
+	plugins.RegisterPluginBuilder("hooks", func(pluginsinterface.PluginClientset, []string) pluginsinterface.PluginInterface {
+		return &hooksPlugin{calls: &calls}
+	})
+
+	job := &batch.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "job1", Namespace: "test"},
+		Spec: batch.JobSpec{
+			// env does not implement the optional hooks and must be skipped.
+			Plugins: map[string][]string{"hooks": {}, "env": {}},
+		},
+		Status: batch.JobStatus{State: batch.JobState{Phase: batch.Running}},
+	}
+
+	fakeController := newFakeController()
+	fakeController.notifyPluginsOnRequest(job, &apis.Request{TaskName: "worker", PodName: "job1-worker-0", Event: busv1alpha1.PodEvictedEvent})
+	fakeController.notifyPluginsOnRequest(job, &apis.Request{TaskName: "worker", PodName: "job1-worker-1", Event: busv1alpha1.PodFailedEvent})
+	fakeController.notifyPluginsOnRequest(job, &apis.Request{TaskName: "master", Event: busv1alpha1.TaskFailedEvent})
+	fakeController.notifyPluginsOnRequest(job, &apis.Request{TaskName: "master", Event: busv1alpha1.PodRunningEvent})
+	fakeController.notifyPluginsOnJobPhaseChange(job, batch.Running)
+	fakeController.notifyPluginsOnJobPhaseChange(job, batch.Pending)
+
+	expected := []string{
+		"PodDelete worker/job1-worker-0",
+		"TaskFailed worker",
+		"TaskFailed master",
+		"JobPhaseChange Pending->Running",
+	}
+	if !equality.Semantic.DeepEqual(calls, expected) {
+		t.Errorf("expected hooks %v, got %v", expected, calls)
+	}
+}
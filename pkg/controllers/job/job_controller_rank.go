@@ -0,0 +1,247 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	"volcano.sh/apis/pkg/apis/helpers"
+	topologyv1alpha1 "volcano.sh/apis/pkg/apis/topology/v1alpha1"
+
+	"volcano.sh/volcano/pkg/controllers/apis"
+	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// assignTopologyRanks assigns the ranks of the pods of the ranked tasks once they are all scheduled, the pods are
+// ordered by the HyperNodes of their nodes so that neighboring ranks share a leaf HyperNode. The ranks of running pods
+// are kept, a recreated pod takes one of the free ranks.
+func (cc *jobcontroller) assignTopologyRanks(jobInfo *apis.JobInfo) error {
+	job := jobInfo.Job
+	tasks := apis.TopologyRankedTasks(job)
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	var pods []*v1.Pod
+	for _, taskName := range tasks {
+		taskIndex := jobhelpers.GetTaskIndexUnderJob(taskName, job)
+		if taskIndex == -1 {
+			continue
+		}
+		for i := 0; i < int(job.Spec.Tasks[taskIndex].Replicas); i++ {
+			pod, found := jobInfo.Pods[taskName][fmt.Sprintf(jobhelpers.PodNameFmt, job.Name, taskName, i)]
+			// Wait for all pods to be scheduled.
+			if !found || pod.DeletionTimestamp != nil || pod.Spec.NodeName == "" {
+				return nil
+			}
+			pods = append(pods, pod)
+		}
+	}
+
+	ranks := map[string]int{}
+	taken := map[int]bool{}
+	var unranked []*v1.Pod
+	for _, pod := range pods {
+		rank, err := strconv.Atoi(pod.Annotations[apis.TopologyRankAnnotationKey])
+		if err == nil && rank >= 0 && rank < len(pods) && !taken[rank] {
+			ranks[pod.Name] = rank
+			taken[rank] = true
+			continue
+		}
+		unranked = append(unranked, pod)
+	}
+	if len(unranked) == 0 {
+		return nil
+	}
+
+	paths, err := cc.topologyPaths()
+	if err != nil {
+		return err
+	}
+	ordered := orderPodsByTopology(unranked, paths)
+	// The ring is rotated to keep the first pod, e.g. the master, at rank 0.
+	if len(unranked) == len(pods) {
+		first := slices.Index(ordered, pods[0])
+		ordered = append(ordered[first:], ordered[:first]...)
+	}
+	rank := 0
+	for _, pod := range ordered {
+		for taken[rank] {
+			rank++
+		}
+		ranks[pod.Name] = rank
+		taken[rank] = true
+	}
+
+	// The hostfile is written before the ranks, so that it is ready once a pod reads its rank.
+	hosts := make([]string, len(pods))
+	for _, pod := range pods {
+		hosts[ranks[pod.Name]] = podHost(pod)
+	}
+	data := map[string]string{apis.TopologyHostfile: strings.Join(hosts, "\n") + "\n"}
+	if err := helpers.CreateOrUpdateConfigMap(job, cc.kubeClient, data, topologyRankCMName(job)); err != nil {
+		return err
+	}
+
+	for _, pod := range unranked {
+		patch, err := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"annotations": map[string]string{apis.TopologyRankAnnotationKey: strconv.Itoa(ranks[pod.Name])},
+			},
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := cc.kubeClient.CoreV1().Pods(pod.Namespace).Patch(context.TODO(), pod.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+			klog.Errorf("Failed to patch rank of Pod <%s/%s>: %v", pod.Namespace, pod.Name, err)
+			return err
+		}
+	}
+	klog.V(3).Infof("Assigned topology aware ranks %v to Job <%s/%s>", ranks, job.Namespace, job.Name)
+
+	return nil
+}
+
+// topologyPaths returns the HyperNodes containing each node, from the root to the leaf HyperNode.
+func (cc *jobcontroller) topologyPaths() (map[string][]string, error) {
+	hyperNodes, err := cc.hnLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := cc.nodeLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	parents := map[string]string{}
+	leaves := map[string]string{}
+	for _, hn := range hyperNodes {
+		for _, member := range hn.Spec.Members {
+			switch member.Type {
+			case topologyv1alpha1.MemberTypeHyperNode:
+				if member.Selector.ExactMatch != nil {
+					parents[member.Selector.ExactMatch.Name] = hn.Name
+				}
+			case topologyv1alpha1.MemberTypeNode:
+				for node := range schedulingapi.GetMembers(member.Selector, nodes) {
+					leaves[node] = hn.Name
+				}
+			}
+		}
+	}
+
+	paths := map[string][]string{}
+	for node, leaf := range leaves {
+		chain := []string{}
+		for hn := leaf; hn != "" && !slices.Contains(chain, hn); hn = parents[hn] {
+			chain = append(chain, hn)
+		}
+		slices.Reverse(chain)
+		paths[node] = chain
+	}
+	return paths, nil
+}
+
+// orderPodsByTopology orders the pods by the HyperNodes of their nodes, pods on nodes not in any HyperNode come last.
+func orderPodsByTopology(pods []*v1.Pod, paths map[string][]string) []*v1.Pod {
+	ordered := slices.Clone(pods)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		pi, pj := paths[ordered[i].Spec.NodeName], paths[ordered[j].Spec.NodeName]
+		if (len(pi) == 0) != (len(pj) == 0) {
+			return len(pj) == 0
+		}
+		if c := slices.Compare(pi, pj); c != 0 {
+			return c < 0
+		}
+		return ordered[i].Spec.NodeName < ordered[j].Spec.NodeName
+	})
+	return ordered
+}
+
+func podHost(pod *v1.Pod) string {
+	if pod.Spec.Hostname != "" && pod.Spec.Subdomain != "" {
+		return pod.Spec.Hostname + "." + pod.Spec.Subdomain
+	}
+	return pod.Name
+}
+
+func topologyRankCMName(job *batch.Job) string {
+	return fmt.Sprintf("%s-topology-rank", job.Name)
+}
+
+// mountTopologyRank projects the rank of the pod and the hostfile of the job into the containers of the pod,
+// the rank is only known after all pods are scheduled, so it is read from a file instead of an env.
+func mountTopologyRank(pod *v1.Pod, job *batch.Job, taskName string) {
+	if !slices.Contains(apis.TopologyRankedTasks(job), taskName) {
+		return
+	}
+
+	optional := true
+	volumeName := "topology-rank"
+	pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{
+		Name: volumeName,
+		VolumeSource: v1.VolumeSource{
+			Projected: &v1.ProjectedVolumeSource{
+				Sources: []v1.VolumeProjection{{
+					DownwardAPI: &v1.DownwardAPIProjection{
+						Items: []v1.DownwardAPIVolumeFile{{
+							Path: apis.TopologyRankFile,
+							FieldRef: &v1.ObjectFieldSelector{
+								FieldPath: fmt.Sprintf("metadata.annotations['%s']", apis.TopologyRankAnnotationKey),
+							},
+						}},
+					},
+				}, {
+					ConfigMap: &v1.ConfigMapProjection{
+						LocalObjectReference: v1.LocalObjectReference{Name: topologyRankCMName(job)},
+						Optional:             &optional,
+					},
+				}},
+			},
+		},
+	})
+
+	for i := range pod.Spec.Containers {
+		pod.Spec.Containers[i].VolumeMounts = append(pod.Spec.Containers[i].VolumeMounts, v1.VolumeMount{
+			Name:      volumeName,
+			MountPath: apis.TopologyRankMountPath,
+			ReadOnly:  true,
+		})
+		pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, v1.EnvVar{
+			Name:  apis.EnvTopologyRankFile,
+			Value: path.Join(apis.TopologyRankMountPath, apis.TopologyRankFile),
+		}, v1.EnvVar{
+			Name:  apis.EnvTopologyHostfile,
+			Value: path.Join(apis.TopologyRankMountPath, apis.TopologyHostfile),
+		})
+	}
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	topologyv1alpha1 "volcano.sh/apis/pkg/apis/topology/v1alpha1"
+
+	"volcano.sh/volcano/pkg/controllers/apis"
+)
+
+func buildHyperNode(name string, tier int, memberType topologyv1alpha1.MemberType, selector topologyv1alpha1.MemberSelector) *topologyv1alpha1.HyperNode {
+	return &topologyv1alpha1.HyperNode{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: topologyv1alpha1.HyperNodeSpec{
+			Tier:    tier,
+			Members: []topologyv1alpha1.MemberSpec{{Type: memberType, Selector: selector}},
+		},
+	}
+}
+
+func buildRankTestPod(job *batch.Job, task, index, nodeName string) *v1.Pod {
+	name := job.Name + "-" + task + "-" + index
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: job.Namespace},
+		Spec:       v1.PodSpec{NodeName: nodeName, Hostname: name, Subdomain: job.Name},
+	}
+}
+
+func TestAssignTopologyRanks(t *testing.T) {
+	job := &batch.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "job1",
+			Namespace:   "test",
+			Annotations: map[string]string{apis.TopologyAwareRankAnnotationKey: "master,worker"},
+		},
+		Spec: batch.JobSpec{
+			Tasks: []batch.TaskSpec{{Name: "master", Replicas: 1}, {Name: "worker", Replicas: 3}},
+		},
+	}
+
+	fakeController := newFakeController()
+	for _, name := range []string{"n0", "n1", "n2", "n3"} {
+		fakeController.nodeInformer.Informer().GetIndexer().Add(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	}
+	for _, hn := range []*topologyv1alpha1.HyperNode{
+		buildHyperNode("s0", 2, topologyv1alpha1.MemberTypeHyperNode, topologyv1alpha1.MemberSelector{ExactMatch: &topologyv1alpha1.ExactMatch{Name: "l0"}}),
+		buildHyperNode("s1", 2, topologyv1alpha1.MemberTypeHyperNode, topologyv1alpha1.MemberSelector{ExactMatch: &topologyv1alpha1.ExactMatch{Name: "l1"}}),
+		buildHyperNode("l0", 1, topologyv1alpha1.MemberTypeNode, topologyv1alpha1.MemberSelector{RegexMatch: &topologyv1alpha1.RegexMatch{Pattern: "n[01]"}}),
+		buildHyperNode("l1", 1, topologyv1alpha1.MemberTypeNode, topologyv1alpha1.MemberSelector{RegexMatch: &topologyv1alpha1.RegexMatch{Pattern: "n[23]"}}),
+	} {
+		fakeController.hnInformer.Informer().GetIndexer().Add(hn)
+	}
+
+	master := buildRankTestPod(job, "master", "0", "n2")
+	workers := []*v1.Pod{
+		buildRankTestPod(job, "worker", "0", "n0"),
+		buildRankTestPod(job, "worker", "1", "n3"),
+		buildRankTestPod(job, "worker", "2", ""),
+	}
+	for _, pod := range append(workers, master) {
+		if _, err := fakeController.kubeClient.CoreV1().Pods("test").Create(context.TODO(), pod, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	jobInfo := &apis.JobInfo{
+		Namespace: "test",
+		Name:      "job1",
+		Job:       job,
+		Pods: map[string]map[string]*v1.Pod{
+			"master": {master.Name: master},
+			"worker": {workers[0].Name: workers[0], workers[1].Name: workers[1], workers[2].Name: workers[2]},
+		},
+	}
+
+	getRanks := func() map[string]string {
+		ranks := map[string]string{}
+		for _, pod := range append(workers, master) {
+			updated, err := fakeController.kubeClient.CoreV1().Pods("test").Get(context.TODO(), pod.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			ranks[pod.Name] = updated.Annotations[apis.TopologyRankAnnotationKey]
+			pod.Annotations = updated.Annotations
+		}
+		return ranks
+	}
+
+	// The ranks are not assigned until all pods are scheduled.
+	if err := fakeController.assignTopologyRanks(jobInfo); err != nil {
+		t.Fatal(err)
+	}
+	if ranks := getRanks(); ranks[master.Name] != "" {
+		t.Fatalf("expected no rank before all pods are scheduled, got %v", ranks)
+	}
+
+	workers[2].Spec.NodeName = "n1"
+	if err := fakeController.assignTopologyRanks(jobInfo); err != nil {
+		t.Fatal(err)
+	}
+	// Ordered by leaf: worker-0 (n0), worker-2 (n1) under l0, master-0 (n2), worker-1 (n3) under l1,
+	// then rotated to keep master-0 at rank 0.
+	expected := map[string]string{"job1-master-0": "0", "job1-worker-1": "1", "job1-worker-0": "2", "job1-worker-2": "3"}
+	ranks := getRanks()
+	for name, rank := range expected {
+		if ranks[name] != rank {
+			t.Errorf("expected rank of %s to be %s, got %v", name, rank, ranks)
+		}
+	}
+	cm, err := fakeController.kubeClient.CoreV1().ConfigMaps("test").Get(context.TODO(), "job1-topology-rank", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedHostfile := "job1-master-0.job1\njob1-worker-1.job1\njob1-worker-0.job1\njob1-worker-2.job1\n"
+	if cm.Data[apis.TopologyHostfile] != expectedHostfile {
+		t.Errorf("expected hostfile %q, got %q", expectedHostfile, cm.Data[apis.TopologyHostfile])
+	}
+
+	// A recreated pod takes the free rank, the ranks of other pods are kept.
+	workers[0].Annotations = nil
+	workers[0].Spec.NodeName = "n3"
+	if err := fakeController.kubeClient.CoreV1().Pods("test").Delete(context.TODO(), workers[0].Name, metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fakeController.kubeClient.CoreV1().Pods("test").Create(context.TODO(), workers[0], metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := fakeController.assignTopologyRanks(jobInfo); err != nil {
+		t.Fatal(err)
+	}
+	ranks = getRanks()
+	for name, rank := range expected {
+		if ranks[name] != rank {
+			t.Errorf("expected rank of %s to be kept as %s, got %v", name, rank, ranks)
+		}
+	}
+}
+
+func TestMountTopologyRank(t *testing.T) {
+	job := &batch.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "job1",
+			Annotations: map[string]string{apis.TopologyAwareRankAnnotationKey: "worker"},
+		},
+	}
+
+	pod := &v1.Pod{Spec: v1.PodSpec{Containers: []v1.Container{{Name: "c"}}}}
+	mountTopologyRank(pod, job, "ps")
+	if len(pod.Spec.Volumes) != 0 {
+		t.Errorf("expected pod of task not ranked to be unchanged, got %v", pod.Spec.Volumes)
+	}
+
+	mountTopologyRank(pod, job, "worker")
+	if len(pod.Spec.Volumes) != 1 || len(pod.Spec.Volumes[0].Projected.Sources) != 2 {
+		t.Fatalf("expected projected volume of rank and hostfile, got %v", pod.Spec.Volumes)
+	}
+	container := pod.Spec.Containers[0]
+	if len(container.VolumeMounts) != 1 || container.VolumeMounts[0].MountPath != apis.TopologyRankMountPath {
+		t.Errorf("expected rank volume to be mounted, got %v", container.VolumeMounts)
+	}
+	if len(container.Env) != 2 || container.Env[0].Value != "/etc/volcano/topology/rank" {
+		t.Errorf("expected rank file env, got %v", container.Env)
+	}
+}
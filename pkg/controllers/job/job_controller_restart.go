@@ -0,0 +1,83 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	busv1alpha1 "volcano.sh/apis/pkg/apis/bus/v1alpha1"
+
+	"volcano.sh/volcano/pkg/controllers/apis"
+	"volcano.sh/volcano/pkg/controllers/job/state"
+)
+
+// applyRestartBudget delays the restart of a task by the backoff of its restart budget, or escalates
+// the restart to the action of the budget if the task exceeds its restart limit or is crash looping.
+func (cc *jobcontroller) applyRestartBudget(job *batch.Job, delayAct *delayAction) {
+	if delayAct.action != busv1alpha1.RestartTaskAction && delayAct.action != busv1alpha1.RestartPodAction {
+		return
+	}
+	if delayAct.taskName == "" {
+		return
+	}
+
+	decision, err := state.CheckTaskRestart(job, delayAct.taskName, time.Now())
+	if err != nil {
+		klog.Warningf("Failed to check restart budget of Job <%s/%s>: %v", job.Namespace, job.Name, err)
+		return
+	}
+	if decision.Escalate != "" {
+		klog.V(3).Infof("Escalate %s of Job <%s/%s> to %s: %s", delayAct.action, job.Namespace, job.Name, decision.Escalate, decision.Reason)
+		cc.recorder.Event(job, v1.EventTypeWarning, TaskRestartBudgetExceededReason,
+			fmt.Sprintf("%s, execute action %s instead of %s", decision.Reason, decision.Escalate, delayAct.action))
+		delayAct.action = decision.Escalate
+		delayAct.delay = 0
+		return
+	}
+	if decision.Delay > delayAct.delay {
+		delayAct.delay = decision.Delay
+	}
+}
+
+// recordTaskRestart adds the restart of the task to the restart history of the Job if it has a restart budget.
+func (cc *jobcontroller) recordTaskRestart(jobInfo *apis.JobInfo, taskName string) (*apis.JobInfo, error) {
+	budget, err := state.GetRestartBudget(jobInfo.Job)
+	if err != nil {
+		klog.Warningf("Failed to get restart budget of Job <%s/%s>: %v", jobInfo.Namespace, jobInfo.Name, err)
+		return jobInfo, nil
+	}
+	if budget == nil || taskName == "" {
+		return jobInfo, nil
+	}
+
+	job := jobInfo.Job.DeepCopy()
+	if err := state.RecordTaskRestart(job, taskName, time.Now()); err != nil {
+		return nil, err
+	}
+	newJob, err := cc.updateJobAndCache(job)
+	if err != nil {
+		return nil, err
+	}
+	jobInfo = jobInfo.Clone()
+	jobInfo.Job = newJob
+	return jobInfo, nil
+}
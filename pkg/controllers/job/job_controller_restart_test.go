@@ -0,0 +1,184 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	busv1alpha1 "volcano.sh/apis/pkg/apis/bus/v1alpha1"
+
+	"volcano.sh/volcano/pkg/controllers/apis"
+	"volcano.sh/volcano/pkg/controllers/job/state"
+)
+
+func buildRestartBudgetJob(budget string) *batch.Job {
+	return &batch.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "job1",
+			Namespace:       "test",
+			ResourceVersion: "100",
+			Annotations:     map[string]string{state.RestartBudgetAnnotationKey: budget},
+		},
+		Spec: batch.JobSpec{
+			Tasks: []batch.TaskSpec{{Name: "worker", Replicas: 1}, {Name: "ps", Replicas: 1}},
+		},
+		Status: batch.JobStatus{
+			State: batch.JobState{Phase: batch.Running},
+		},
+	}
+}
+
+func TestCheckTaskRestart(t *testing.T) {
+	now := time.Now()
+	budget := `{"maxRestarts":4,"backoff":{"initialDelay":"10s","maxDelay":"30s"},` +
+		`"crashLoop":{"restarts":3,"window":"1m"},"tasks":{"ps":{"maxRestarts":1,"action":"TerminateJob"}}}`
+
+	testcases := []struct {
+		Name             string
+		Task             string
+		Restarts         []time.Time
+		ExpectedDelay    time.Duration
+		ExpectedEscalate busv1alpha1.Action
+	}{
+		{
+			Name:          "first restart",
+			Task:          "worker",
+			ExpectedDelay: 10 * time.Second,
+		},
+		{
+			Name:          "exponential backoff",
+			Task:          "worker",
+			Restarts:      []time.Time{now.Add(-time.Hour)},
+			ExpectedDelay: 20 * time.Second,
+		},
+		{
+			Name:          "backoff capped by max delay",
+			Task:          "worker",
+			Restarts:      []time.Time{now.Add(-3 * time.Hour), now.Add(-2 * time.Hour), now.Add(-time.Hour)},
+			ExpectedDelay: 30 * time.Second,
+		},
+		{
+			Name:             "crash loop",
+			Task:             "worker",
+			Restarts:         []time.Time{now.Add(-50 * time.Second), now.Add(-30 * time.Second), now.Add(-10 * time.Second)},
+			ExpectedEscalate: busv1alpha1.AbortJobAction,
+		},
+		{
+			Name:             "task restart limit",
+			Task:             "ps",
+			Restarts:         []time.Time{now.Add(-time.Hour)},
+			ExpectedEscalate: busv1alpha1.TerminateJobAction,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			job := buildRestartBudgetJob(budget)
+			for _, restart := range testcase.Restarts {
+				if err := state.RecordTaskRestart(job, testcase.Task, restart); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			decision, err := state.CheckTaskRestart(job, testcase.Task, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if decision.Escalate != testcase.ExpectedEscalate {
+				t.Errorf("expected escalation %q but got %q", testcase.ExpectedEscalate, decision.Escalate)
+			}
+			if decision.Escalate == "" && decision.Delay != testcase.ExpectedDelay {
+				t.Errorf("expected delay %v but got %v", testcase.ExpectedDelay, decision.Delay)
+			}
+		})
+	}
+}
+
+func TestApplyRestartBudget(t *testing.T) {
+	fakeController := newFakeController()
+	job := buildRestartBudgetJob(`{"maxRestarts":1,"backoff":{"initialDelay":"10s"}}`)
+
+	delayAct := &delayAction{action: busv1alpha1.RestartTaskAction, taskName: "worker"}
+	fakeController.applyRestartBudget(job, delayAct)
+	if delayAct.action != busv1alpha1.RestartTaskAction || delayAct.delay != 10*time.Second {
+		t.Errorf("expected restart delayed by 10s, got %s after %v", delayAct.action, delayAct.delay)
+	}
+
+	// A job level action is not limited by the restart budget.
+	delayAct = &delayAction{action: busv1alpha1.RestartJobAction, taskName: "worker"}
+	fakeController.applyRestartBudget(job, delayAct)
+	if delayAct.action != busv1alpha1.RestartJobAction || delayAct.delay != 0 {
+		t.Errorf("expected restart job not to be changed, got %s after %v", delayAct.action, delayAct.delay)
+	}
+
+	if err := state.RecordTaskRestart(job, "worker", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	delayAct = &delayAction{action: busv1alpha1.RestartPodAction, taskName: "worker", delay: time.Minute}
+	fakeController.applyRestartBudget(job, delayAct)
+	if delayAct.action != busv1alpha1.AbortJobAction || delayAct.delay != 0 {
+		t.Errorf("expected restart escalated to AbortJob immediately, got %s after %v", delayAct.action, delayAct.delay)
+	}
+}
+
+func TestKillTargetRecordsTaskRestart(t *testing.T) {
+	fakeController := newFakeController()
+	job := buildRestartBudgetJob(`{"maxRestarts":3}`)
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "job1-worker-0", Namespace: "test"},
+		Status:     v1.PodStatus{Phase: v1.PodFailed},
+	}
+
+	if _, err := fakeController.vcClient.BatchV1alpha1().Jobs("test").Create(context.TODO(), job, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := fakeController.cache.Add(job); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fakeController.kubeClient.CoreV1().Pods("test").Create(context.TODO(), pod, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	jobInfo := &apis.JobInfo{
+		Namespace: "test",
+		Name:      "job1",
+		Job:       job,
+		Pods:      map[string]map[string]*v1.Pod{"worker": {pod.Name: pod}},
+	}
+	target := state.Target{TaskName: "worker", Type: state.TargetTypeTask}
+	if err := fakeController.killTarget(jobInfo, target, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := fakeController.vcClient.BatchV1alpha1().Jobs("test").Get(context.TODO(), "job1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	restarts, err := state.GetTaskRestarts(updated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restarts["worker"].Count != 1 || restarts["ps"].Count != 0 {
+		t.Errorf("expected worker to be restarted once, got %v", restarts)
+	}
+}
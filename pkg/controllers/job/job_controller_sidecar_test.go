@@ -0,0 +1,119 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	busv1alpha1 "volcano.sh/apis/pkg/apis/bus/v1alpha1"
+
+	"volcano.sh/volcano/pkg/controllers/apis"
+	"volcano.sh/volcano/pkg/controllers/job/state"
+)
+
+func buildSidecarJob(minSuccess *int32, workerPhases, sidecarPhases map[v1.PodPhase]int32) *batch.Job {
+	return &batch.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "job1", Namespace: "test"},
+		Spec: batch.JobSpec{
+			MinAvailable: 3,
+			MinSuccess:   minSuccess,
+			Tasks: []batch.TaskSpec{
+				{Name: "worker", Replicas: 2},
+				{
+					Name:     "log-shipper",
+					Replicas: 1,
+					Template: v1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: map[string]string{state.TaskRoleAnnotationKey: state.TaskRoleSidecar},
+						},
+					},
+				},
+			},
+		},
+		Status: batch.JobStatus{
+			State: batch.JobState{Phase: batch.Running},
+			TaskStatusCount: map[string]batch.TaskState{
+				"worker":      {Phase: workerPhases},
+				"log-shipper": {Phase: sidecarPhases},
+			},
+		},
+	}
+}
+
+func TestRunningStateWithSidecar(t *testing.T) {
+	one := int32(1)
+	testcases := []struct {
+		Name     string
+		Job      *batch.Job
+		Expected batch.JobPhase
+	}{
+		{
+			Name:     "workers running",
+			Job:      buildSidecarJob(nil, map[v1.PodPhase]int32{v1.PodRunning: 1, v1.PodSucceeded: 1}, map[v1.PodPhase]int32{v1.PodRunning: 1}),
+			Expected: batch.Running,
+		},
+		{
+			Name:     "workers succeeded",
+			Job:      buildSidecarJob(nil, map[v1.PodPhase]int32{v1.PodSucceeded: 2}, map[v1.PodPhase]int32{v1.PodRunning: 1}),
+			Expected: batch.Completing,
+		},
+		{
+			Name:     "sidecar failed",
+			Job:      buildSidecarJob(nil, map[v1.PodPhase]int32{v1.PodSucceeded: 2}, map[v1.PodPhase]int32{v1.PodFailed: 1}),
+			Expected: batch.Completing,
+		},
+		{
+			Name:     "worker failed",
+			Job:      buildSidecarJob(nil, map[v1.PodPhase]int32{v1.PodSucceeded: 1, v1.PodFailed: 1}, map[v1.PodPhase]int32{v1.PodRunning: 1}),
+			Expected: batch.Failed,
+		},
+		{
+			Name:     "min success of workers",
+			Job:      buildSidecarJob(&one, map[v1.PodPhase]int32{v1.PodSucceeded: 1, v1.PodRunning: 1}, map[v1.PodPhase]int32{v1.PodRunning: 1}),
+			Expected: batch.Completing,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			jobInfo := &apis.JobInfo{Namespace: "test", Name: "job1", Job: testcase.Job}
+			state.SyncJob = func(job *apis.JobInfo, fn state.UpdateStatusFn) error {
+				status := job.Job.Status.DeepCopy()
+				for _, taskStatus := range status.TaskStatusCount {
+					status.Pending += taskStatus.Phase[v1.PodPending]
+					status.Running += taskStatus.Phase[v1.PodRunning]
+					status.Succeeded += taskStatus.Phase[v1.PodSucceeded]
+					status.Failed += taskStatus.Phase[v1.PodFailed]
+				}
+				fn(status)
+				job.Job.Status = *status
+				return nil
+			}
+
+			if err := state.NewState(jobInfo).Execute(state.Action{Action: busv1alpha1.SyncJobAction}); err != nil {
+				t.Fatal(err)
+			}
+			if phase := jobInfo.Job.Status.State.Phase; phase != testcase.Expected {
+				t.Errorf("expected phase %s, got %s", testcase.Expected, phase)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	busv1alpha1 "volcano.sh/apis/pkg/apis/bus/v1alpha1"
+
+	"volcano.sh/volcano/pkg/controllers/apis"
+	jobcache "volcano.sh/volcano/pkg/controllers/cache"
+	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
+	"volcano.sh/volcano/pkg/controllers/job/state"
+)
+
+// waitDependsOnTaskStarted checks whether the dependencies of the task meet its startup policy.
+// If they do not within the timeout of the policy, a TaskFailed event of the task is raised.
+func (cc *jobcontroller) waitDependsOnTaskStarted(taskIndex int, job *batch.Job, policy state.TaskStartupPolicy) bool {
+	taskName := job.Spec.Tasks[taskIndex].Name
+	dependsOn := job.Spec.Tasks[taskIndex].DependsOn
+
+	started := 0
+	allCreated := true
+	var waitingSince time.Time
+	for _, dependsOnTask := range dependsOn.Name {
+		taskStarted, created, createdAt := cc.isDependsOnTaskStarted(dependsOnTask, job, policy)
+		if taskStarted {
+			started++
+		}
+		allCreated = allCreated && created
+		if createdAt.After(waitingSince) {
+			waitingSince = createdAt
+		}
+	}
+
+	if len(dependsOn.Name) > 1 && dependsOn.Iteration == batch.IterationAny {
+		if started > 0 {
+			return true
+		}
+	} else if started == len(dependsOn.Name) {
+		return true
+	}
+
+	// The timeout starts once all pods of the dependencies are created, so that it does not
+	// include the time the dependencies wait to be scheduled as a gang.
+	if policy.Timeout != nil && allCreated {
+		cc.checkStartupTimeout(job, taskName, waitingSince, policy.Timeout.Duration)
+	}
+	return false
+}
+
+// isDependsOnTaskStarted checks whether enough pods of the task meet the startup condition of the policy,
+// it also returns whether all pods of the task are created and the creation time of the latest one.
+func (cc *jobcontroller) isDependsOnTaskStarted(task string, job *batch.Job, policy state.TaskStartupPolicy) (bool, bool, time.Time) {
+	ts, found := jobhelpers.GetTaskSpec(job, task)
+	if !found {
+		return false, false, time.Time{}
+	}
+	minPods := ts.Replicas
+	if ts.MinAvailable != nil {
+		minPods = *ts.MinAvailable
+	}
+	if policy.MinPods != nil {
+		minPods = *policy.MinPods
+	}
+
+	var startedPods int32
+	created := true
+	var createdAt time.Time
+	for _, podName := range jobhelpers.GetPodsNameUnderTask(task, job) {
+		pod, err := cc.podLister.Pods(job.Namespace).Get(podName)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				// The job has been deleted, nothing to wait for.
+				if _, errGetJob := cc.jobLister.Jobs(job.Namespace).Get(job.Name); apierrors.IsNotFound(errGetJob) {
+					return true, true, time.Time{}
+				}
+			} else {
+				klog.Errorf("Failed to get pod %v/%v %v", job.Namespace, podName, err)
+			}
+			created = false
+			continue
+		}
+
+		if pod.CreationTimestamp.After(createdAt) {
+			createdAt = pod.CreationTimestamp.Time
+		}
+		if policy.PodStarted(pod) {
+			startedPods++
+		} else {
+			klog.V(5).Infof("Pod %v/%v of depends on task %s does not meet startup condition %s", pod.Namespace, pod.Name, task, policy.Condition)
+		}
+	}
+	return startedPods >= minPods, created, createdAt
+}
+
+// checkStartupTimeout raises a TaskFailed event of the task once its dependencies have been waited
+// for longer than the timeout since waitingSince, or checks the task again when the timeout expires.
+func (cc *jobcontroller) checkStartupTimeout(job *batch.Job, taskName string, waitingSince time.Time, timeout time.Duration) {
+	jobKey := jobcache.JobKeyByName(job.Namespace, job.Name)
+	queue := cc.getWorkerQueue(jobKey)
+
+	if remaining := timeout - time.Since(waitingSince); remaining > 0 {
+		queue.AddAfter(apis.Request{
+			Namespace: job.Namespace,
+			JobName:   job.Name,
+			JobUid:    job.UID,
+			Event:     busv1alpha1.OutOfSyncEvent,
+		}, remaining)
+		return
+	}
+
+	// The event is raised once for the same pods of the dependencies.
+	key := jobKey + "/" + taskName
+	cc.startupTimeoutsLock.Lock()
+	raised, found := cc.startupTimeouts[key]
+	if found && raised.Equal(waitingSince) {
+		cc.startupTimeoutsLock.Unlock()
+		return
+	}
+	cc.startupTimeouts[key] = waitingSince
+	cc.startupTimeoutsLock.Unlock()
+
+	msg := fmt.Sprintf("Dependencies of task %s are not started within %s", taskName, timeout)
+	klog.V(3).Infof("%s, Job <%s/%s>", msg, job.Namespace, job.Name)
+	cc.recorder.Event(job, v1.EventTypeWarning, TaskStartupTimeoutReason, msg)
+	queue.Add(apis.Request{
+		Namespace:  job.Namespace,
+		JobName:    job.Name,
+		JobUid:     job.UID,
+		TaskName:   taskName,
+		Event:      busv1alpha1.TaskFailedEvent,
+		JobVersion: job.Status.Version,
+	})
+}
+
+// cleanupStartupTimeouts forgets the startup timeouts raised for the job.
+func (cc *jobcontroller) cleanupStartupTimeouts(jobKey string) {
+	cc.startupTimeoutsLock.Lock()
+	defer cc.startupTimeoutsLock.Unlock()
+	for key := range cc.startupTimeouts {
+		if strings.HasPrefix(key, jobKey+"/") {
+			delete(cc.startupTimeouts, key)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	busv1alpha1 "volcano.sh/apis/pkg/apis/bus/v1alpha1"
+
+	"volcano.sh/volcano/pkg/controllers/apis"
+	jobcache "volcano.sh/volcano/pkg/controllers/cache"
+	"volcano.sh/volcano/pkg/controllers/job/state"
+)
+
+func buildStartupPolicyJob(policy string) *batch.Job {
+	return &batch.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "job1",
+			Namespace:   "test",
+			Annotations: map[string]string{state.StartupPolicyAnnotationKey: policy},
+		},
+		Spec: batch.JobSpec{
+			Tasks: []batch.TaskSpec{
+				{Name: "ps", Replicas: 2},
+				{Name: "worker", Replicas: 2, DependsOn: &batch.DependsOn{Name: []string{"ps"}}},
+			},
+		},
+	}
+}
+
+func buildStartupPod(name string, phase v1.PodPhase, created time.Time, conditions ...v1.PodCondition) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test", CreationTimestamp: metav1.NewTime(created)},
+		Status:     v1.PodStatus{Phase: phase, Conditions: conditions},
+	}
+}
+
+func TestWaitDependsOnTaskStarted(t *testing.T) {
+	ready := v1.PodCondition{Type: v1.PodReady, Status: v1.ConditionTrue}
+	notReady := v1.PodCondition{Type: v1.PodReady, Status: v1.ConditionFalse}
+	loaded := v1.PodCondition{Type: "example.com/model-loaded", Status: v1.ConditionTrue}
+	created := time.Now().Add(-time.Minute)
+
+	testcases := []struct {
+		Name     string
+		Policy   string
+		Pods     []*v1.Pod
+		Expected bool
+	}{
+		{
+			Name:   "all pods ready",
+			Policy: `{}`,
+			Pods: []*v1.Pod{
+				buildStartupPod("job1-ps-0", v1.PodRunning, created, ready),
+				buildStartupPod("job1-ps-1", v1.PodRunning, created, ready),
+			},
+			Expected: true,
+		},
+		{
+			Name:   "running but not ready",
+			Policy: `{}`,
+			Pods: []*v1.Pod{
+				buildStartupPod("job1-ps-0", v1.PodRunning, created, ready),
+				buildStartupPod("job1-ps-1", v1.PodRunning, created, notReady),
+			},
+			Expected: false,
+		},
+		{
+			Name:   "running condition",
+			Policy: `{"condition":"Running"}`,
+			Pods: []*v1.Pod{
+				buildStartupPod("job1-ps-0", v1.PodRunning, created, notReady),
+				buildStartupPod("job1-ps-1", v1.PodSucceeded, created),
+			},
+			Expected: true,
+		},
+		{
+			Name:   "min pods of the task",
+			Policy: `{"tasks":{"worker":{"minPods":1}}}`,
+			Pods: []*v1.Pod{
+				buildStartupPod("job1-ps-0", v1.PodRunning, created, ready),
+			},
+			Expected: true,
+		},
+		{
+			Name:   "readiness gate",
+			Policy: `{"condition":"ReadinessGate","readinessGate":"example.com/model-loaded"}`,
+			Pods: []*v1.Pod{
+				buildStartupPod("job1-ps-0", v1.PodRunning, created, ready, loaded),
+				buildStartupPod("job1-ps-1", v1.PodRunning, created, ready),
+			},
+			Expected: false,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			fakeController := newFakeController()
+			job := buildStartupPolicyJob(testcase.Policy)
+			for _, pod := range testcase.Pods {
+				fakeController.podInformer.Informer().GetIndexer().Add(pod)
+			}
+			fakeController.jobInformer.Informer().GetIndexer().Add(job)
+
+			if ready := fakeController.waitDependsOnTaskMeetCondition(1, job); ready != testcase.Expected {
+				t.Errorf("expected dependencies met %v, got %v", testcase.Expected, ready)
+			}
+		})
+	}
+}
+
+func TestStartupTimeout(t *testing.T) {
+	fakeController := newFakeController()
+	job := buildStartupPolicyJob(`{"timeout":"30s"}`)
+	fakeController.jobInformer.Informer().GetIndexer().Add(job)
+	for _, name := range []string{"job1-ps-0", "job1-ps-1"} {
+		fakeController.podInformer.Informer().GetIndexer().Add(buildStartupPod(name, v1.PodPending, time.Now().Add(-time.Minute)))
+	}
+	queue := fakeController.getWorkerQueue(jobcache.JobKeyByName("test", "job1"))
+
+	if fakeController.waitDependsOnTaskMeetCondition(1, job) {
+		t.Fatalf("expected dependencies not to be met")
+	}
+	if queue.Len() != 1 {
+		t.Fatalf("expected a request to be queued, got %d", queue.Len())
+	}
+	item, _ := queue.Get()
+	req := item.(apis.Request)
+	queue.Done(item)
+	if req.Event != busv1alpha1.TaskFailedEvent || req.TaskName != "worker" {
+		t.Errorf("expected TaskFailed event of worker, got %s of %s", req.Event, req.TaskName)
+	}
+
+	// The timeout is raised once for the same pods.
+	fakeController.waitDependsOnTaskMeetCondition(1, job)
+	if queue.Len() != 0 {
+		t.Errorf("expected the timeout not to be raised again, got %d requests", queue.Len())
+	}
+}
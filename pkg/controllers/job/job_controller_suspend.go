@@ -0,0 +1,224 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	scheduling "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano/pkg/controllers/apis"
+	"volcano.sh/volcano/pkg/controllers/job/state"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// suspendJob records the progress of the Job and kills its Pods, the Succeeded and Failed Pods are retained.
+// The PodGroup is kept in killPods but released, so that the resources return to the queue.
+func (cc *jobcontroller) suspendJob(jobInfo *apis.JobInfo, updateStatus state.UpdateStatusFn) error {
+	job := jobInfo.Job
+	if job.DeletionTimestamp != nil {
+		klog.Infof("Job <%s/%s> is terminating, skip management process.", job.Namespace, job.Name)
+		return nil
+	}
+
+	klog.V(3).Infof("Suspending Job <%s/%s>, current version %d", job.Namespace, job.Name, job.Status.Version)
+	if !apis.IsSuspended(job) {
+		job = job.DeepCopy()
+		if err := apis.SetSuspensionRecord(job, newSuspensionRecord(jobInfo)); err != nil {
+			return err
+		}
+		newJob, err := cc.updateJobAndCache(job)
+		if err != nil {
+			return err
+		}
+		jobInfo = jobInfo.Clone()
+		jobInfo.Job = newJob
+	}
+
+	return cc.killJob(jobInfo, state.PodRetainPhaseSoft, updateStatus)
+}
+
+// resumeJob resumes a suspended Job in place: the PodGroup is scheduled again
+// and the missing Pods are recreated with the same names and indices.
+func (cc *jobcontroller) resumeJob(jobInfo *apis.JobInfo, updateStatus state.UpdateStatusFn) error {
+	job := jobInfo.Job
+	if job.DeletionTimestamp != nil {
+		klog.Infof("Job <%s/%s> is terminating, skip management process.", job.Namespace, job.Name)
+		return nil
+	}
+
+	klog.V(3).Infof("Resuming Job <%s/%s>, current version %d", job.Namespace, job.Name, job.Status.Version)
+	// Resume the PodGroup first, the Job is still suspended if it fails and the resume is retried.
+	pg, err := cc.getPodGroupByJob(job)
+	if err != nil && !apierrors.IsNotFound(err) {
+		klog.Errorf("Failed to find PodGroup of Job: %s/%s, error: %s", job.Namespace, job.Name, err.Error())
+		return err
+	}
+	if pg != nil {
+		if err := cc.suspendPodGroup(pg, false); err != nil {
+			return err
+		}
+	}
+
+	record, err := apis.GetSuspensionRecord(job)
+	if err != nil {
+		return err
+	}
+	if record != nil && record.ResumedAt == nil {
+		now := metav1.Now()
+		record.ResumedAt = &now
+		job = job.DeepCopy()
+		if err := apis.SetSuspensionRecord(job, record); err != nil {
+			return err
+		}
+		newJob, err := cc.updateJobAndCache(job)
+		if err != nil {
+			return err
+		}
+		jobInfo = jobInfo.Clone()
+		jobInfo.Job = newJob
+	}
+
+	return cc.syncJob(jobInfo, updateStatus)
+}
+
+// suspendPodGroup marks the PodGroup as suspended so that the scheduler skips it, a suspended PodGroup
+// is moved back to Pending to release the resources reserved for it in the queue.
+func (cc *jobcontroller) suspendPodGroup(pg *scheduling.PodGroup, suspend bool) error {
+	_, suspended := pg.Annotations[schedulingapi.PodGroupSuspendedAnnotationKey]
+	if suspended == suspend && (!suspend || pg.Status.Phase == scheduling.PodGroupPending) {
+		return nil
+	}
+
+	pg = pg.DeepCopy()
+	if suspend {
+		if pg.Annotations == nil {
+			pg.Annotations = map[string]string{}
+		}
+		pg.Annotations[schedulingapi.PodGroupSuspendedAnnotationKey] = "true"
+		pg.Status.Phase = scheduling.PodGroupPending
+	} else {
+		delete(pg.Annotations, schedulingapi.PodGroupSuspendedAnnotationKey)
+	}
+
+	if _, err := cc.vcClient.SchedulingV1beta1().PodGroups(pg.Namespace).Update(context.TODO(), pg, metav1.UpdateOptions{}); err != nil {
+		klog.Errorf("Failed to update PodGroup %s/%s: %v", pg.Namespace, pg.Name, err)
+		return err
+	}
+	return nil
+}
+
+func (cc *jobcontroller) updateJobAndCache(job *batch.Job) (*batch.Job, error) {
+	newJob, err := cc.vcClient.BatchV1alpha1().Jobs(job.Namespace).Update(context.TODO(), job, metav1.UpdateOptions{})
+	if err != nil {
+		klog.Errorf("Failed to update Job %v/%v: %v", job.Namespace, job.Name, err)
+		return nil, err
+	}
+	if err := cc.cache.Update(newJob); err != nil {
+		klog.Errorf("Failed to update Job %v/%v in cache: %v", newJob.Namespace, newJob.Name, err)
+		return nil, err
+	}
+	return newJob, nil
+}
+
+// newSuspensionRecord records the finished Pods and the node of each Pod of the Job.
+func newSuspensionRecord(jobInfo *apis.JobInfo) *apis.SuspensionRecord {
+	record := &apis.SuspensionRecord{
+		SuspendedAt: metav1.Now(),
+		RetryCount:  jobInfo.Job.Status.RetryCount,
+		Tasks:       map[string]apis.TaskProgress{},
+	}
+	for taskName, pods := range jobInfo.Pods {
+		progress := apis.TaskProgress{Nodes: map[string]string{}}
+		for _, pod := range pods {
+			if pod.Spec.NodeName != "" {
+				progress.Nodes[pod.Name] = pod.Spec.NodeName
+			}
+			index, err := strconv.Atoi(pod.Annotations[batch.TaskIndex])
+			if err != nil {
+				continue
+			}
+			switch pod.Status.Phase {
+			case v1.PodSucceeded:
+				progress.Succeeded = append(progress.Succeeded, index)
+			case v1.PodFailed:
+				progress.Failed = append(progress.Failed, index)
+			}
+		}
+		sort.Ints(progress.Succeeded)
+		sort.Ints(progress.Failed)
+		record.Tasks[taskName] = progress
+	}
+	return record
+}
+
+// previousNodesOfJob returns the nodes the Pods ran on before the Job was suspended,
+// if the Job prefers to resume on them.
+func previousNodesOfJob(job *batch.Job) map[string]string {
+	if job.Annotations[apis.ResumeOnPreviousNodesAnnotationKey] != "true" {
+		return nil
+	}
+	record, err := apis.GetSuspensionRecord(job)
+	if err != nil {
+		klog.Warningf("Failed to get suspension record of Job <%s/%s>: %v", job.Namespace, job.Name, err)
+		return nil
+	}
+	if record == nil {
+		return nil
+	}
+
+	nodes := map[string]string{}
+	for _, progress := range record.Tasks {
+		for podName, nodeName := range progress.Nodes {
+			nodes[podName] = nodeName
+		}
+	}
+	return nodes
+}
+
+// preferPreviousNode adds a preferred node affinity to the node the Pod ran on before.
+func preferPreviousNode(pod *v1.Pod, nodeName string) {
+	if nodeName == "" {
+		return
+	}
+	if pod.Spec.Affinity == nil {
+		pod.Spec.Affinity = &v1.Affinity{}
+	}
+	if pod.Spec.Affinity.NodeAffinity == nil {
+		pod.Spec.Affinity.NodeAffinity = &v1.NodeAffinity{}
+	}
+	nodeAffinity := pod.Spec.Affinity.NodeAffinity
+	nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+		v1.PreferredSchedulingTerm{
+			Weight: 100,
+			Preference: v1.NodeSelectorTerm{
+				MatchFields: []v1.NodeSelectorRequirement{{
+					Key:      "metadata.name",
+					Operator: v1.NodeSelectorOpIn,
+					Values:   []string{nodeName},
+				}},
+			},
+		})
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	scheduling "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano/pkg/controllers/apis"
+	"volcano.sh/volcano/pkg/controllers/job/state"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+)
+
+func buildSuspendTestPod(job *batch.Job, index string, phase v1.PodPhase, nodeName string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      job.Name + "-worker-" + index,
+			Namespace: job.Namespace,
+			Annotations: map[string]string{
+				batch.TaskIndex:   index,
+				batch.TaskSpecKey: "worker",
+				batch.JobNameKey:  job.Name,
+			},
+		},
+		Spec:   v1.PodSpec{NodeName: nodeName},
+		Status: v1.PodStatus{Phase: phase},
+	}
+}
+
+func TestSuspendAndResumeJob(t *testing.T) {
+	namespace := "test"
+	job := &batch.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "job1",
+			Namespace:       namespace,
+			UID:             "uid1",
+			ResourceVersion: "100",
+			Annotations: map[string]string{
+				apis.ResumeOnPreviousNodesAnnotationKey: "true",
+			},
+		},
+		Spec: batch.JobSpec{
+			Queue:        "default",
+			MinAvailable: 2,
+			Tasks: []batch.TaskSpec{{
+				Name:     "worker",
+				Replicas: 2,
+			}},
+		},
+		Status: batch.JobStatus{
+			State:      batch.JobState{Phase: batch.Running},
+			RetryCount: 1,
+		},
+	}
+	succeeded := buildSuspendTestPod(job, "0", v1.PodSucceeded, "node-a")
+	running := buildSuspendTestPod(job, "1", v1.PodRunning, "node-b")
+	pg := &scheduling.PodGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "job1-uid1",
+			Namespace: namespace,
+		},
+		Spec:   scheduling.PodGroupSpec{MinMember: 2, Queue: "default"},
+		Status: scheduling.PodGroupStatus{Phase: scheduling.PodGroupRunning},
+	}
+	queue := &scheduling.Queue{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+	}
+
+	fakeController := newFakeController()
+	state.KillJob = fakeController.killJob
+	state.SyncJob = fakeController.syncJob
+	state.SuspendJob = fakeController.suspendJob
+	state.ResumeJob = fakeController.resumeJob
+
+	if _, err := fakeController.vcClient.BatchV1alpha1().Jobs(namespace).Create(context.TODO(), job, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := fakeController.cache.Add(job); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fakeController.vcClient.SchedulingV1beta1().PodGroups(namespace).Create(context.TODO(), pg, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	fakeController.pgInformer.Informer().GetIndexer().Add(pg)
+	fakeController.queueInformer.Informer().GetIndexer().Add(queue)
+	for _, pod := range []*v1.Pod{succeeded, running} {
+		if _, err := fakeController.kubeClient.CoreV1().Pods(namespace).Create(context.TODO(), pod, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	jobInfo := &apis.JobInfo{
+		Namespace: namespace,
+		Name:      job.Name,
+		Job:       job,
+		Pods: map[string]map[string]*v1.Pod{
+			"worker": {succeeded.Name: succeeded, running.Name: running},
+		},
+	}
+	if err := state.NewState(jobInfo).Execute(state.Action{Action: apis.SuspendJobAction}); err != nil {
+		t.Fatalf("failed to suspend job: %v", err)
+	}
+
+	suspended, err := fakeController.vcClient.BatchV1alpha1().Jobs(namespace).Get(context.TODO(), job.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if suspended.Status.State.Phase != batch.Aborting {
+		t.Errorf("expected job to be %s but got %s", batch.Aborting, suspended.Status.State.Phase)
+	}
+	if !apis.IsSuspended(suspended) {
+		t.Fatalf("expected job to be suspended")
+	}
+	record, _ := apis.GetSuspensionRecord(suspended)
+	expectedProgress := apis.TaskProgress{
+		Succeeded: []int{0},
+		Nodes:     map[string]string{succeeded.Name: "node-a", running.Name: "node-b"},
+	}
+	if !reflect.DeepEqual(record.Tasks["worker"], expectedProgress) || record.RetryCount != 1 {
+		t.Errorf("unexpected suspension record %+v", record)
+	}
+
+	if _, err := fakeController.kubeClient.CoreV1().Pods(namespace).Get(context.TODO(), running.Name, metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected running pod to be deleted, got %v", err)
+	}
+	if _, err := fakeController.kubeClient.CoreV1().Pods(namespace).Get(context.TODO(), succeeded.Name, metav1.GetOptions{}); err != nil {
+		t.Errorf("expected succeeded pod to be kept, got %v", err)
+	}
+
+	suspendedPG, err := fakeController.vcClient.SchedulingV1beta1().PodGroups(namespace).Get(context.TODO(), pg.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected podgroup to be kept, got %v", err)
+	}
+	if suspendedPG.Annotations[schedulingapi.PodGroupSuspendedAnnotationKey] != "true" || suspendedPG.Status.Phase != scheduling.PodGroupPending {
+		t.Errorf("expected podgroup to be suspended and pending, got %v %s", suspendedPG.Annotations, suspendedPG.Status.Phase)
+	}
+
+	// The job is aborted once the running pod is gone.
+	suspended.Status.State.Phase = batch.Aborted
+	fakeController.pgInformer.Informer().GetIndexer().Update(suspendedPG)
+	jobInfo = &apis.JobInfo{
+		Namespace: namespace,
+		Name:      job.Name,
+		Job:       suspended,
+		Pods: map[string]map[string]*v1.Pod{
+			"worker": {succeeded.Name: succeeded},
+		},
+	}
+	if err := state.NewState(jobInfo).Execute(state.Action{Action: "ResumeJob"}); err != nil {
+		t.Fatalf("failed to resume job: %v", err)
+	}
+
+	resumed, err := fakeController.vcClient.BatchV1alpha1().Jobs(namespace).Get(context.TODO(), job.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resumed.Status.State.Phase != batch.Pending || resumed.Status.RetryCount != 1 {
+		t.Errorf("expected job to be pending with retry count 1, got %s %d", resumed.Status.State.Phase, resumed.Status.RetryCount)
+	}
+	if apis.IsSuspended(resumed) {
+		t.Errorf("expected job to be resumed")
+	}
+	resumedPG, err := fakeController.vcClient.SchedulingV1beta1().PodGroups(namespace).Get(context.TODO(), pg.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, found := resumedPG.Annotations[schedulingapi.PodGroupSuspendedAnnotationKey]; found {
+		t.Errorf("expected podgroup to be resumed")
+	}
+
+	nodes := previousNodesOfJob(resumed)
+	pod := createJobPod(resumed, &v1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Name: "worker"}}, "", 1, false)
+	preferPreviousNode(pod, nodes[pod.Name])
+	terms := pod.Spec.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+	if len(terms) != 1 || terms[0].Preference.MatchFields[0].Values[0] != "node-b" {
+		t.Errorf("expected pod %s to prefer node-b, got %v", pod.Name, terms)
+	}
+}
@@ -236,6 +236,10 @@ func applyPolicies(job *batch.Job, req *apis.Request) (delayAct *delayAction) {
 						return
 					}
 				}
+				// The failure or completion of a sidecar task does not affect the Job.
+				if state.IsSidecarTask(&task) && isSidecarIgnoredEvent(req.Event) {
+					return
+				}
 				break
 			}
 		}
@@ -270,6 +274,10 @@ func applyPolicies(job *batch.Job, req *apis.Request) (delayAct *delayAction) {
 	return
 }
 
+func isSidecarIgnoredEvent(event v1alpha1.Event) bool {
+	return event == v1alpha1.PodFailedEvent || event == v1alpha1.TaskFailedEvent || event == v1alpha1.TaskCompletedEvent
+}
+
 func shouldConfigureTimeout(event v1alpha1.Event) bool {
 	return event == v1alpha1.PodPendingEvent
 }
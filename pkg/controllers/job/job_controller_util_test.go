@@ -28,6 +28,7 @@ import (
 	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
 	busv1alpha1 "volcano.sh/apis/pkg/apis/bus/v1alpha1"
 	"volcano.sh/volcano/pkg/controllers/apis"
+	"volcano.sh/volcano/pkg/controllers/job/state"
 )
 
 func TestMakePodName(t *testing.T) {
@@ -731,6 +732,44 @@ func TestApplyPolicies(t *testing.T) {
 			Request:   &apis.Request{},
 			ReturnVal: busv1alpha1.SyncJobAction,
 		},
+		{
+			Name: "Test Apply policies where a sidecar task failed",
+			Job: &v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "job1",
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.JobSpec{
+					SchedulerName: "volcano",
+					Tasks: []v1alpha1.TaskSpec{
+						{
+							Name:     "worker",
+							Replicas: 2,
+						},
+						{
+							Name:     "log-shipper",
+							Replicas: 1,
+							Template: v1.PodTemplateSpec{
+								ObjectMeta: metav1.ObjectMeta{
+									Annotations: map[string]string{state.TaskRoleAnnotationKey: state.TaskRoleSidecar},
+								},
+							},
+						},
+					},
+					Policies: []v1alpha1.LifecyclePolicy{
+						{
+							Action: busv1alpha1.AbortJobAction,
+							Event:  busv1alpha1.PodFailedEvent,
+						},
+					},
+				},
+			},
+			Request: &apis.Request{
+				TaskName: "log-shipper",
+				Event:    busv1alpha1.PodFailedEvent,
+			},
+			ReturnVal: busv1alpha1.SyncJobAction,
+		},
 	}
 
 	for i, testcase := range testcases {
@@ -0,0 +1,251 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deepspeed
+
+import (
+	"flag"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	"volcano.sh/apis/pkg/apis/helpers"
+	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
+	pluginsinterface "volcano.sh/volcano/pkg/controllers/job/plugins/interface"
+)
+
+const (
+	// DeepSpeedPluginName is the name of the plugin
+	DeepSpeedPluginName = "deepspeed"
+	// DefaultPort is the default port of the master
+	DefaultPort = 29500
+	// DefaultMaster is the default task name of master host
+	DefaultMaster = "master"
+	// DefaultWorker is the default task name of worker host
+	DefaultWorker = "worker"
+	// GPUResourceName is the resource the slots of a host are counted from by default
+	GPUResourceName = "nvidia.com/gpu"
+
+	// HostfileKey is the key of the hostfile in the ConfigMap
+	HostfileKey = "hostfile"
+	// HostfileMountPath is the directory the hostfile is mounted to
+	HostfileMountPath = "/etc/volcano/deepspeed"
+
+	// EnvMasterAddr is the env name of master addr
+	EnvMasterAddr = "MASTER_ADDR"
+	// EnvMasterPort is the env name of master port
+	EnvMasterPort = "MASTER_PORT"
+	// EnvHostfile is the env name of the path of the hostfile
+	EnvHostfile = "DEEPSPEED_HOSTFILE"
+)
+
+type deepSpeedPlugin struct {
+	deepSpeedArguments []string
+	clientset          pluginsinterface.PluginClientset
+	masterName         string
+	workerName         string
+	port               int
+	slots              int
+}
+
+// New creates deepspeed plugin.
+func New(client pluginsinterface.PluginClientset, arguments []string) pluginsinterface.PluginInterface {
+	dp := deepSpeedPlugin{deepSpeedArguments: arguments, clientset: client}
+	dp.addFlags()
+	return &dp
+}
+
+func (dp *deepSpeedPlugin) addFlags() {
+	flagSet := flag.NewFlagSet(dp.Name(), flag.ContinueOnError)
+	flagSet.StringVar(&dp.masterName, "master", DefaultMaster, "name of master role task")
+	flagSet.StringVar(&dp.workerName, "worker", DefaultWorker, "name of worker role task")
+	flagSet.IntVar(&dp.port, "port", DefaultPort, "open port for containers")
+	flagSet.IntVar(&dp.slots, "slots", 0, "slots of each host, defaults to the gpus requested by the task")
+	if err := flagSet.Parse(dp.deepSpeedArguments); err != nil {
+		klog.Errorf("plugin %s flagset parse failed, err: %v", dp.Name(), err)
+	}
+}
+
+func (dp *deepSpeedPlugin) Name() string {
+	return DeepSpeedPluginName
+}
+
+func (dp *deepSpeedPlugin) OnPodCreate(pod *v1.Pod, job *batch.Job) error {
+	taskType := jobhelpers.GetTaskKey(pod)
+	if taskType != dp.masterName && taskType != dp.workerName {
+		return nil
+	}
+
+	// The first pod of the master task, or of the worker task if there is no master, is the master of the job.
+	masterIndex := jobhelpers.GetTaskIndexUnderJob(dp.masterName, job)
+	if masterIndex == -1 {
+		masterIndex = jobhelpers.GetTaskIndexUnderJob(dp.workerName, job)
+	}
+	if masterIndex == -1 {
+		klog.Errorf("job %v doesn't have task %v or %v", job.Name, dp.masterName, dp.workerName)
+		return nil
+	}
+
+	masterHosts := dp.generateTaskHosts(job.Spec.Tasks[masterIndex], job.Name)
+	envVars := []v1.EnvVar{{
+		Name:  EnvMasterAddr,
+		Value: masterHosts[0],
+	}, {
+		Name:  EnvMasterPort,
+		Value: strconv.Itoa(dp.port),
+	}, {
+		Name:  EnvHostfile,
+		Value: path.Join(HostfileMountPath, HostfileKey),
+	}}
+
+	for i := range pod.Spec.Containers {
+		dp.openContainerPort(i, pod)
+		pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, envVars...)
+	}
+	dp.mountHostfile(pod, job)
+
+	return nil
+}
+
+// generateHostfile generates the hostfile of the DeepSpeed launcher, one line of `<host> slots=<slots>`
+// for each pod, the master pods come before the worker pods.
+func (dp *deepSpeedPlugin) generateHostfile(job *batch.Job) string {
+	var builder strings.Builder
+	for _, taskName := range []string{dp.masterName, dp.workerName} {
+		taskIndex := jobhelpers.GetTaskIndexUnderJob(taskName, job)
+		if taskIndex == -1 {
+			continue
+		}
+		task := job.Spec.Tasks[taskIndex]
+		slots := dp.getSlots(task)
+		for _, host := range dp.generateTaskHosts(task, job.Name) {
+			builder.WriteString(fmt.Sprintf("%s slots=%d\n", host, slots))
+		}
+	}
+
+	return builder.String()
+}
+
+func (dp *deepSpeedPlugin) getSlots(task batch.TaskSpec) int {
+	if dp.slots > 0 {
+		return dp.slots
+	}
+
+	gpus := int64(0)
+	for _, c := range task.Template.Spec.Containers {
+		if quantity, found := c.Resources.Limits[GPUResourceName]; found {
+			gpus += quantity.Value()
+		}
+	}
+	if gpus == 0 {
+		return 1
+	}
+	return int(gpus)
+}
+
+func (dp *deepSpeedPlugin) generateTaskHosts(task batch.TaskSpec, jobName string) []string {
+	hosts := make([]string, 0, task.Replicas)
+	for i := 0; i < int(task.Replicas); i++ {
+		hostName := task.Template.Spec.Hostname
+		subdomain := task.Template.Spec.Subdomain
+		if len(hostName) == 0 {
+			hostName = jobhelpers.MakePodName(jobName, task.Name, i)
+		}
+		if len(subdomain) == 0 {
+			subdomain = jobName
+		}
+		hosts = append(hosts, hostName+"."+subdomain)
+	}
+
+	return hosts
+}
+
+func (dp *deepSpeedPlugin) openContainerPort(index int, pod *v1.Pod) {
+	for _, p := range pod.Spec.Containers[index].Ports {
+		if p.ContainerPort == int32(dp.port) {
+			return
+		}
+	}
+
+	pod.Spec.Containers[index].Ports = append(pod.Spec.Containers[index].Ports, v1.ContainerPort{
+		Name:          "deepspeedjob-port",
+		ContainerPort: int32(dp.port),
+	})
+}
+
+func (dp *deepSpeedPlugin) mountHostfile(pod *v1.Pod, job *batch.Job) {
+	cmName := dp.cmName(job)
+	pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{
+		Name: cmName,
+		VolumeSource: v1.VolumeSource{
+			ConfigMap: &v1.ConfigMapVolumeSource{
+				LocalObjectReference: v1.LocalObjectReference{
+					Name: cmName,
+				},
+			},
+		},
+	})
+
+	vm := v1.VolumeMount{
+		MountPath: HostfileMountPath,
+		Name:      cmName,
+	}
+	for i, c := range pod.Spec.Containers {
+		pod.Spec.Containers[i].VolumeMounts = append(c.VolumeMounts, vm)
+	}
+}
+
+func (dp *deepSpeedPlugin) OnJobAdd(job *batch.Job) error {
+	if job.Status.ControlledResources["plugin-"+dp.Name()] == dp.Name() {
+		return nil
+	}
+
+	data := map[string]string{HostfileKey: dp.generateHostfile(job)}
+	if err := helpers.CreateOrUpdateConfigMap(job, dp.clientset.KubeClients, data, dp.cmName(job)); err != nil {
+		return err
+	}
+
+	job.Status.ControlledResources["plugin-"+dp.Name()] = dp.Name()
+	return nil
+}
+
+func (dp *deepSpeedPlugin) OnJobDelete(job *batch.Job) error {
+	if job.Status.ControlledResources["plugin-"+dp.Name()] != dp.Name() {
+		return nil
+	}
+
+	if err := helpers.DeleteConfigmap(job, dp.clientset.KubeClients, dp.cmName(job)); err != nil {
+		return err
+	}
+
+	delete(job.Status.ControlledResources, "plugin-"+dp.Name())
+	return nil
+}
+
+func (dp *deepSpeedPlugin) OnJobUpdate(job *batch.Job) error {
+	// updates the hostfile when the job is scaled.
+	data := map[string]string{HostfileKey: dp.generateHostfile(job)}
+	return helpers.CreateOrUpdateConfigMap(job, dp.clientset.KubeClients, data, dp.cmName(job))
+}
+
+func (dp *deepSpeedPlugin) cmName(job *batch.Job) string {
+	return fmt.Sprintf("%s-%s", job.Name, dp.Name())
+}
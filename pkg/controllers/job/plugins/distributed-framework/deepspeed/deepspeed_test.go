@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deepspeed
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	pluginsinterface "volcano.sh/volcano/pkg/controllers/job/plugins/interface"
+)
+
+func buildTask(name string, replicas int32, gpus string) v1alpha1.TaskSpec {
+	container := v1.Container{Name: name}
+	if gpus != "" {
+		container.Resources.Limits = v1.ResourceList{GPUResourceName: resource.MustParse(gpus)}
+	}
+	return v1alpha1.TaskSpec{
+		Name:     name,
+		Replicas: replicas,
+		Template: v1.PodTemplateSpec{Spec: v1.PodSpec{Containers: []v1.Container{container}}},
+	}
+}
+
+func TestDeepSpeed(t *testing.T) {
+	testcases := []struct {
+		Name     string
+		Args     []string
+		Tasks    []v1alpha1.TaskSpec
+		Hostfile string
+	}{
+		{
+			Name:  "slots from gpus",
+			Tasks: []v1alpha1.TaskSpec{buildTask("master", 1, ""), buildTask("worker", 2, "8")},
+			Hostfile: "test-ds-master-0.test-ds slots=1\n" +
+				"test-ds-worker-0.test-ds slots=8\n" +
+				"test-ds-worker-1.test-ds slots=8\n",
+		},
+		{
+			Name:  "slots from arguments",
+			Args:  []string{"--slots=4"},
+			Tasks: []v1alpha1.TaskSpec{buildTask("worker", 2, "8"), buildTask("evaluator", 1, "")},
+			Hostfile: "test-ds-worker-0.test-ds slots=4\n" +
+				"test-ds-worker-1.test-ds slots=4\n",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			job := &v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-ds", Namespace: "test"},
+				Spec:       v1alpha1.JobSpec{Tasks: testcase.Tasks},
+				Status:     v1alpha1.JobStatus{ControlledResources: map[string]string{}},
+			}
+			client := fake.NewSimpleClientset()
+			dp := New(pluginsinterface.PluginClientset{KubeClients: client}, testcase.Args)
+			if err := dp.OnJobAdd(job); err != nil {
+				t.Fatalf("OnJobAdd failed: %v", err)
+			}
+
+			cm, err := client.CoreV1().ConfigMaps("test").Get(context.TODO(), "test-ds-deepspeed", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cm.Data[HostfileKey] != testcase.Hostfile {
+				t.Errorf("expected hostfile %q but got %q", testcase.Hostfile, cm.Data[HostfileKey])
+			}
+		})
+	}
+}
+
+func TestDeepSpeedPodEnv(t *testing.T) {
+	job := &v1alpha1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-ds"},
+		Spec:       v1alpha1.JobSpec{Tasks: []v1alpha1.TaskSpec{buildTask("master", 1, ""), buildTask("worker", 2, "8")}},
+	}
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-ds-worker-1",
+			Annotations: map[string]string{v1alpha1.TaskSpecKey: "worker"},
+		},
+		Spec: v1.PodSpec{Containers: []v1.Container{{Name: "worker"}}},
+	}
+
+	dp := New(pluginsinterface.PluginClientset{}, []string{"--port=5000"})
+	if err := dp.OnPodCreate(pod, job); err != nil {
+		t.Fatalf("OnPodCreate failed: %v", err)
+	}
+
+	expected := []v1.EnvVar{
+		{Name: EnvMasterAddr, Value: "test-ds-master-0.test-ds"},
+		{Name: EnvMasterPort, Value: "5000"},
+		{Name: EnvHostfile, Value: "/etc/volcano/deepspeed/hostfile"},
+	}
+	container := pod.Spec.Containers[0]
+	if !equality.Semantic.DeepEqual(container.Env, expected) {
+		t.Errorf("expected envs %v but got %v", expected, container.Env)
+	}
+	if len(container.VolumeMounts) != 1 || len(pod.Spec.Volumes) != 1 || pod.Spec.Volumes[0].ConfigMap.Name != "test-ds-deepspeed" {
+		t.Errorf("expected hostfile to be mounted, got %v %v", container.VolumeMounts, pod.Spec.Volumes)
+	}
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jax
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	"volcano.sh/volcano/pkg/controllers/job/helpers"
+	pluginsinterface "volcano.sh/volcano/pkg/controllers/job/plugins/interface"
+)
+
+const (
+	// JaxPluginName is the name of the plugin
+	JaxPluginName = "jax"
+	// DefaultPort is the default port of the coordinator
+	DefaultPort = 1234
+	// DefaultMaster is the default task name of master host
+	DefaultMaster = "master"
+	// DefaultWorker is the default task name of worker host
+	DefaultWorker = "worker"
+
+	// EnvCoordinatorAddress is the env name of the coordinator address
+	EnvCoordinatorAddress = "JAX_COORDINATOR_ADDRESS"
+	// EnvNumProcesses is the env name of the number of processes
+	EnvNumProcesses = "JAX_NUM_PROCESSES"
+	// EnvProcessID is the env name of the process id
+	EnvProcessID = "JAX_PROCESS_ID"
+)
+
+type jaxPlugin struct {
+	jaxArguments []string
+	clientset    pluginsinterface.PluginClientset
+	masterName   string
+	workerName   string
+	port         int
+}
+
+// New creates jax plugin.
+func New(client pluginsinterface.PluginClientset, arguments []string) pluginsinterface.PluginInterface {
+	jp := jaxPlugin{jaxArguments: arguments, clientset: client}
+	jp.addFlags()
+	return &jp
+}
+
+func (jp *jaxPlugin) addFlags() {
+	flagSet := flag.NewFlagSet(jp.Name(), flag.ContinueOnError)
+	flagSet.StringVar(&jp.masterName, "master", DefaultMaster, "name of master role task")
+	flagSet.StringVar(&jp.workerName, "worker", DefaultWorker, "name of worker role task")
+	flagSet.IntVar(&jp.port, "port", DefaultPort, "port of the coordinator")
+	if err := flagSet.Parse(jp.jaxArguments); err != nil {
+		klog.Errorf("plugin %s flagset parse failed, err: %v", jp.Name(), err)
+	}
+}
+
+func (jp *jaxPlugin) Name() string {
+	return JaxPluginName
+}
+
+func (jp *jaxPlugin) OnPodCreate(pod *v1.Pod, job *batch.Job) error {
+	taskType := helpers.GetTaskKey(pod)
+	if taskType != jp.masterName && taskType != jp.workerName {
+		return nil
+	}
+
+	// The first pod of the master task, or of the worker task if there is no master, is the coordinator.
+	coordinatorIndex := helpers.GetTaskIndexUnderJob(jp.masterName, job)
+	if coordinatorIndex == -1 {
+		coordinatorIndex = helpers.GetTaskIndexUnderJob(jp.workerName, job)
+	}
+	if coordinatorIndex == -1 {
+		klog.Errorf("job %v doesn't have task %v or %v", job.Name, jp.masterName, jp.workerName)
+		return nil
+	}
+
+	index, err := strconv.Atoi(helpers.GetPodIndexUnderTask(pod))
+	if err != nil {
+		return err
+	}
+	// Processes are ranked by the task index, the master pods come before the worker pods.
+	processID := index
+	if taskType == jp.workerName {
+		processID += int(jp.getReplicas(jp.masterName, job))
+	}
+
+	coordinatorAddr := fmt.Sprintf("%s:%d", jp.generateHost(job.Spec.Tasks[coordinatorIndex], job.Name), jp.port)
+	numProcesses := jp.getReplicas(jp.masterName, job) + jp.getReplicas(jp.workerName, job)
+	for i := range pod.Spec.Containers {
+		if taskType == job.Spec.Tasks[coordinatorIndex].Name && index == 0 {
+			jp.openContainerPort(i, pod)
+		}
+
+		pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, v1.EnvVar{
+			Name:  EnvCoordinatorAddress,
+			Value: coordinatorAddr,
+		}, v1.EnvVar{
+			Name:  EnvNumProcesses,
+			Value: strconv.Itoa(int(numProcesses)),
+		}, v1.EnvVar{
+			Name:  EnvProcessID,
+			Value: strconv.Itoa(processID),
+		})
+	}
+
+	return nil
+}
+
+func (jp *jaxPlugin) getReplicas(taskName string, job *batch.Job) int32 {
+	taskIndex := helpers.GetTaskIndexUnderJob(taskName, job)
+	if taskIndex == -1 {
+		return 0
+	}
+	return job.Spec.Tasks[taskIndex].Replicas
+}
+
+func (jp *jaxPlugin) generateHost(task batch.TaskSpec, jobName string) string {
+	hostName := task.Template.Spec.Hostname
+	subdomain := task.Template.Spec.Subdomain
+	if len(hostName) == 0 {
+		hostName = helpers.MakePodName(jobName, task.Name, 0)
+	}
+	if len(subdomain) == 0 {
+		subdomain = jobName
+	}
+
+	return hostName + "." + subdomain
+}
+
+func (jp *jaxPlugin) openContainerPort(index int, pod *v1.Pod) {
+	for _, p := range pod.Spec.Containers[index].Ports {
+		if p.ContainerPort == int32(jp.port) {
+			return
+		}
+	}
+
+	pod.Spec.Containers[index].Ports = append(pod.Spec.Containers[index].Ports, v1.ContainerPort{
+		Name:          "jaxjob-port",
+		ContainerPort: int32(jp.port),
+	})
+}
+
+func (jp *jaxPlugin) OnJobAdd(job *batch.Job) error {
+	if job.Status.ControlledResources["plugin-"+jp.Name()] == jp.Name() {
+		return nil
+	}
+	job.Status.ControlledResources["plugin-"+jp.Name()] = jp.Name()
+	return nil
+}
+
+func (jp *jaxPlugin) OnJobDelete(job *batch.Job) error {
+	if job.Status.ControlledResources["plugin-"+jp.Name()] != jp.Name() {
+		return nil
+	}
+	delete(job.Status.ControlledResources, "plugin-"+jp.Name())
+	return nil
+}
+
+func (jp *jaxPlugin) OnJobUpdate(job *batch.Job) error {
+	return nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jax
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	pluginsinterface "volcano.sh/volcano/pkg/controllers/job/plugins/interface"
+)
+
+func buildPod(name, task string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Annotations: map[string]string{v1alpha1.TaskSpecKey: task},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: task}},
+		},
+	}
+}
+
+func TestJax(t *testing.T) {
+	testcases := []struct {
+		Name  string
+		Args  []string
+		Tasks []v1alpha1.TaskSpec
+		Pod   *v1.Pod
+		Ports []v1.ContainerPort
+		Envs  []v1.EnvVar
+	}{
+		{
+			Name:  "test coordinator pod",
+			Tasks: []v1alpha1.TaskSpec{{Name: "master", Replicas: 1}, {Name: "worker", Replicas: 2}},
+			Pod:   buildPod("test-jax-master-0", "master"),
+			Ports: []v1.ContainerPort{{Name: "jaxjob-port", ContainerPort: DefaultPort}},
+			Envs: []v1.EnvVar{
+				{Name: EnvCoordinatorAddress, Value: "test-jax-master-0.test-jax:1234"},
+				{Name: EnvNumProcesses, Value: "3"},
+				{Name: EnvProcessID, Value: "0"},
+			},
+		},
+		{
+			Name:  "test worker pod ranked after master",
+			Args:  []string{"--port=5000"},
+			Tasks: []v1alpha1.TaskSpec{{Name: "master", Replicas: 1}, {Name: "worker", Replicas: 2}},
+			Pod:   buildPod("test-jax-worker-1", "worker"),
+			Envs: []v1.EnvVar{
+				{Name: EnvCoordinatorAddress, Value: "test-jax-master-0.test-jax:5000"},
+				{Name: EnvNumProcesses, Value: "3"},
+				{Name: EnvProcessID, Value: "2"},
+			},
+		},
+		{
+			Name:  "test worker pod without master",
+			Args:  []string{"--worker=trainer"},
+			Tasks: []v1alpha1.TaskSpec{{Name: "trainer", Replicas: 4}},
+			Pod:   buildPod("test-jax-trainer-3", "trainer"),
+			Envs: []v1.EnvVar{
+				{Name: EnvCoordinatorAddress, Value: "test-jax-trainer-0.test-jax:1234"},
+				{Name: EnvNumProcesses, Value: "4"},
+				{Name: EnvProcessID, Value: "3"},
+			},
+		},
+		{
+			Name:  "test pod of other task",
+			Tasks: []v1alpha1.TaskSpec{{Name: "master", Replicas: 1}, {Name: "evaluator", Replicas: 1}},
+			Pod:   buildPod("test-jax-evaluator-0", "evaluator"),
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			job := &v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-jax"},
+				Spec:       v1alpha1.JobSpec{Tasks: testcase.Tasks},
+			}
+			jp := New(pluginsinterface.PluginClientset{}, testcase.Args)
+			if err := jp.OnPodCreate(testcase.Pod, job); err != nil {
+				t.Fatalf("OnPodCreate failed: %v", err)
+			}
+
+			container := testcase.Pod.Spec.Containers[0]
+			if !equality.Semantic.DeepEqual(container.Ports, testcase.Ports) {
+				t.Errorf("expected ports %v but got %v", testcase.Ports, container.Ports)
+			}
+			if !equality.Semantic.DeepEqual(container.Env, testcase.Envs) {
+				t.Errorf("expected envs %v but got %v", testcase.Envs, container.Env)
+			}
+		})
+	}
+}
@@ -18,13 +18,21 @@ package mpi
 
 import (
 	"flag"
+	"fmt"
+	"hash/fnv"
+	"path"
+	"sort"
+	"strconv"
 	"strings"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/klog/v2"
 
 	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	apishelpers "volcano.sh/apis/pkg/apis/helpers"
 
+	"volcano.sh/volcano/pkg/controllers/apis"
 	"volcano.sh/volcano/pkg/controllers/job/helpers"
 	pluginsinterface "volcano.sh/volcano/pkg/controllers/job/plugins/interface"
 )
@@ -40,6 +48,24 @@ const (
 	DefaultWorker = "worker"
 	// MPIHost is the environment variable key of MPI host
 	MPIHost = "MPI_HOST"
+	// MPIHostfile is the environment variable key of the path of the hostfile
+	MPIHostfile = "MPI_HOSTFILE"
+	// MPIDiscoverHosts is the environment variable key of the path of the host discovery script
+	MPIDiscoverHosts = "MPI_DISCOVER_HOSTS"
+
+	// GPUResourceName is the resource the slots of a worker are counted from, before cpu
+	GPUResourceName = "nvidia.com/gpu"
+	// HostfileMountPath is the directory the hostfile is mounted to
+	HostfileMountPath = "/etc/mpi"
+	// HostfileKey is the key of the hostfile of mpirun, one line of `<host> slots=<slots>` for each worker
+	HostfileKey = "hostfile"
+	// HostsKey is the key of the hosts in the format of Horovod, one line of `<host>:<slots>` for each worker
+	HostsKey = "hosts"
+	// DiscoverHostsKey is the key of the host discovery script of Horovod elastic
+	DiscoverHostsKey = "discover_hosts.sh"
+
+	// hostfileHashKey is the key of the hash of the hostfile written to the ConfigMap in the controlled resources of the job
+	hostfileHashKey = "plugin-mpi-hostfile"
 )
 
 type Plugin struct {
@@ -48,6 +74,7 @@ type Plugin struct {
 	masterName   string
 	workerName   string
 	port         int
+	slots        int
 }
 
 // New creates mpi plugin.
@@ -68,6 +95,7 @@ func (mp *Plugin) addFlags() {
 	flagSet.StringVar(&mp.masterName, "master", DefaultMaster, "name of master role task")
 	flagSet.StringVar(&mp.workerName, "worker", DefaultWorker, "name of worker role task")
 	flagSet.IntVar(&mp.port, "port", DefaultPort, "open port for containers")
+	flagSet.IntVar(&mp.slots, "slots", 0, "slots of each worker, defaults to the gpus or cpus requested by the worker")
 	if err := flagSet.Parse(mp.mpiArguments); err != nil {
 		klog.Errorf("plugin %s flagset parse failed, err: %v", mp.Name(), err)
 	}
@@ -106,9 +134,142 @@ func (mp *Plugin) OnPodCreate(pod *v1.Pod, job *batch.Job) error {
 		}
 	}
 
+	if isMaster {
+		mp.mountHostfile(pod, job)
+	}
+
 	return nil
 }
 
+// mountHostfile mounts the hostfile of the job to the master, the ConfigMap is mounted as a directory
+// so that the hostfile is updated in the containers when the workers change.
+func (mp *Plugin) mountHostfile(pod *v1.Pod, job *batch.Job) {
+	cmName := mp.cmName(job)
+	mode := int32(0755)
+	pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{
+		Name: cmName,
+		VolumeSource: v1.VolumeSource{
+			ConfigMap: &v1.ConfigMapVolumeSource{
+				LocalObjectReference: v1.LocalObjectReference{
+					Name: cmName,
+				},
+				DefaultMode: &mode,
+			},
+		},
+	})
+
+	vm := v1.VolumeMount{
+		MountPath: HostfileMountPath,
+		Name:      cmName,
+	}
+	envs := []v1.EnvVar{{
+		Name:  MPIHostfile,
+		Value: path.Join(HostfileMountPath, HostfileKey),
+	}, {
+		Name:  MPIDiscoverHosts,
+		Value: path.Join(HostfileMountPath, DiscoverHostsKey),
+	}}
+	for i, c := range pod.Spec.InitContainers {
+		pod.Spec.InitContainers[i].VolumeMounts = append(c.VolumeMounts, vm)
+		pod.Spec.InitContainers[i].Env = append(pod.Spec.InitContainers[i].Env, envs...)
+	}
+	for i, c := range pod.Spec.Containers {
+		pod.Spec.Containers[i].VolumeMounts = append(c.VolumeMounts, vm)
+		pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, envs...)
+	}
+}
+
+// generateHostfile generates the hostfile of the running workers, the workers with topology aware ranks
+// come first in rank order, followed by the others in task index order. The workers are listed from
+// the pod cache of the job controller, as it is regenerated whenever the job is synced.
+func (mp *Plugin) generateHostfile(job *batch.Job) (map[string]string, error) {
+	if mp.clientset.PodLister == nil {
+		return nil, fmt.Errorf("pod lister is required to generate the hostfile of Job <%s/%s>", job.Namespace, job.Name)
+	}
+	selector := labels.SelectorFromSet(labels.Set{
+		batch.JobNameKey:  job.Name,
+		batch.TaskSpecKey: mp.workerName,
+	})
+	pods, err := mp.clientset.PodLister.Pods(job.Namespace).List(selector)
+	if err != nil {
+		klog.Errorf("Failed to list workers of Job <%s/%s>: %v", job.Namespace, job.Name, err)
+		return nil, err
+	}
+
+	var workers []*v1.Pod
+	for _, pod := range pods {
+		if pod.DeletionTimestamp == nil && pod.Status.Phase == v1.PodRunning {
+			workers = append(workers, pod)
+		}
+	}
+	sort.SliceStable(workers, func(i, j int) bool {
+		ri, rankedI := workerOrder(workers[i])
+		rj, rankedJ := workerOrder(workers[j])
+		if rankedI != rankedJ {
+			return rankedI
+		}
+		return ri < rj
+	})
+
+	var hostfile, hosts strings.Builder
+	for _, pod := range workers {
+		host := pod.Spec.Hostname + "." + pod.Spec.Subdomain
+		if pod.Spec.Hostname == "" || pod.Spec.Subdomain == "" {
+			host = pod.Name + "." + job.Name
+		}
+		slots := mp.getSlots(pod)
+		hostfile.WriteString(fmt.Sprintf("%s slots=%d\n", host, slots))
+		hosts.WriteString(fmt.Sprintf("%s:%d\n", host, slots))
+	}
+
+	return map[string]string{
+		HostfileKey:      hostfile.String(),
+		HostsKey:         hosts.String(),
+		DiscoverHostsKey: fmt.Sprintf("#!/bin/sh\ncat %s\n", path.Join(HostfileMountPath, HostsKey)),
+	}, nil
+}
+
+// workerOrder returns the topology aware rank of the worker and true if assigned, or its task index and false.
+func workerOrder(pod *v1.Pod) (int, bool) {
+	if rank, err := strconv.Atoi(pod.Annotations[apis.TopologyRankAnnotationKey]); err == nil {
+		return rank, true
+	}
+	index, err := strconv.Atoi(helpers.GetPodIndexUnderTask(pod))
+	if err != nil {
+		return -1, false
+	}
+	return index, false
+}
+
+// getSlots returns the slots of the worker, which is the gpus requested by the worker,
+// or the cpus if it does not request gpus.
+func (mp *Plugin) getSlots(pod *v1.Pod) int {
+	if mp.slots > 0 {
+		return mp.slots
+	}
+
+	var gpus, milliCPUs int64
+	for _, c := range pod.Spec.Containers {
+		if quantity, found := c.Resources.Limits[GPUResourceName]; found {
+			gpus += quantity.Value()
+		}
+		if quantity, found := c.Resources.Requests[v1.ResourceCPU]; found {
+			milliCPUs += quantity.MilliValue()
+		}
+	}
+	if gpus > 0 {
+		return int(gpus)
+	}
+	if milliCPUs >= 1000 {
+		return int(milliCPUs / 1000)
+	}
+	return 1
+}
+
+func (mp *Plugin) cmName(job *batch.Job) string {
+	return fmt.Sprintf("%s-%s", job.Name, mp.Name())
+}
+
 func (mp *Plugin) generateTaskHosts(task batch.TaskSpec, jobName string) string {
 	if task.Replicas == 0 {
 		return ""
@@ -169,20 +330,58 @@ func (mp *Plugin) OnJobAdd(job *batch.Job) error {
 	if job.Status.ControlledResources["plugin-"+mp.Name()] == mp.Name() {
 		return nil
 	}
+
+	if err := mp.updateHostfile(job); err != nil {
+		return err
+	}
+
 	job.Status.ControlledResources["plugin-"+mp.Name()] = mp.Name()
 	return nil
 }
 
+// updateHostfile writes the hostfile to the ConfigMap only if it changes, the hash of the hostfile
+// written is recorded in the controlled resources of the job.
+func (mp *Plugin) updateHostfile(job *batch.Job) error {
+	data, err := mp.generateHostfile(job)
+	if err != nil {
+		return err
+	}
+	hash := hashHostfile(data)
+	if job.Status.ControlledResources[hostfileHashKey] == hash {
+		return nil
+	}
+	if err := apishelpers.CreateOrUpdateConfigMap(job, mp.clientset.KubeClients, data, mp.cmName(job)); err != nil {
+		return err
+	}
+	job.Status.ControlledResources[hostfileHashKey] = hash
+	return nil
+}
+
+func hashHostfile(data map[string]string) string {
+	hash := fnv.New32()
+	for _, key := range []string{HostfileKey, HostsKey, DiscoverHostsKey} {
+		hash.Write([]byte(key + "\x00" + data[key] + "\x00"))
+	}
+	return strconv.FormatUint(uint64(hash.Sum32()), 16)
+}
+
 func (mp *Plugin) OnJobDelete(job *batch.Job) error {
 	if job.Status.ControlledResources["plugin-"+mp.Name()] != mp.Name() {
 		return nil
 	}
+
+	if err := apishelpers.DeleteConfigmap(job, mp.clientset.KubeClients, mp.cmName(job)); err != nil {
+		return err
+	}
+
 	delete(job.Status.ControlledResources, "plugin-"+mp.Name())
+	delete(job.Status.ControlledResources, hostfileHashKey)
 	return nil
 }
 
+// OnJobUpdate regenerates the hostfile, it is called whenever the job is synced, e.g. a worker is restarted or replaced.
 func (mp *Plugin) OnJobUpdate(job *batch.Job) error {
-	return nil
+	return mp.updateHostfile(job)
 }
 
 func (mp *Plugin) GetMasterName() string {
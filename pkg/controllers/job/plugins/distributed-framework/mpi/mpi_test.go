@@ -17,12 +17,18 @@ limitations under the License.
 package mpi
 
 import (
+	"context"
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
 
 	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	"volcano.sh/volcano/pkg/controllers/apis"
 	pluginsinterface "volcano.sh/volcano/pkg/controllers/job/plugins/interface"
 )
 
@@ -118,3 +124,92 @@ func TestMpi(t *testing.T) {
 		})
 	}
 }
+
+func buildWorker(name string, phase v1.PodPhase, rank string, resources v1.ResourceRequirements) *v1.Pod {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+			Labels:    map[string]string{v1alpha1.JobNameKey: "test-mpi", v1alpha1.TaskSpecKey: "worker"},
+		},
+		Spec: v1.PodSpec{
+			Hostname:   name,
+			Subdomain:  "test-mpi",
+			Containers: []v1.Container{{Name: "worker", Resources: resources}},
+		},
+		Status: v1.PodStatus{Phase: phase},
+	}
+	if rank != "" {
+		pod.Annotations = map[string]string{apis.TopologyRankAnnotationKey: rank}
+	}
+	return pod
+}
+
+func TestMpiHostfile(t *testing.T) {
+	job := &v1alpha1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-mpi", Namespace: "test"},
+		Spec: v1alpha1.JobSpec{
+			Tasks: []v1alpha1.TaskSpec{{Name: "master", Replicas: 1}, {Name: "worker", Replicas: 3}},
+		},
+		Status: v1alpha1.JobStatus{ControlledResources: map[string]string{}},
+	}
+	gpus := v1.ResourceRequirements{Limits: v1.ResourceList{GPUResourceName: resource.MustParse("4")}}
+	cpus := v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("2500m")}}
+
+	client := fake.NewSimpleClientset()
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	mp := New(pluginsinterface.PluginClientset{KubeClients: client, PodLister: corelisters.NewPodLister(indexer)}, nil)
+	if err := mp.OnJobAdd(job); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, pod := range []*v1.Pod{
+		buildWorker("test-mpi-worker-0", v1.PodRunning, "", gpus),
+		buildWorker("test-mpi-worker-1", v1.PodRunning, "", cpus),
+		buildWorker("test-mpi-worker-2", v1.PodPending, "", gpus),
+	} {
+		if err := indexer.Add(pod); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := mp.OnJobUpdate(job); err != nil {
+		t.Fatal(err)
+	}
+	cm, err := client.CoreV1().ConfigMaps("test").Get(context.TODO(), "test-mpi-mpi", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "test-mpi-worker-0.test-mpi slots=4\ntest-mpi-worker-1.test-mpi slots=2\n"
+	if cm.Data[HostfileKey] != expected {
+		t.Errorf("expected hostfile %q, got %q", expected, cm.Data[HostfileKey])
+	}
+	if cm.Data[HostsKey] != "test-mpi-worker-0.test-mpi:4\ntest-mpi-worker-1.test-mpi:2\n" {
+		t.Errorf("unexpected hosts %q", cm.Data[HostsKey])
+	}
+
+	// The ConfigMap is not accessed if the hostfile does not change.
+	actions := len(client.Actions())
+	if err := mp.OnJobUpdate(job); err != nil {
+		t.Fatal(err)
+	}
+	if len(client.Actions()) != actions {
+		t.Errorf("expected no request for the unchanged hostfile, got %v", client.Actions()[actions:])
+	}
+
+	// The replaced worker is added once running, the workers are ordered by their topology aware ranks.
+	worker := buildWorker("test-mpi-worker-2", v1.PodRunning, "0", gpus)
+	if err := indexer.Update(worker); err != nil {
+		t.Fatal(err)
+	}
+	if err := mp.OnJobUpdate(job); err != nil {
+		t.Fatal(err)
+	}
+	cm, err = client.CoreV1().ConfigMaps("test").Get(context.TODO(), "test-mpi-mpi", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = "test-mpi-worker-2.test-mpi slots=4\n" + expected
+	if cm.Data[HostfileKey] != expected {
+		t.Errorf("expected hostfile %q, got %q", expected, cm.Data[HostfileKey])
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package paddle
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	"volcano.sh/volcano/pkg/controllers/job/helpers"
+	pluginsinterface "volcano.sh/volcano/pkg/controllers/job/plugins/interface"
+)
+
+const (
+	// PaddlePluginName is the name of the plugin
+	PaddlePluginName = "paddle"
+	// DefaultPort is the default port of the trainers
+	DefaultPort = 6070
+	// DefaultMaster is the default task name of master host
+	DefaultMaster = "master"
+	// DefaultWorker is the default task name of worker host
+	DefaultWorker = "worker"
+
+	// EnvTrainerEndpoints is the env name of the endpoints of all trainers
+	EnvTrainerEndpoints = "PADDLE_TRAINER_ENDPOINTS"
+	// EnvCurrentEndpoint is the env name of the endpoint of the current trainer
+	EnvCurrentEndpoint = "PADDLE_CURRENT_ENDPOINT"
+	// EnvTrainerID is the env name of the id of the current trainer
+	EnvTrainerID = "PADDLE_TRAINER_ID"
+	// EnvTrainersNum is the env name of the number of trainers
+	EnvTrainersNum = "PADDLE_TRAINERS_NUM"
+)
+
+type paddlePlugin struct {
+	paddleArguments []string
+	clientset       pluginsinterface.PluginClientset
+	masterName      string
+	workerName      string
+	port            int
+}
+
+// New creates paddle plugin.
+func New(client pluginsinterface.PluginClientset, arguments []string) pluginsinterface.PluginInterface {
+	pp := paddlePlugin{paddleArguments: arguments, clientset: client}
+	pp.addFlags()
+	return &pp
+}
+
+func (pp *paddlePlugin) addFlags() {
+	flagSet := flag.NewFlagSet(pp.Name(), flag.ContinueOnError)
+	flagSet.StringVar(&pp.masterName, "master", DefaultMaster, "name of master role task")
+	flagSet.StringVar(&pp.workerName, "worker", DefaultWorker, "name of worker role task")
+	flagSet.IntVar(&pp.port, "port", DefaultPort, "open port for containers")
+	if err := flagSet.Parse(pp.paddleArguments); err != nil {
+		klog.Errorf("plugin %s flagset parse failed, err: %v", pp.Name(), err)
+	}
+}
+
+func (pp *paddlePlugin) Name() string {
+	return PaddlePluginName
+}
+
+func (pp *paddlePlugin) OnPodCreate(pod *v1.Pod, job *batch.Job) error {
+	taskType := helpers.GetTaskKey(pod)
+	if taskType != pp.masterName && taskType != pp.workerName {
+		return nil
+	}
+
+	index, err := strconv.Atoi(helpers.GetPodIndexUnderTask(pod))
+	if err != nil {
+		return err
+	}
+
+	// Trainers are ranked by the task index, the master pods come before the worker pods.
+	var endpoints []string
+	for _, taskName := range []string{pp.masterName, pp.workerName} {
+		taskIndex := helpers.GetTaskIndexUnderJob(taskName, job)
+		if taskIndex == -1 {
+			continue
+		}
+		if taskName == taskType {
+			index += len(endpoints)
+		}
+		endpoints = append(endpoints, pp.generateTaskEndpoints(job.Spec.Tasks[taskIndex], job.Name)...)
+	}
+	if index >= len(endpoints) {
+		return fmt.Errorf("pod %s is out of the %d trainers of job %s", pod.Name, len(endpoints), job.Name)
+	}
+
+	for i := range pod.Spec.Containers {
+		pp.openContainerPort(i, pod)
+
+		pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, v1.EnvVar{
+			Name:  EnvTrainerEndpoints,
+			Value: strings.Join(endpoints, ","),
+		}, v1.EnvVar{
+			Name:  EnvCurrentEndpoint,
+			Value: endpoints[index],
+		}, v1.EnvVar{
+			Name:  EnvTrainerID,
+			Value: strconv.Itoa(index),
+		}, v1.EnvVar{
+			Name:  EnvTrainersNum,
+			Value: strconv.Itoa(len(endpoints)),
+		})
+	}
+
+	return nil
+}
+
+func (pp *paddlePlugin) generateTaskEndpoints(task batch.TaskSpec, jobName string) []string {
+	endpoints := make([]string, 0, task.Replicas)
+	for i := 0; i < int(task.Replicas); i++ {
+		hostName := task.Template.Spec.Hostname
+		subdomain := task.Template.Spec.Subdomain
+		if len(hostName) == 0 {
+			hostName = helpers.MakePodName(jobName, task.Name, i)
+		}
+		if len(subdomain) == 0 {
+			subdomain = jobName
+		}
+		endpoints = append(endpoints, fmt.Sprintf("%s.%s:%d", hostName, subdomain, pp.port))
+	}
+
+	return endpoints
+}
+
+func (pp *paddlePlugin) openContainerPort(index int, pod *v1.Pod) {
+	for _, p := range pod.Spec.Containers[index].Ports {
+		if p.ContainerPort == int32(pp.port) {
+			return
+		}
+	}
+
+	pod.Spec.Containers[index].Ports = append(pod.Spec.Containers[index].Ports, v1.ContainerPort{
+		Name:          "paddlejob-port",
+		ContainerPort: int32(pp.port),
+	})
+}
+
+func (pp *paddlePlugin) OnJobAdd(job *batch.Job) error {
+	if job.Status.ControlledResources["plugin-"+pp.Name()] == pp.Name() {
+		return nil
+	}
+	job.Status.ControlledResources["plugin-"+pp.Name()] = pp.Name()
+	return nil
+}
+
+func (pp *paddlePlugin) OnJobDelete(job *batch.Job) error {
+	if job.Status.ControlledResources["plugin-"+pp.Name()] != pp.Name() {
+		return nil
+	}
+	delete(job.Status.ControlledResources, "plugin-"+pp.Name())
+	return nil
+}
+
+func (pp *paddlePlugin) OnJobUpdate(job *batch.Job) error {
+	return nil
+}
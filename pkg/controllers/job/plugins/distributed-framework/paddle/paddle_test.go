@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package paddle
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	pluginsinterface "volcano.sh/volcano/pkg/controllers/job/plugins/interface"
+)
+
+func buildPod(name, task string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Annotations: map[string]string{v1alpha1.TaskSpecKey: task},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: task}},
+		},
+	}
+}
+
+func TestPaddle(t *testing.T) {
+	testcases := []struct {
+		Name  string
+		Args  []string
+		Tasks []v1alpha1.TaskSpec
+		Pod   *v1.Pod
+		Envs  []v1.EnvVar
+	}{
+		{
+			Name:  "test master pod",
+			Tasks: []v1alpha1.TaskSpec{{Name: "master", Replicas: 1}, {Name: "worker", Replicas: 2}},
+			Pod:   buildPod("test-paddle-master-0", "master"),
+			Envs: []v1.EnvVar{
+				{Name: EnvTrainerEndpoints, Value: "test-paddle-master-0.test-paddle:6070,test-paddle-worker-0.test-paddle:6070,test-paddle-worker-1.test-paddle:6070"},
+				{Name: EnvCurrentEndpoint, Value: "test-paddle-master-0.test-paddle:6070"},
+				{Name: EnvTrainerID, Value: "0"},
+				{Name: EnvTrainersNum, Value: "3"},
+			},
+		},
+		{
+			Name:  "test worker pod ranked after master",
+			Args:  []string{"--port=7000"},
+			Tasks: []v1alpha1.TaskSpec{{Name: "master", Replicas: 1}, {Name: "worker", Replicas: 2}},
+			Pod:   buildPod("test-paddle-worker-1", "worker"),
+			Envs: []v1.EnvVar{
+				{Name: EnvTrainerEndpoints, Value: "test-paddle-master-0.test-paddle:7000,test-paddle-worker-0.test-paddle:7000,test-paddle-worker-1.test-paddle:7000"},
+				{Name: EnvCurrentEndpoint, Value: "test-paddle-worker-1.test-paddle:7000"},
+				{Name: EnvTrainerID, Value: "2"},
+				{Name: EnvTrainersNum, Value: "3"},
+			},
+		},
+		{
+			Name:  "test worker pod without master",
+			Tasks: []v1alpha1.TaskSpec{{Name: "worker", Replicas: 2}},
+			Pod:   buildPod("test-paddle-worker-0", "worker"),
+			Envs: []v1.EnvVar{
+				{Name: EnvTrainerEndpoints, Value: "test-paddle-worker-0.test-paddle:6070,test-paddle-worker-1.test-paddle:6070"},
+				{Name: EnvCurrentEndpoint, Value: "test-paddle-worker-0.test-paddle:6070"},
+				{Name: EnvTrainerID, Value: "0"},
+				{Name: EnvTrainersNum, Value: "2"},
+			},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			job := &v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-paddle"},
+				Spec:       v1alpha1.JobSpec{Tasks: testcase.Tasks},
+			}
+			pp := New(pluginsinterface.PluginClientset{}, testcase.Args)
+			if err := pp.OnPodCreate(testcase.Pod, job); err != nil {
+				t.Fatalf("OnPodCreate failed: %v", err)
+			}
+
+			container := testcase.Pod.Spec.Containers[0]
+			if len(container.Ports) != 1 || container.Ports[0].Name != "paddlejob-port" {
+				t.Errorf("expected paddle port to be opened, got %v", container.Ports)
+			}
+			if !equality.Semantic.DeepEqual(container.Env, testcase.Envs) {
+				t.Errorf("expected envs %v but got %v", testcase.Envs, container.Env)
+			}
+		})
+	}
+}
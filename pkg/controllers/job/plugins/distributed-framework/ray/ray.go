@@ -0,0 +1,208 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ray
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	"volcano.sh/volcano/pkg/controllers/job/helpers"
+	pluginsinterface "volcano.sh/volcano/pkg/controllers/job/plugins/interface"
+)
+
+const (
+	// RayPluginName is the name of the plugin
+	RayPluginName = "ray"
+	// DefaultHead is the default task name of head node
+	DefaultHead = "head"
+	// DefaultWorker is the default task name of worker nodes
+	DefaultWorker = "worker"
+	// DefaultPort is the default port of the GCS server on the head node
+	DefaultPort = 6379
+	// DefaultDashboardPort is the default port of the dashboard on the head node
+	DefaultDashboardPort = 8265
+	// DefaultClientServerPort is the default port of the Ray client server on the head node
+	DefaultClientServerPort = 10001
+
+	// EnvHeadAddress is the env name of the head address
+	EnvHeadAddress = "RAY_HEAD_ADDRESS"
+	// EnvHeadPort is the env name of the GCS port of the head
+	EnvHeadPort = "RAY_HEAD_PORT"
+	// EnvAddress is the env name of the GCS address the Ray workers and drivers connect to
+	EnvAddress = "RAY_ADDRESS"
+	// EnvDashboardPort is the env name of the dashboard port
+	EnvDashboardPort = "RAY_DASHBOARD_PORT"
+	// EnvClientServerPort is the env name of the Ray client server port
+	EnvClientServerPort = "RAY_CLIENT_SERVER_PORT"
+	// EnvStartArgs is the env name of the arguments of `ray start` for the role of the pod
+	EnvStartArgs = "RAY_START_ARGS"
+)
+
+// Plugin wires the head and worker tasks of a Ray cluster.
+type Plugin struct {
+	rayArguments     []string
+	clientset        pluginsinterface.PluginClientset
+	headName         string
+	workerName       string
+	port             int
+	dashboardPort    int
+	clientServerPort int
+}
+
+// New creates ray plugin.
+func New(client pluginsinterface.PluginClientset, arguments []string) pluginsinterface.PluginInterface {
+	rp := Plugin{rayArguments: arguments, clientset: client}
+	rp.addFlags()
+	return &rp
+}
+
+// NewInstance creates ray plugin without clientset, it is used to validate the job.
+func NewInstance(arguments []string) Plugin {
+	rp := Plugin{rayArguments: arguments}
+	rp.addFlags()
+	return rp
+}
+
+func (rp *Plugin) addFlags() {
+	flagSet := flag.NewFlagSet(rp.Name(), flag.ContinueOnError)
+	flagSet.StringVar(&rp.headName, "head", DefaultHead, "name of head role task")
+	flagSet.StringVar(&rp.workerName, "worker", DefaultWorker, "name of worker role task")
+	flagSet.IntVar(&rp.port, "port", DefaultPort, "port of the GCS server on the head")
+	flagSet.IntVar(&rp.dashboardPort, "dashboard-port", DefaultDashboardPort, "port of the dashboard on the head")
+	flagSet.IntVar(&rp.clientServerPort, "client-server-port", DefaultClientServerPort, "port of the Ray client server on the head")
+	if err := flagSet.Parse(rp.rayArguments); err != nil {
+		klog.Errorf("plugin %s flagset parse failed, err: %v", rp.Name(), err)
+	}
+}
+
+func (rp *Plugin) Name() string {
+	return RayPluginName
+}
+
+func (rp *Plugin) OnPodCreate(pod *v1.Pod, job *batch.Job) error {
+	taskType := helpers.GetTaskKey(pod)
+	if taskType != rp.headName && taskType != rp.workerName {
+		return nil
+	}
+
+	headIndex := helpers.GetTaskIndexUnderJob(rp.headName, job)
+	if headIndex == -1 {
+		klog.Errorf("job %v doesn't have task %v", job.Name, rp.headName)
+		return nil
+	}
+
+	headAddr := rp.generateHeadAddr(job.Spec.Tasks[headIndex], job.Name)
+	gcsAddr := fmt.Sprintf("%s:%d", headAddr, rp.port)
+	envVars := []v1.EnvVar{
+		{Name: EnvHeadAddress, Value: headAddr},
+		{Name: EnvHeadPort, Value: strconv.Itoa(rp.port)},
+		{Name: EnvAddress, Value: gcsAddr},
+		{Name: EnvDashboardPort, Value: strconv.Itoa(rp.dashboardPort)},
+		{Name: EnvClientServerPort, Value: strconv.Itoa(rp.clientServerPort)},
+	}
+
+	isHead := taskType == rp.headName
+	if isHead {
+		envVars = append(envVars, v1.EnvVar{
+			Name: EnvStartArgs,
+			Value: fmt.Sprintf("--head --port=%d --dashboard-host=0.0.0.0 --dashboard-port=%d --ray-client-server-port=%d",
+				rp.port, rp.dashboardPort, rp.clientServerPort),
+		})
+	} else {
+		envVars = append(envVars, v1.EnvVar{
+			Name:  EnvStartArgs,
+			Value: fmt.Sprintf("--address=%s", gcsAddr),
+		})
+	}
+
+	for i := range pod.Spec.Containers {
+		if isHead {
+			rp.openContainerPorts(&pod.Spec.Containers[i])
+		}
+		pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, envVars...)
+	}
+
+	return nil
+}
+
+func (rp *Plugin) generateHeadAddr(task batch.TaskSpec, jobName string) string {
+	hostName := task.Template.Spec.Hostname
+	subdomain := task.Template.Spec.Subdomain
+	if len(hostName) == 0 {
+		hostName = helpers.MakePodName(jobName, task.Name, 0)
+	}
+	if len(subdomain) == 0 {
+		subdomain = jobName
+	}
+
+	return hostName + "." + subdomain
+}
+
+// openContainerPorts opens the GCS, dashboard and client server ports of the head.
+func (rp *Plugin) openContainerPorts(c *v1.Container) {
+	ports := []v1.ContainerPort{
+		{Name: "gcs", ContainerPort: int32(rp.port)},
+		{Name: "dashboard", ContainerPort: int32(rp.dashboardPort)},
+		{Name: "client", ContainerPort: int32(rp.clientServerPort)},
+	}
+
+	for _, port := range ports {
+		hasPort := false
+		for _, p := range c.Ports {
+			if p.ContainerPort == port.ContainerPort {
+				hasPort = true
+				break
+			}
+		}
+		if !hasPort {
+			c.Ports = append(c.Ports, port)
+		}
+	}
+}
+
+func (rp *Plugin) OnJobAdd(job *batch.Job) error {
+	if job.Status.ControlledResources["plugin-"+rp.Name()] == rp.Name() {
+		return nil
+	}
+	job.Status.ControlledResources["plugin-"+rp.Name()] = rp.Name()
+	return nil
+}
+
+func (rp *Plugin) OnJobDelete(job *batch.Job) error {
+	if job.Status.ControlledResources["plugin-"+rp.Name()] != rp.Name() {
+		return nil
+	}
+	delete(job.Status.ControlledResources, "plugin-"+rp.Name())
+	return nil
+}
+
+func (rp *Plugin) OnJobUpdate(job *batch.Job) error {
+	return nil
+}
+
+func (rp *Plugin) GetHeadName() string {
+	return rp.headName
+}
+
+func (rp *Plugin) GetWorkerName() string {
+	return rp.workerName
+}
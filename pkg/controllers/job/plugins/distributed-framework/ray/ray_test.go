@@ -0,0 +1,141 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ray
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	pluginsinterface "volcano.sh/volcano/pkg/controllers/job/plugins/interface"
+)
+
+func TestRay(t *testing.T) {
+	job := &v1alpha1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-ray"},
+		Spec: v1alpha1.JobSpec{
+			Tasks: []v1alpha1.TaskSpec{
+				{
+					Name:     "head",
+					Replicas: 1,
+					Template: v1.PodTemplateSpec{},
+				},
+				{
+					Name:     "worker",
+					Replicas: 2,
+					Template: v1.PodTemplateSpec{},
+				},
+				{
+					Name:     "extra",
+					Replicas: 1,
+					Template: v1.PodTemplateSpec{},
+				},
+			},
+		},
+	}
+
+	testcases := []struct {
+		Name  string
+		Args  []string
+		Pod   *v1.Pod
+		Ports []v1.ContainerPort
+		Envs  []v1.EnvVar
+	}{
+		{
+			Name: "test head pod",
+			Args: []string{"--dashboard-port=8000"},
+			Pod: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-ray-head-0",
+					Annotations: map[string]string{v1alpha1.TaskSpecKey: "head"},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{{
+						Name:  "head",
+						Ports: []v1.ContainerPort{{Name: "redis", ContainerPort: 6379}},
+					}},
+				},
+			},
+			Ports: []v1.ContainerPort{
+				{Name: "redis", ContainerPort: 6379},
+				{Name: "dashboard", ContainerPort: 8000},
+				{Name: "client", ContainerPort: 10001},
+			},
+			Envs: []v1.EnvVar{
+				{Name: EnvHeadAddress, Value: "test-ray-head-0.test-ray"},
+				{Name: EnvHeadPort, Value: "6379"},
+				{Name: EnvAddress, Value: "test-ray-head-0.test-ray:6379"},
+				{Name: EnvDashboardPort, Value: "8000"},
+				{Name: EnvClientServerPort, Value: "10001"},
+				{Name: EnvStartArgs, Value: "--head --port=6379 --dashboard-host=0.0.0.0 --dashboard-port=8000 --ray-client-server-port=10001"},
+			},
+		},
+		{
+			Name: "test worker pod",
+			Args: []string{"--port=7000"},
+			Pod: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-ray-worker-1",
+					Annotations: map[string]string{v1alpha1.TaskSpecKey: "worker"},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{{Name: "worker"}},
+				},
+			},
+			Envs: []v1.EnvVar{
+				{Name: EnvHeadAddress, Value: "test-ray-head-0.test-ray"},
+				{Name: EnvHeadPort, Value: "7000"},
+				{Name: EnvAddress, Value: "test-ray-head-0.test-ray:7000"},
+				{Name: EnvDashboardPort, Value: "8265"},
+				{Name: EnvClientServerPort, Value: "10001"},
+				{Name: EnvStartArgs, Value: "--address=test-ray-head-0.test-ray:7000"},
+			},
+		},
+		{
+			Name: "test pod of other task",
+			Pod: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-ray-extra-0",
+					Annotations: map[string]string{v1alpha1.TaskSpecKey: "extra"},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{{Name: "extra"}},
+				},
+			},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			rp := New(pluginsinterface.PluginClientset{}, testcase.Args)
+			if err := rp.OnPodCreate(testcase.Pod, job); err != nil {
+				t.Fatalf("OnPodCreate failed: %v", err)
+			}
+
+			container := testcase.Pod.Spec.Containers[0]
+			if !equality.Semantic.DeepEqual(container.Ports, testcase.Ports) {
+				t.Errorf("expected ports %v but got %v", testcase.Ports, container.Ports)
+			}
+			if !equality.Semantic.DeepEqual(container.Env, testcase.Envs) {
+				t.Errorf("expected envs %v but got %v", testcase.Envs, container.Env)
+			}
+		})
+	}
+}
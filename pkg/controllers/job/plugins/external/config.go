@@ -0,0 +1,167 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// FailurePolicy defines how a failed call to an endpoint is handled.
+type FailurePolicy string
+
+const (
+	// Fail fails the hook, so that the job controller retries it.
+	Fail FailurePolicy = "Fail"
+	// Ignore logs the failure and continues as if the endpoint returned nothing.
+	Ignore FailurePolicy = "Ignore"
+
+	// DefaultTimeoutSeconds is the default timeout of a call to an endpoint.
+	DefaultTimeoutSeconds = 10
+)
+
+// Configuration is the configuration of the endpoints the external plugin can call.
+// The endpoints are configured by the cluster administrator, a job only refers to them by name.
+type Configuration struct {
+	Endpoints []Endpoint `json:"endpoints"`
+}
+
+// Endpoint is an HTTP(S) endpoint called on the hooks of the jobs using it.
+type Endpoint struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	// CAFile is the path of the CA bundle to verify the certificate of the endpoint, the system roots are used if empty.
+	CAFile string `json:"caFile,omitempty"`
+	// TimeoutSeconds is the timeout of a call, defaults to DefaultTimeoutSeconds.
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+	// FailurePolicy defaults to Fail.
+	FailurePolicy FailurePolicy `json:"failurePolicy,omitempty"`
+	// Hooks are the hooks the endpoint is called on, defaults to all of them.
+	Hooks []string `json:"hooks,omitempty"`
+
+	client *http.Client
+}
+
+var (
+	endpointsMutex sync.RWMutex
+	endpoints      = map[string]*Endpoint{}
+)
+
+// LoadConfiguration reads the configuration of the endpoints from file and replaces the registered endpoints,
+// no endpoint is registered if no file is set.
+func LoadConfiguration(file string) error {
+	conf := &Configuration{}
+	if file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read external plugin configuration %s: %v", file, err)
+		}
+		if conf, err = parseConfiguration(data); err != nil {
+			return err
+		}
+	}
+
+	registered := map[string]*Endpoint{}
+	for i := range conf.Endpoints {
+		registered[conf.Endpoints[i].Name] = &conf.Endpoints[i]
+	}
+
+	endpointsMutex.Lock()
+	defer endpointsMutex.Unlock()
+	endpoints = registered
+	return nil
+}
+
+func parseConfiguration(data []byte) (*Configuration, error) {
+	conf := &Configuration{}
+	if err := yaml.UnmarshalStrict(data, conf); err != nil {
+		return nil, fmt.Errorf("failed to parse external plugin configuration: %v", err)
+	}
+
+	names := map[string]bool{}
+	for i := range conf.Endpoints {
+		ep := &conf.Endpoints[i]
+		if ep.Name == "" {
+			return nil, fmt.Errorf("the name of endpoint %d is empty", i)
+		}
+		if names[ep.Name] {
+			return nil, fmt.Errorf("endpoint <%s> is duplicated", ep.Name)
+		}
+		names[ep.Name] = true
+
+		u, err := url.Parse(ep.URL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return nil, fmt.Errorf("invalid url %q of endpoint <%s>", ep.URL, ep.Name)
+		}
+		switch ep.FailurePolicy {
+		case "":
+			ep.FailurePolicy = Fail
+		case Fail, Ignore:
+		default:
+			return nil, fmt.Errorf("invalid failurePolicy %q of endpoint <%s>, must be %s or %s", ep.FailurePolicy, ep.Name, Fail, Ignore)
+		}
+		for _, hook := range ep.Hooks {
+			if !slices.Contains(allHooks, hook) {
+				return nil, fmt.Errorf("invalid hook %q of endpoint <%s>, must be one of %v", hook, ep.Name, allHooks)
+			}
+		}
+		timeout := int32(DefaultTimeoutSeconds)
+		if ep.TimeoutSeconds != nil {
+			if *ep.TimeoutSeconds <= 0 {
+				return nil, fmt.Errorf("timeoutSeconds of endpoint <%s> must be positive", ep.Name)
+			}
+			timeout = *ep.TimeoutSeconds
+		}
+
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if ep.CAFile != "" {
+			ca, err := os.ReadFile(ep.CAFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read CA file of endpoint <%s>: %v", ep.Name, err)
+			}
+			tlsConfig.RootCAs = x509.NewCertPool()
+			if !tlsConfig.RootCAs.AppendCertsFromPEM(ca) {
+				return nil, fmt.Errorf("no certificate found in CA file of endpoint <%s>", ep.Name)
+			}
+		}
+		ep.client = &http.Client{
+			Timeout:   time.Duration(timeout) * time.Second,
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		}
+	}
+	return conf, nil
+}
+
+func getEndpoint(name string) (*Endpoint, bool) {
+	endpointsMutex.RLock()
+	defer endpointsMutex.RUnlock()
+	ep, found := endpoints[name]
+	return ep, found
+}
+
+func (ep *Endpoint) calledOn(hook string) bool {
+	return len(ep.Hooks) == 0 || slices.Contains(ep.Hooks, hook)
+}
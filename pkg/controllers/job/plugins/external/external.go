@@ -0,0 +1,369 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	jsonpatch "gopkg.in/evanphx/json-patch.v4"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	"volcano.sh/apis/pkg/apis/helpers"
+	pluginsinterface "volcano.sh/volcano/pkg/controllers/job/plugins/interface"
+)
+
+const (
+	// ExternalPluginName is the name of the plugin
+	ExternalPluginName = "external"
+
+	// HookOnPodCreate is the hook called on pod creation, its response can patch the pod.
+	HookOnPodCreate = "OnPodCreate"
+	// HookOnJobAdd is the hook called on job initiation.
+	HookOnJobAdd = "OnJobAdd"
+	// HookOnJobDelete is the hook called when the job is killed, the objects created for the job are deleted after it.
+	HookOnJobDelete = "OnJobDelete"
+
+	// EndpointLabelKey is the label of the objects created for an endpoint, its value is the name of the endpoint.
+	EndpointLabelKey = "volcano.sh/external-plugin-endpoint"
+
+	// maxResponseBytes limits the size of a response read from an endpoint.
+	maxResponseBytes = 4 << 20
+)
+
+var allHooks = []string{HookOnPodCreate, HookOnJobAdd, HookOnJobDelete}
+
+// HookRequest is the body POSTed to an endpoint.
+type HookRequest struct {
+	Hook string     `json:"hook"`
+	Job  *batch.Job `json:"job"`
+	// Pod is only set on OnPodCreate.
+	Pod *v1.Pod `json:"pod,omitempty"`
+}
+
+// HookResponse is the body an endpoint responds with, an empty body is an empty response.
+type HookResponse struct {
+	// Patch is a JSON patch applied to the pod, only allowed on OnPodCreate.
+	Patch json.RawMessage `json:"patch,omitempty"`
+	// Objects are created in the namespace of the job and owned by it, only ConfigMaps and Secrets
+	// are allowed and they are not allowed on OnJobDelete.
+	Objects []runtime.RawExtension `json:"objects,omitempty"`
+}
+
+type externalPlugin struct {
+	externalArguments []string
+	clientset         pluginsinterface.PluginClientset
+	endpoints         string
+}
+
+// New creates external plugin.
+func New(client pluginsinterface.PluginClientset, arguments []string) pluginsinterface.PluginInterface {
+	ep := externalPlugin{externalArguments: arguments, clientset: client}
+	ep.addFlags()
+	return &ep
+}
+
+// EndpointNames returns the names of the endpoints set in the arguments of the plugin, it is used to validate the job.
+func EndpointNames(arguments []string) []string {
+	ep := externalPlugin{externalArguments: arguments}
+	ep.addFlags()
+	return ep.endpointNames()
+}
+
+func (ep *externalPlugin) addFlags() {
+	flagSet := flag.NewFlagSet(ep.Name(), flag.ContinueOnError)
+	flagSet.StringVar(&ep.endpoints, "endpoint", "", "comma separated names of the configured endpoints to call, in order")
+	if err := flagSet.Parse(ep.externalArguments); err != nil {
+		klog.Errorf("plugin %s flagset parse failed, err: %v", ep.Name(), err)
+	}
+}
+
+func (ep *externalPlugin) Name() string {
+	return ExternalPluginName
+}
+
+func (ep *externalPlugin) OnPodCreate(pod *v1.Pod, job *batch.Job) error {
+	return ep.callEndpoints(HookOnPodCreate, job, pod)
+}
+
+func (ep *externalPlugin) OnJobAdd(job *batch.Job) error {
+	if job.Status.ControlledResources["plugin-"+ep.Name()] == ep.Name() {
+		return nil
+	}
+
+	if err := ep.callEndpoints(HookOnJobAdd, job, nil); err != nil {
+		return err
+	}
+
+	job.Status.ControlledResources["plugin-"+ep.Name()] = ep.Name()
+	return nil
+}
+
+func (ep *externalPlugin) OnJobDelete(job *batch.Job) error {
+	if job.Status.ControlledResources["plugin-"+ep.Name()] != ep.Name() {
+		return nil
+	}
+
+	if err := ep.callEndpoints(HookOnJobDelete, job, nil); err != nil {
+		return err
+	}
+	if err := ep.deleteObjects(job); err != nil {
+		return err
+	}
+
+	delete(job.Status.ControlledResources, "plugin-"+ep.Name())
+	return nil
+}
+
+func (ep *externalPlugin) OnJobUpdate(job *batch.Job) error {
+	return nil
+}
+
+func (ep *externalPlugin) endpointNames() []string {
+	var names []string
+	for _, name := range strings.Split(ep.endpoints, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// callEndpoints calls the endpoints of the job on the hook in order, and applies their responses.
+func (ep *externalPlugin) callEndpoints(hook string, job *batch.Job, pod *v1.Pod) error {
+	names := ep.endpointNames()
+	if len(names) == 0 {
+		return fmt.Errorf("no endpoint is set for plugin %s of job %s/%s", ep.Name(), job.Namespace, job.Name)
+	}
+
+	for _, name := range names {
+		endpoint, found := getEndpoint(name)
+		if !found {
+			return fmt.Errorf("endpoint <%s> of job %s/%s is not configured", name, job.Namespace, job.Name)
+		}
+		if !endpoint.calledOn(hook) {
+			continue
+		}
+
+		err := ep.callEndpoint(endpoint, hook, job, pod)
+		if err == nil {
+			continue
+		}
+		if endpoint.FailurePolicy == Ignore {
+			klog.Warningf("Ignore failure of endpoint <%s> on %s of job %s/%s: %v", name, hook, job.Namespace, job.Name, err)
+			continue
+		}
+		return fmt.Errorf("endpoint <%s> failed on %s: %v", name, hook, err)
+	}
+	return nil
+}
+
+func (ep *externalPlugin) callEndpoint(endpoint *Endpoint, hook string, job *batch.Job, pod *v1.Pod) error {
+	body, err := json.Marshal(HookRequest{Hook: hook, Job: job, Pod: pod})
+	if err != nil {
+		return err
+	}
+	resp, err := endpoint.client.Post(endpoint.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	response := &HookResponse{}
+	if len(bytes.TrimSpace(data)) != 0 {
+		if err := json.Unmarshal(data, response); err != nil {
+			return fmt.Errorf("failed to decode response: %v", err)
+		}
+	}
+
+	if len(response.Patch) != 0 {
+		if pod == nil {
+			return fmt.Errorf("patch is only allowed on %s", HookOnPodCreate)
+		}
+		if err := patchPod(pod, response.Patch); err != nil {
+			return err
+		}
+	}
+	if len(response.Objects) != 0 {
+		if hook == HookOnJobDelete {
+			return fmt.Errorf("objects are not allowed on %s", HookOnJobDelete)
+		}
+		for _, raw := range response.Objects {
+			if err := ep.createObject(job, endpoint.Name, raw.Raw); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// patchPod applies the JSON patch to the pod, the identity and owner of the pod must not be changed.
+func patchPod(pod *v1.Pod, patchData []byte) error {
+	patch, err := jsonpatch.DecodePatch(patchData)
+	if err != nil {
+		return fmt.Errorf("failed to decode patch: %v", err)
+	}
+	original, err := json.Marshal(pod)
+	if err != nil {
+		return err
+	}
+	patched, err := patch.Apply(original)
+	if err != nil {
+		return fmt.Errorf("failed to apply patch: %v", err)
+	}
+
+	newPod := &v1.Pod{}
+	if err := json.Unmarshal(patched, newPod); err != nil {
+		return fmt.Errorf("failed to decode patched pod: %v", err)
+	}
+	if newPod.Name != pod.Name || newPod.Namespace != pod.Namespace ||
+		!reflect.DeepEqual(newPod.OwnerReferences, pod.OwnerReferences) {
+		return fmt.Errorf("patch must not change the name, namespace or owner of the pod")
+	}
+	*pod = *newPod
+	return nil
+}
+
+// createObject creates or updates the object in the namespace of the job, owned by the job.
+func (ep *externalPlugin) createObject(job *batch.Job, endpoint string, data []byte) error {
+	typeMeta := &metav1.TypeMeta{}
+	if err := json.Unmarshal(data, typeMeta); err != nil {
+		return fmt.Errorf("failed to decode object: %v", err)
+	}
+	if typeMeta.APIVersion != "v1" {
+		return fmt.Errorf("object of %s %s is not allowed", typeMeta.APIVersion, typeMeta.Kind)
+	}
+
+	ctx := context.TODO()
+	kubeClient := ep.clientset.KubeClients
+	switch typeMeta.Kind {
+	case "ConfigMap":
+		cm := &v1.ConfigMap{}
+		if err := json.Unmarshal(data, cm); err != nil {
+			return fmt.Errorf("failed to decode ConfigMap: %v", err)
+		}
+		setObjectMeta(&cm.ObjectMeta, job, endpoint)
+		_, err := kubeClient.CoreV1().ConfigMaps(job.Namespace).Create(ctx, cm, metav1.CreateOptions{})
+		if apierrors.IsAlreadyExists(err) {
+			var existing *v1.ConfigMap
+			if existing, err = kubeClient.CoreV1().ConfigMaps(job.Namespace).Get(ctx, cm.Name, metav1.GetOptions{}); err == nil {
+				if err = checkCreatedFor(existing, job, endpoint); err == nil {
+					cm.ResourceVersion = existing.ResourceVersion
+					_, err = kubeClient.CoreV1().ConfigMaps(job.Namespace).Update(ctx, cm, metav1.UpdateOptions{})
+				}
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("failed to create ConfigMap %s: %v", cm.Name, err)
+		}
+	case "Secret":
+		secret := &v1.Secret{}
+		if err := json.Unmarshal(data, secret); err != nil {
+			return fmt.Errorf("failed to decode Secret: %v", err)
+		}
+		setObjectMeta(&secret.ObjectMeta, job, endpoint)
+		_, err := kubeClient.CoreV1().Secrets(job.Namespace).Create(ctx, secret, metav1.CreateOptions{})
+		if apierrors.IsAlreadyExists(err) {
+			var existing *v1.Secret
+			if existing, err = kubeClient.CoreV1().Secrets(job.Namespace).Get(ctx, secret.Name, metav1.GetOptions{}); err == nil {
+				if err = checkCreatedFor(existing, job, endpoint); err == nil {
+					secret.ResourceVersion = existing.ResourceVersion
+					_, err = kubeClient.CoreV1().Secrets(job.Namespace).Update(ctx, secret, metav1.UpdateOptions{})
+				}
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("failed to create Secret %s: %v", secret.Name, err)
+		}
+	default:
+		return fmt.Errorf("object of kind %s is not allowed", typeMeta.Kind)
+	}
+	return nil
+}
+
+func setObjectMeta(meta *metav1.ObjectMeta, job *batch.Job, endpoint string) {
+	meta.Namespace = job.Namespace
+	meta.ResourceVersion = ""
+	meta.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(job, helpers.JobKind)}
+	if meta.Labels == nil {
+		meta.Labels = map[string]string{}
+	}
+	meta.Labels[batch.JobNameKey] = job.Name
+	meta.Labels[EndpointLabelKey] = endpoint
+}
+
+// checkCreatedFor checks that the existing object was created for the job by the endpoint, so that
+// an endpoint can not take over the objects of others by returning their names.
+func checkCreatedFor(existing metav1.Object, job *batch.Job, endpoint string) error {
+	if !metav1.IsControlledBy(existing, job) || existing.GetLabels()[EndpointLabelKey] != endpoint {
+		return fmt.Errorf("object already exists and was not created for the job by endpoint %s", endpoint)
+	}
+	return nil
+}
+
+// deleteObjects deletes the objects created for the job by the endpoints.
+func (ep *externalPlugin) deleteObjects(job *batch.Job) error {
+	ctx := context.TODO()
+	kubeClient := ep.clientset.KubeClients
+	for _, name := range ep.endpointNames() {
+		selector := labels.SelectorFromSet(labels.Set{batch.JobNameKey: job.Name, EndpointLabelKey: name}).String()
+
+		cms, err := kubeClient.CoreV1().ConfigMaps(job.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return err
+		}
+		for _, cm := range cms.Items {
+			if err := kubeClient.CoreV1().ConfigMaps(job.Namespace).Delete(ctx, cm.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+				klog.Errorf("Failed to delete ConfigMap %s of job %s/%s: %v", cm.Name, job.Namespace, job.Name, err)
+				return err
+			}
+		}
+
+		secrets, err := kubeClient.CoreV1().Secrets(job.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return err
+		}
+		for _, secret := range secrets.Items {
+			if err := kubeClient.CoreV1().Secrets(job.Namespace).Delete(ctx, secret.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+				klog.Errorf("Failed to delete Secret %s of job %s/%s: %v", secret.Name, job.Namespace, job.Name, err)
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,292 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	pluginsinterface "volcano.sh/volcano/pkg/controllers/job/plugins/interface"
+)
+
+func loadEndpoints(t *testing.T, conf string) {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "external.yaml")
+	if err := os.WriteFile(file, []byte(conf), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadConfiguration(file); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { LoadConfiguration("") })
+}
+
+func TestParseConfiguration(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    string
+		wantErr bool
+	}{
+		{
+			name: "valid endpoint with defaults",
+			conf: "endpoints:\n- name: sidecar\n  url: https://injector.example.com/hook\n",
+		},
+		{
+			name:    "unknown field",
+			conf:    "endpoints:\n- name: sidecar\n  url: https://injector.example.com/hook\n  retries: 3\n",
+			wantErr: true,
+		},
+		{
+			name:    "duplicated endpoint",
+			conf:    "endpoints:\n- name: a\n  url: http://a\n- name: a\n  url: http://b\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid scheme",
+			conf:    "endpoints:\n- name: a\n  url: ftp://a\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid failure policy",
+			conf:    "endpoints:\n- name: a\n  url: http://a\n  failurePolicy: Retry\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid hook",
+			conf:    "endpoints:\n- name: a\n  url: http://a\n  hooks: [OnJobUpdate]\n",
+			wantErr: true,
+		},
+		{
+			name:    "non positive timeout",
+			conf:    "endpoints:\n- name: a\n  url: http://a\n  timeoutSeconds: 0\n",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			conf, err := parseConfiguration([]byte(test.conf))
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %v, got %v", test.wantErr, err)
+			}
+			if err == nil && conf.Endpoints[0].FailurePolicy != Fail {
+				t.Errorf("expected default failure policy %s, got %s", Fail, conf.Endpoints[0].FailurePolicy)
+			}
+		})
+	}
+}
+
+func TestOnPodCreate(t *testing.T) {
+	patch := `[{"op":"add","path":"/spec/containers/-","value":{"name":"log-shipper","image":"shipper:v1"}}]`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := &HookRequest{}
+		if err := json.NewDecoder(r.Body).Decode(request); err != nil || request.Hook != HookOnPodCreate || request.Pod == nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, `{"patch":%s}`, patch)
+	}))
+	defer server.Close()
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(1500 * time.Millisecond)
+	}))
+	defer slow.Close()
+
+	loadEndpoints(t, fmt.Sprintf(`endpoints:
+- name: sidecar
+  url: %s
+- name: broken-ignored
+  url: %s
+  failurePolicy: Ignore
+- name: broken
+  url: %s
+- name: slow
+  url: %s
+  timeoutSeconds: 1
+- name: job-only
+  url: %s
+  hooks: [OnJobAdd]
+`, server.URL, failing.URL, failing.URL, slow.URL, failing.URL))
+
+	tests := []struct {
+		name           string
+		params         []string
+		wantErr        bool
+		wantContainers int
+	}{
+		{
+			name:           "patch applied",
+			params:         []string{"--endpoint=sidecar"},
+			wantContainers: 2,
+		},
+		{
+			name:           "failure ignored",
+			params:         []string{"--endpoint=broken-ignored,sidecar"},
+			wantContainers: 2,
+		},
+		{
+			name:    "failure fails the hook",
+			params:  []string{"--endpoint=broken"},
+			wantErr: true,
+		},
+		{
+			name:    "timeout fails the hook",
+			params:  []string{"--endpoint=slow"},
+			wantErr: true,
+		},
+		{
+			name:           "endpoint not called on the hook",
+			params:         []string{"--endpoint=job-only"},
+			wantContainers: 1,
+		},
+		{
+			name:    "endpoint not configured",
+			params:  []string{"--endpoint=unknown"},
+			wantErr: true,
+		},
+		{
+			name:    "no endpoint",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			job := &batch.Job{ObjectMeta: metav1.ObjectMeta{Name: "job1", Namespace: "default"}}
+			pod := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "job1-worker-0", Namespace: "default"},
+				Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "worker", Image: "worker:v1"}}},
+			}
+
+			plugin := New(pluginsinterface.PluginClientset{}, test.params)
+			err := plugin.OnPodCreate(pod, job)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("expected error %v, got %v", test.wantErr, err)
+			}
+			if err == nil && len(pod.Spec.Containers) != test.wantContainers {
+				t.Errorf("expected %d containers, got %d", test.wantContainers, len(pod.Spec.Containers))
+			}
+		})
+	}
+}
+
+func TestPatchPodIdentity(t *testing.T) {
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "job1-worker-0", Namespace: "default"}}
+	if err := patchPod(pod, []byte(`[{"op":"replace","path":"/metadata/name","value":"other"}]`)); err == nil {
+		t.Errorf("expected renaming the pod to be rejected")
+	}
+	if pod.Name != "job1-worker-0" {
+		t.Errorf("expected pod to be unchanged, got name %s", pod.Name)
+	}
+}
+
+func TestOnJobAddAndDelete(t *testing.T) {
+	var hooks []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := &HookRequest{}
+		if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		hooks = append(hooks, request.Hook)
+		if request.Hook == HookOnJobAdd {
+			fmt.Fprint(w, `{"objects":[{"apiVersion":"v1","kind":"Secret","metadata":{"name":"job1-site-token"},"stringData":{"token":"t"}}]}`)
+		}
+	}))
+	defer server.Close()
+
+	loadEndpoints(t, fmt.Sprintf("endpoints:\n- name: secrets\n  url: %s\n", server.URL))
+
+	kubeClient := fake.NewSimpleClientset()
+	job := &batch.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "job1", Namespace: "default", UID: "uid1"},
+		Status:     batch.JobStatus{ControlledResources: map[string]string{}},
+	}
+	plugin := New(pluginsinterface.PluginClientset{KubeClients: kubeClient}, []string{"--endpoint=secrets"})
+
+	if err := plugin.OnJobAdd(job); err != nil {
+		t.Fatal(err)
+	}
+	secret, err := kubeClient.CoreV1().Secrets("default").Get(context.TODO(), "job1-site-token", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected secret to be created: %v", err)
+	}
+	if secret.Labels[EndpointLabelKey] != "secrets" || len(secret.OwnerReferences) != 1 {
+		t.Errorf("expected secret to be labeled and owned by the job, got %v", secret.ObjectMeta)
+	}
+
+	// the job is initiated only once
+	if err := plugin.OnJobAdd(job); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := plugin.OnJobDelete(job); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := kubeClient.CoreV1().Secrets("default").Get(context.TODO(), "job1-site-token", metav1.GetOptions{}); err == nil {
+		t.Errorf("expected secret to be deleted")
+	}
+	if len(hooks) != 2 || hooks[0] != HookOnJobAdd || hooks[1] != HookOnJobDelete {
+		t.Errorf("expected hooks [%s %s], got %v", HookOnJobAdd, HookOnJobDelete, hooks)
+	}
+}
+
+func TestCreateExistingObject(t *testing.T) {
+	job := &batch.Job{ObjectMeta: metav1.ObjectMeta{Name: "job1", Namespace: "default", UID: "uid1"}}
+	owned := &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "job1-conf", Namespace: "default"}}
+	setObjectMeta(&owned.ObjectMeta, job, "site")
+	other := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "db-password", Namespace: "default"}, StringData: map[string]string{"password": "p"}}
+	kubeClient := fake.NewSimpleClientset(owned, other)
+	plugin := &externalPlugin{clientset: pluginsinterface.PluginClientset{KubeClients: kubeClient}}
+
+	if err := plugin.createObject(job, "site", []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"job1-conf"},"data":{"k":"v"}}`)); err != nil {
+		t.Errorf("expected the object created for the job to be updated, got %v", err)
+	}
+	cm, _ := kubeClient.CoreV1().ConfigMaps("default").Get(context.TODO(), "job1-conf", metav1.GetOptions{})
+	if cm.Data["k"] != "v" {
+		t.Errorf("expected ConfigMap to be updated, got %v", cm.Data)
+	}
+
+	if err := plugin.createObject(job, "other", []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"job1-conf"}}`)); err == nil {
+		t.Errorf("expected the object created by another endpoint to be rejected")
+	}
+	if err := plugin.createObject(job, "site", []byte(`{"apiVersion":"v1","kind":"Secret","metadata":{"name":"db-password"}}`)); err == nil {
+		t.Errorf("expected the object not created for the job to be rejected")
+	}
+	secret, _ := kubeClient.CoreV1().Secrets("default").Get(context.TODO(), "db-password", metav1.GetOptions{})
+	if len(secret.OwnerReferences) != 0 || secret.StringData["password"] != "p" {
+		t.Errorf("expected the existing Secret to be unchanged, got %v", secret)
+	}
+}
@@ -19,11 +19,16 @@ package plugins
 import (
 	"sync"
 
+	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/deepspeed"
 	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/hcclrank"
+	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/jax"
 	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/mpi"
+	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/paddle"
 	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/pytorch"
+	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/ray"
 	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/tensorflow"
 	"volcano.sh/volcano/pkg/controllers/job/plugins/env"
+	"volcano.sh/volcano/pkg/controllers/job/plugins/external"
 	pluginsinterface "volcano.sh/volcano/pkg/controllers/job/plugins/interface"
 	"volcano.sh/volcano/pkg/controllers/job/plugins/ssh"
 	"volcano.sh/volcano/pkg/controllers/job/plugins/svc"
@@ -37,6 +42,11 @@ func init() {
 	RegisterPluginBuilder("mpi", mpi.New)
 	RegisterPluginBuilder("pytorch", pytorch.New)
 	RegisterPluginBuilder("hcclrank", hcclrank.New)
+	RegisterPluginBuilder("ray", ray.New)
+	RegisterPluginBuilder("jax", jax.New)
+	RegisterPluginBuilder("deepspeed", deepspeed.New)
+	RegisterPluginBuilder("paddle", paddle.New)
+	RegisterPluginBuilder("external", external.New)
 }
 
 var pluginMutex sync.Mutex
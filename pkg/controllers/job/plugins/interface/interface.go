@@ -19,6 +19,7 @@ package pluginsinterface
 import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
 
 	vcbatch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
 )
@@ -26,6 +27,8 @@ import (
 // PluginClientset clientset.
 type PluginClientset struct {
 	KubeClients kubernetes.Interface
+	// PodLister lists the pods from the cache of the job controller
+	PodLister corelisters.PodLister
 }
 
 // PluginInterface interface.
@@ -48,3 +51,28 @@ type PluginInterface interface {
 	// Note: it can be called multi times, must be idempotent
 	OnJobUpdate(job *vcbatch.Job) error
 }
+
+// The following interfaces are optional, a plugin implements them to be notified of the
+// corresponding events from the job controller. Like the hooks of PluginInterface, they
+// can be called multi times for the same event, so they must be idempotent.
+
+// PodDeleteHandler is implemented by plugins that react to pod deletion.
+type PodDeleteHandler interface {
+	// OnPodDelete is called when a pod of the job is deleted, the pod has already
+	// been removed from the cache, so only its task and name are passed.
+	OnPodDelete(job *vcbatch.Job, taskName, podName string) error
+}
+
+// TaskFailureHandler is implemented by plugins that react to task failures.
+type TaskFailureHandler interface {
+	// OnTaskFailed is called when a pod of the task fails, or when the task fails
+	// because it can no longer meet its minAvailable.
+	OnTaskFailed(job *vcbatch.Job, taskName string) error
+}
+
+// JobPhaseChangeHandler is implemented by plugins that react to job phase transitions.
+type JobPhaseChangeHandler interface {
+	// OnJobPhaseChange is called after the job status is updated to a new phase,
+	// the new phase is job.Status.State.Phase.
+	OnJobPhaseChange(job *vcbatch.Job, oldPhase vcbatch.JobPhase) error
+}
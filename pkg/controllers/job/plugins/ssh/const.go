@@ -23,6 +23,21 @@ const (
 	// SSHPublicKey public key
 	SSHPublicKey = "id_rsa.pub"
 
+	// SSHEd25519PrivateKey ed25519 private key
+	SSHEd25519PrivateKey = "id_ed25519"
+
+	// SSHEd25519PublicKey ed25519 public key
+	SSHEd25519PublicKey = "id_ed25519.pub"
+
+	// SSHKnownHosts known hosts of the pods of the job
+	SSHKnownHosts = "known_hosts"
+
+	// SSHHostPrivateKey host private key shared by the pods of the job
+	SSHHostPrivateKey = "ssh_host_ed25519_key"
+
+	// SSHHostPublicKey host public key shared by the pods of the job
+	SSHHostPublicKey = "ssh_host_ed25519_key.pub"
+
 	// SSHAuthorizedKeys authkey
 	SSHAuthorizedKeys = "authorized_keys"
 
@@ -34,4 +49,10 @@ const (
 
 	// SSHRelativePath ssh rel path
 	SSHRelativePath = ".ssh"
+
+	// KeyTypeRSA generates rsa keys
+	KeyTypeRSA = "rsa"
+
+	// KeyTypeEd25519 generates ed25519 keys
+	KeyTypeEd25519 = "ed25519"
 )
@@ -17,15 +17,21 @@ limitations under the License.
 package ssh
 
 import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
 	"flag"
 	"fmt"
+	"strings"
 
 	"golang.org/x/crypto/ssh"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 
 	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
@@ -48,6 +54,18 @@ type sshPlugin struct {
 
 	// public key string
 	sshPublicKey string
+
+	// type of the generated keys, rsa or ed25519
+	keyType string
+
+	// secret in the namespace of the job holding the private key
+	sshKeySecret string
+
+	// comma separated tasks whose pods get the private key, all tasks if empty
+	privateKeyTasks string
+
+	// generate host keys and known_hosts, so that host keys are checked
+	strictHostKeyChecking bool
 }
 
 // New creates ssh plugin
@@ -56,6 +74,7 @@ func New(client pluginsinterface.PluginClientset, arguments []string) pluginsint
 		pluginArguments: arguments,
 		client:          client,
 		sshKeyFilePath:  SSHAbsolutePath,
+		keyType:         KeyTypeRSA,
 	}
 
 	p.addFlags()
@@ -80,14 +99,22 @@ func (sp *sshPlugin) OnJobAdd(job *batch.Job) error {
 
 	var data map[string][]byte
 	var err error
-	if len(sp.sshPrivateKey) > 0 {
-		data, err = withUserProvidedRsaKey(job, sp.sshPrivateKey, sp.sshPublicKey)
-	} else {
-		data, err = generateRsaKey(job)
+	switch {
+	case len(sp.sshKeySecret) > 0:
+		data, err = sp.withSecretProvidedKey(job)
+	case len(sp.sshPrivateKey) > 0:
+		data, err = sp.withUserProvidedRsaKey(job)
+	default:
+		data, err = sp.generateKey(job)
 	}
 	if err != nil {
 		return err
 	}
+	if sp.strictHostKeyChecking {
+		if err := sp.generateHostKey(job, data); err != nil {
+			return err
+		}
+	}
 
 	if err := helpers.CreateOrUpdateSecret(job, sp.client.KubeClients, data, sp.secretName(job)); err != nil {
 		return fmt.Errorf("create secret for job <%s/%s> with ssh plugin failed for %v",
@@ -135,29 +162,27 @@ func (sp *sshPlugin) mountRsaKey(pod *v1.Pod, job *batch.Job) {
 		Name: secretName,
 	}
 
+	privateKey, publicKey := sp.keyFileNames()
+	keys := []string{publicKey, SSHAuthorizedKeys, SSHConfig}
+	// Only the pods of the tasks initiating ssh connections, e.g. the launcher of an MPI job, need the private key.
+	if tasks := splitTasks(sp.privateKeyTasks); tasks.Len() == 0 || tasks.Has(jobhelpers.GetTaskKey(pod)) {
+		keys = append([]string{privateKey}, keys...)
+	}
+	if sp.strictHostKeyChecking {
+		keys = append(keys, SSHKnownHosts, SSHHostPrivateKey, SSHHostPublicKey)
+	}
+
 	var mode int32 = 0600
 	sshVolume.Secret = &v1.SecretVolumeSource{
-		SecretName: secretName,
-		Items: []v1.KeyToPath{
-			{
-				Key:  SSHPrivateKey,
-				Path: SSHRelativePath + "/" + SSHPrivateKey,
-			},
-			{
-				Key:  SSHPublicKey,
-				Path: SSHRelativePath + "/" + SSHPublicKey,
-			},
-			{
-				Key:  SSHAuthorizedKeys,
-				Path: SSHRelativePath + "/" + SSHAuthorizedKeys,
-			},
-			{
-				Key:  SSHConfig,
-				Path: SSHRelativePath + "/" + SSHConfig,
-			},
-		},
+		SecretName:  secretName,
 		DefaultMode: &mode,
 	}
+	for _, key := range keys {
+		sshVolume.Secret.Items = append(sshVolume.Secret.Items, v1.KeyToPath{
+			Key:  key,
+			Path: SSHRelativePath + "/" + key,
+		})
+	}
 
 	if sp.sshKeyFilePath != SSHAbsolutePath {
 		var noRootMode int32 = 0644
@@ -186,47 +211,129 @@ func (sp *sshPlugin) mountRsaKey(pod *v1.Pod, job *batch.Job) {
 	}
 }
 
-func generateRsaKey(job *batch.Job) (map[string][]byte, error) {
-	bitSize := 2048
-
-	privateKey, err := rsa.GenerateKey(rand.Reader, bitSize)
+func (sp *sshPlugin) generateKey(job *batch.Job) (map[string][]byte, error) {
+	privateKeyBytes, publicKeyBytes, err := generateKeyPair(sp.keyType)
 	if err != nil {
-		klog.Errorf("rsa generateKey err: %v", err)
 		return nil, err
 	}
 
-	// id_rsa
-	privBlock := pem.Block{
-		Type:  "RSA PRIVATE KEY",
-		Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+	return sp.keyData(job, privateKeyBytes, publicKeyBytes), nil
+}
+
+// generateKeyPair generates a private key in PEM and its public key in the authorized_keys format.
+func generateKeyPair(keyType string) ([]byte, []byte, error) {
+	var privBlock *pem.Block
+	var publicKey crypto.PublicKey
+	switch keyType {
+	case KeyTypeEd25519:
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			klog.Errorf("ed25519 generateKey err: %v", err)
+			return nil, nil, err
+		}
+		// id_ed25519 is only supported in the OpenSSH format
+		if privBlock, err = ssh.MarshalPrivateKey(priv, ""); err != nil {
+			return nil, nil, err
+		}
+		publicKey = pub
+	case KeyTypeRSA:
+		bitSize := 2048
+
+		privateKey, err := rsa.GenerateKey(rand.Reader, bitSize)
+		if err != nil {
+			klog.Errorf("rsa generateKey err: %v", err)
+			return nil, nil, err
+		}
+		privBlock = &pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+		}
+		publicKey = &privateKey.PublicKey
+	default:
+		return nil, nil, fmt.Errorf("unsupported ssh key type %q, must be %s or %s", keyType, KeyTypeRSA, KeyTypeEd25519)
 	}
-	privateKeyBytes := pem.EncodeToMemory(&privBlock)
 
-	// id_rsa.pub
-	publicRsaKey, err := ssh.NewPublicKey(&privateKey.PublicKey)
+	sshPublicKey, err := ssh.NewPublicKey(publicKey)
 	if err != nil {
 		klog.Errorf("ssh newPublicKey err: %v", err)
+		return nil, nil, err
+	}
+
+	return pem.EncodeToMemory(privBlock), ssh.MarshalAuthorizedKey(sshPublicKey), nil
+}
+
+func (sp *sshPlugin) withUserProvidedRsaKey(job *batch.Job) (map[string][]byte, error) {
+	return sp.keyData(job, []byte(sp.sshPrivateKey), []byte(sp.sshPublicKey)), nil
+}
+
+// withSecretProvidedKey reads the private key from the `ssh-privatekey` of the secret, so that
+// the key is neither in the spec of the job nor shared across jobs unless the user wants to.
+func (sp *sshPlugin) withSecretProvidedKey(job *batch.Job) (map[string][]byte, error) {
+	if len(sp.sshPrivateKey) > 0 || len(sp.sshPublicKey) > 0 {
+		return nil, fmt.Errorf("ssh-key-secret can not be used with ssh-private-key or ssh-public-key")
+	}
+	secret, err := sp.client.KubeClients.CoreV1().Secrets(job.Namespace).Get(context.TODO(), sp.sshKeySecret, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ssh key secret %s of job <%s/%s>: %v", sp.sshKeySecret, job.Namespace, job.Name, err)
+	}
+	privateKeyBytes, found := secret.Data[v1.SSHAuthPrivateKey]
+	if !found {
+		return nil, fmt.Errorf("no %s found in ssh key secret %s", v1.SSHAuthPrivateKey, sp.sshKeySecret)
+	}
+
+	privateKey, err := ssh.ParseRawPrivateKey(privateKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key of ssh key secret %s: %v", sp.sshKeySecret, err)
+	}
+	keyType := ""
+	switch privateKey.(type) {
+	case *rsa.PrivateKey:
+		keyType = KeyTypeRSA
+	case ed25519.PrivateKey, *ed25519.PrivateKey:
+		keyType = KeyTypeEd25519
+	}
+	if keyType != sp.keyType {
+		return nil, fmt.Errorf("the private key of ssh key secret %s is not of key type %s", sp.sshKeySecret, sp.keyType)
+	}
+	signer, err := ssh.NewSignerFromKey(privateKey)
+	if err != nil {
 		return nil, err
 	}
-	publicKeyBytes := ssh.MarshalAuthorizedKey(publicRsaKey)
 
-	data := make(map[string][]byte)
-	data[SSHPrivateKey] = privateKeyBytes
-	data[SSHPublicKey] = publicKeyBytes
-	data[SSHAuthorizedKeys] = publicKeyBytes
-	data[SSHConfig] = []byte(generateSSHConfig(job))
+	return sp.keyData(job, privateKeyBytes, ssh.MarshalAuthorizedKey(signer.PublicKey())), nil
+}
+
+// generateHostKey adds a host key pair shared by the pods of the job and the known_hosts of the pods to data.
+func (sp *sshPlugin) generateHostKey(job *batch.Job, data map[string][]byte) error {
+	privateKeyBytes, publicKeyBytes, err := generateKeyPair(KeyTypeEd25519)
+	if err != nil {
+		return err
+	}
 
-	return data, nil
+	data[SSHHostPrivateKey] = privateKeyBytes
+	data[SSHHostPublicKey] = publicKeyBytes
+	data[SSHKnownHosts] = []byte(generateKnownHosts(job, strings.TrimSpace(string(publicKeyBytes))))
+	return nil
 }
 
-func withUserProvidedRsaKey(job *batch.Job, sshPrivateKey string, sshPublicKey string) (map[string][]byte, error) {
+func (sp *sshPlugin) keyData(job *batch.Job, privateKeyBytes, publicKeyBytes []byte) map[string][]byte {
+	privateKey, publicKey := sp.keyFileNames()
+
 	data := make(map[string][]byte)
-	data[SSHPrivateKey] = []byte(sshPrivateKey)
-	data[SSHPublicKey] = []byte(sshPublicKey)
-	data[SSHAuthorizedKeys] = []byte(sshPublicKey)
-	data[SSHConfig] = []byte(generateSSHConfig(job))
+	data[privateKey] = privateKeyBytes
+	data[publicKey] = publicKeyBytes
+	data[SSHAuthorizedKeys] = publicKeyBytes
+	data[SSHConfig] = []byte(sp.generateSSHConfig(job))
 
-	return data, nil
+	return data
+}
+
+// keyFileNames returns the file names of the private and public key of the key type.
+func (sp *sshPlugin) keyFileNames() (string, string) {
+	if sp.keyType == KeyTypeEd25519 {
+		return SSHEd25519PrivateKey, SSHEd25519PublicKey
+	}
+	return SSHPrivateKey, SSHPublicKey
 }
 
 func (sp *sshPlugin) secretName(job *batch.Job) string {
@@ -239,14 +346,27 @@ func (sp *sshPlugin) addFlags() {
 		"ssh private and public keys, it is `/root/.ssh` by default.")
 	flagSet.StringVar(&sp.sshPrivateKey, "ssh-private-key", sp.sshPrivateKey, "The input string of the private key")
 	flagSet.StringVar(&sp.sshPublicKey, "ssh-public-key", sp.sshPublicKey, "The input string of the public key")
+	flagSet.StringVar(&sp.keyType, "key-type", sp.keyType, "The type of the ssh keys, rsa or ed25519")
+	flagSet.StringVar(&sp.sshKeySecret, "ssh-key-secret", sp.sshKeySecret, "The secret in the namespace of the job "+
+		"whose `ssh-privatekey` is used as the private key instead of a generated one")
+	flagSet.StringVar(&sp.privateKeyTasks, "private-key-tasks", sp.privateKeyTasks, "The comma separated tasks "+
+		"whose pods get the private key, all tasks by default")
+	flagSet.BoolVar(&sp.strictHostKeyChecking, "strict-host-key-checking", sp.strictHostKeyChecking, "Generate "+
+		"a host key and known_hosts for the pods of the job, so that host keys are checked")
 
 	if err := flagSet.Parse(sp.pluginArguments); err != nil {
 		klog.Errorf("plugin %s flagset parse failed, err: %v", sp.Name(), err)
 	}
 }
 
-func generateSSHConfig(job *batch.Job) string {
+func (sp *sshPlugin) generateSSHConfig(job *batch.Job) string {
 	config := "StrictHostKeyChecking no\nUserKnownHostsFile /dev/null\n"
+	if sp.strictHostKeyChecking {
+		config = "StrictHostKeyChecking yes\nUserKnownHostsFile " + sp.sshKeyFilePath + "/" + SSHKnownHosts + "\n"
+	}
+	if privateKey, _ := sp.keyFileNames(); privateKey != SSHPrivateKey {
+		config += "IdentityFile " + sp.sshKeyFilePath + "/" + privateKey + "\n"
+	}
 
 	for _, ts := range job.Spec.Tasks {
 		for i := 0; i < int(ts.Replicas); i++ {
@@ -269,3 +389,59 @@ func generateSSHConfig(job *batch.Job) string {
 
 	return config
 }
+
+// generateKnownHosts trusts the host key for the short and full host names of all pods of the job.
+func generateKnownHosts(job *batch.Job, hostKey string) string {
+	var knownHosts strings.Builder
+	for _, ts := range job.Spec.Tasks {
+		for i := 0; i < int(ts.Replicas); i++ {
+			domainName := jobhelpers.MakeDomainName(ts, job, i)
+			hostName := strings.SplitN(domainName, ".", 2)[0]
+
+			knownHosts.WriteString(hostName + "," + domainName + " " + hostKey + "\n")
+			if len(ts.Template.Spec.Hostname) != 0 {
+				break
+			}
+		}
+	}
+
+	return knownHosts.String()
+}
+
+func splitTasks(s string) sets.Set[string] {
+	tasks := sets.New[string]()
+	for _, task := range strings.Split(s, ",") {
+		if task = strings.TrimSpace(task); task != "" {
+			tasks.Insert(task)
+		}
+	}
+	return tasks
+}
+
+// KeySecretName returns the name of the secret holding the private key set by the arguments, empty if not set.
+func KeySecretName(arguments []string) string {
+	sp := sshPlugin{pluginArguments: arguments, sshKeyFilePath: SSHAbsolutePath, keyType: KeyTypeRSA}
+	sp.addFlags()
+	return sp.sshKeySecret
+}
+
+// ValidateArguments checks the arguments of the ssh plugin of the job, it is used to validate the job.
+func ValidateArguments(arguments []string, job *batch.Job) error {
+	sp := sshPlugin{pluginArguments: arguments, sshKeyFilePath: SSHAbsolutePath, keyType: KeyTypeRSA}
+	sp.addFlags()
+
+	if sp.keyType != KeyTypeRSA && sp.keyType != KeyTypeEd25519 {
+		return fmt.Errorf("unsupported key-type %q, must be %s or %s", sp.keyType, KeyTypeRSA, KeyTypeEd25519)
+	}
+	if len(sp.sshKeySecret) > 0 && (len(sp.sshPrivateKey) > 0 || len(sp.sshPublicKey) > 0) {
+		return fmt.Errorf("ssh-key-secret can not be used with ssh-private-key or ssh-public-key")
+	}
+	tasks := sets.New[string]()
+	for _, ts := range job.Spec.Tasks {
+		tasks.Insert(ts.Name)
+	}
+	if unknown := splitTasks(sp.privateKeyTasks).Difference(tasks); unknown.Len() != 0 {
+		return fmt.Errorf("unknown private-key-tasks %v", sets.List(unknown))
+	}
+	return nil
+}
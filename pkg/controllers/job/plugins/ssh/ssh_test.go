@@ -17,8 +17,16 @@ limitations under the License.
 package ssh
 
 import (
+	"context"
+	"strings"
 	"testing"
 
+	"golang.org/x/crypto/ssh"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
 	pluginsinterface "volcano.sh/volcano/pkg/controllers/job/plugins/interface"
 )
 
@@ -71,3 +79,153 @@ func TestSSHPlugin(t *testing.T) {
 		})
 	}
 }
+
+func newTestJob() *batch.Job {
+	return &batch.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "mpi", Namespace: "default", UID: "uid1"},
+		Spec: batch.JobSpec{
+			Tasks: []batch.TaskSpec{
+				{Name: "launcher", Replicas: 1},
+				{Name: "worker", Replicas: 2},
+			},
+		},
+		Status: batch.JobStatus{ControlledResources: map[string]string{}},
+	}
+}
+
+func newTestPod(task string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "mpi-" + task + "-0",
+			Namespace:   "default",
+			Annotations: map[string]string{batch.TaskSpecKey: task},
+		},
+		Spec: v1.PodSpec{Containers: []v1.Container{{Name: task}}},
+	}
+}
+
+func mountedKeys(pod *v1.Pod) []string {
+	var keys []string
+	for _, item := range pod.Spec.Volumes[0].Secret.Items {
+		keys = append(keys, item.Key)
+	}
+	return keys
+}
+
+func TestEd25519KeysWithKnownHosts(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+	job := newTestJob()
+	plugin := New(pluginsinterface.PluginClientset{KubeClients: kubeClient},
+		[]string{"--key-type=ed25519", "--private-key-tasks=launcher", "--strict-host-key-checking"})
+
+	if err := plugin.OnJobAdd(job); err != nil {
+		t.Fatal(err)
+	}
+	secret, err := kubeClient.CoreV1().Secrets("default").Get(context.TODO(), "mpi-ssh", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	privateKey, err := ssh.ParsePrivateKey(secret.Data[SSHEd25519PrivateKey])
+	if err != nil {
+		t.Fatalf("failed to parse the private key: %v", err)
+	}
+	if privateKey.PublicKey().Type() != ssh.KeyAlgoED25519 {
+		t.Errorf("expected an ed25519 key, got %s", privateKey.PublicKey().Type())
+	}
+	if _, found := secret.Data[SSHPrivateKey]; found {
+		t.Errorf("expected no rsa key")
+	}
+
+	config := string(secret.Data[SSHConfig])
+	if !strings.Contains(config, "StrictHostKeyChecking yes") || !strings.Contains(config, "UserKnownHostsFile /root/.ssh/known_hosts") {
+		t.Errorf("expected host keys to be checked, got config %s", config)
+	}
+	knownHosts := strings.Split(strings.TrimSpace(string(secret.Data[SSHKnownHosts])), "\n")
+	if len(knownHosts) != 3 || !strings.HasPrefix(knownHosts[1], "mpi-worker-0,mpi-worker-0.mpi ssh-ed25519 ") {
+		t.Errorf("unexpected known_hosts %v", knownHosts)
+	}
+
+	launcher := newTestPod("launcher")
+	if err := plugin.OnPodCreate(launcher, job); err != nil {
+		t.Fatal(err)
+	}
+	if keys := mountedKeys(launcher); len(keys) != 7 || keys[0] != SSHEd25519PrivateKey {
+		t.Errorf("expected the launcher to get the private key, got %v", keys)
+	}
+	worker := newTestPod("worker")
+	if err := plugin.OnPodCreate(worker, job); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range mountedKeys(worker) {
+		if key == SSHEd25519PrivateKey {
+			t.Errorf("expected the worker not to get the private key")
+		}
+	}
+}
+
+func TestSecretProvidedKey(t *testing.T) {
+	privateKey, publicKey, err := generateKeyPair(KeyTypeEd25519)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kubeClient := fake.NewSimpleClientset(&v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "user-key", Namespace: "default"},
+		Type:       v1.SecretTypeSSHAuth,
+		Data:       map[string][]byte{v1.SSHAuthPrivateKey: privateKey},
+	})
+
+	job := newTestJob()
+	plugin := New(pluginsinterface.PluginClientset{KubeClients: kubeClient}, []string{"--ssh-key-secret=user-key"})
+	if err := plugin.OnJobAdd(job); err == nil {
+		t.Errorf("expected an ed25519 key to be rejected for key type rsa")
+	}
+
+	plugin = New(pluginsinterface.PluginClientset{KubeClients: kubeClient}, []string{"--ssh-key-secret=user-key", "--key-type=ed25519"})
+	if err := plugin.OnJobAdd(job); err != nil {
+		t.Fatal(err)
+	}
+	secret, err := kubeClient.CoreV1().Secrets("default").Get(context.TODO(), "mpi-ssh", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(secret.Data[SSHEd25519PrivateKey]) != string(privateKey) || string(secret.Data[SSHAuthorizedKeys]) != string(publicKey) {
+		t.Errorf("expected the keys of the secret to be used")
+	}
+}
+
+func TestValidateArguments(t *testing.T) {
+	tests := []struct {
+		name      string
+		arguments []string
+		wantErr   bool
+	}{
+		{
+			name:      "valid",
+			arguments: []string{"--key-type=ed25519", "--ssh-key-secret=user-key", "--private-key-tasks=launcher"},
+		},
+		{
+			name:      "unsupported key type",
+			arguments: []string{"--key-type=dsa"},
+			wantErr:   true,
+		},
+		{
+			name:      "secret with keys in arguments",
+			arguments: []string{"--ssh-key-secret=user-key", "--ssh-public-key=ssh-rsa AAAA"},
+			wantErr:   true,
+		},
+		{
+			name:      "unknown private key task",
+			arguments: []string{"--private-key-tasks=master"},
+			wantErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := ValidateArguments(test.arguments, newTestJob()); (err != nil) != test.wantErr {
+				t.Errorf("expected error %v, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
@@ -26,4 +26,9 @@ const (
 
 	// ConfigMapMountPath mount path
 	ConfigMapMountPath = "/etc/volcano"
+
+	// TaskServiceNameFmt is the name of the service of a task, `<job name>-<task name>`
+	TaskServiceNameFmt = "%s-%s"
+	// AllowedNetworkPolicyNameFmt is the name of the NetworkPolicy allowing the configured peers to access the job
+	AllowedNetworkPolicyNameFmt = "%s-allowed"
 )
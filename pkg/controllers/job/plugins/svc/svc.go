@@ -27,6 +27,9 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/klog/v2"
 
 	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
@@ -44,6 +47,12 @@ type servicePlugin struct {
 	// flag parse args
 	publishNotReadyAddresses bool
 	disableNetworkPolicy     bool
+	perTaskService           bool
+	clusterIPTasks           string
+	allowNamespaces          string
+	allowNamespaceSelector   string
+	allowPodSelector         string
+	allowTasks               string
 }
 
 // New creates service plugin.
@@ -65,6 +74,18 @@ func (sp *servicePlugin) addFlags() {
 		"set publishNotReadyAddresses of svc to true")
 	flagSet.BoolVar(&sp.disableNetworkPolicy, "disable-network-policy", sp.disableNetworkPolicy,
 		"set disableNetworkPolicy of svc to true")
+	flagSet.BoolVar(&sp.perTaskService, "per-task-service", sp.perTaskService,
+		"create a service named <job>-<task> for each task, with the ports of its containers")
+	flagSet.StringVar(&sp.clusterIPTasks, "cluster-ip-tasks", sp.clusterIPTasks,
+		"comma separated tasks whose service gets a cluster IP instead of being headless, requires per-task-service")
+	flagSet.StringVar(&sp.allowNamespaces, "allow-namespaces", sp.allowNamespaces,
+		"comma separated namespaces whose pods are allowed to access the job by the network policy")
+	flagSet.StringVar(&sp.allowNamespaceSelector, "allow-namespace-selector", sp.allowNamespaceSelector,
+		"label selector of the namespaces whose pods are allowed to access the job by the network policy")
+	flagSet.StringVar(&sp.allowPodSelector, "allow-pod-selector", sp.allowPodSelector,
+		"label selector of the pods in the namespace of the job allowed to access the job by the network policy")
+	flagSet.StringVar(&sp.allowTasks, "allow-tasks", sp.allowTasks,
+		"comma separated tasks the allowed namespaces and pods can access, all tasks by default")
 
 	if err := flagSet.Parse(sp.pluginArguments); err != nil {
 		klog.Errorf("plugin %s flagset parse failed, err: %v", sp.Name(), err)
@@ -139,10 +160,23 @@ func (sp *servicePlugin) OnJobAdd(job *batch.Job) error {
 		return err
 	}
 
+	if sp.perTaskService {
+		for _, ts := range job.Spec.Tasks {
+			if err := sp.createTaskServiceIfNotExist(job, ts); err != nil {
+				return err
+			}
+		}
+	}
+
 	if !sp.disableNetworkPolicy {
 		if err := sp.createNetworkPolicyIfNotExist(job); err != nil {
 			return err
 		}
+		if sp.hasAllowedPeers() {
+			if err := sp.createAllowedNetworkPolicyIfNotExist(job); err != nil {
+				return err
+			}
+		}
 	}
 	job.Status.ControlledResources["plugin-"+sp.Name()] = sp.Name()
 
@@ -164,6 +198,13 @@ func (sp *servicePlugin) OnJobDelete(job *batch.Job) error {
 			return err
 		}
 	}
+	if sp.perTaskService {
+		for _, ts := range job.Spec.Tasks {
+			if err := sp.deleteTaskServiceIfControlled(job, ts); err != nil {
+				return err
+			}
+		}
+	}
 	delete(job.Status.ControlledResources, "plugin-"+sp.Name())
 
 	if !sp.disableNetworkPolicy {
@@ -173,6 +214,15 @@ func (sp *servicePlugin) OnJobDelete(job *batch.Job) error {
 				return err
 			}
 		}
+		if sp.hasAllowedPeers() {
+			name := fmt.Sprintf(AllowedNetworkPolicyNameFmt, job.Name)
+			if err := sp.Clientset.KubeClients.NetworkingV1().NetworkPolicies(job.Namespace).Delete(context.TODO(), name, metav1.DeleteOptions{}); err != nil {
+				if !apierrors.IsNotFound(err) {
+					klog.Errorf("Failed to delete Network policy %s of Job %v/%v: %v", name, job.Namespace, job.Name, err)
+					return err
+				}
+			}
+		}
 	}
 	return nil
 }
@@ -295,6 +345,252 @@ func (sp *servicePlugin) createNetworkPolicyIfNotExist(job *batch.Job) error {
 	return nil
 }
 
+// deleteTaskServiceIfControlled deletes the service of the task, the service with the same name of another job is kept.
+func (sp *servicePlugin) deleteTaskServiceIfControlled(job *batch.Job, ts batch.TaskSpec) error {
+	name := TaskServiceName(job.Name, ts.Name)
+	existing, err := sp.Clientset.KubeClients.CoreV1().Services(job.Namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		klog.Errorf("Failed to get Service %s of Job %v/%v: %v", name, job.Namespace, job.Name, err)
+		return err
+	}
+	if !metav1.IsControlledBy(existing, job) {
+		return nil
+	}
+	if err := sp.Clientset.KubeClients.CoreV1().Services(job.Namespace).Delete(context.TODO(), name, metav1.DeleteOptions{}); err != nil {
+		if !apierrors.IsNotFound(err) {
+			klog.Errorf("Failed to delete Service %s of Job %v/%v: %v", name, job.Namespace, job.Name, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// createTaskServiceIfNotExist creates a service selecting the pods of the task, it is headless unless
+// the task is one of cluster-ip-tasks. The ports of the service are the ports of the containers of the task.
+func (sp *servicePlugin) createTaskServiceIfNotExist(job *batch.Job, ts batch.TaskSpec) error {
+	name := TaskServiceName(job.Name, ts.Name)
+	if existing, err := sp.Clientset.KubeClients.CoreV1().Services(job.Namespace).Get(context.TODO(), name, metav1.GetOptions{}); err == nil {
+		// The name of the service may collide with the service of another job, e.g. job "a-b" with task "c"
+		// and job "a" with task "b-c", never take over the service of others.
+		if !metav1.IsControlledBy(existing, job) {
+			return fmt.Errorf("service %s already exists and is not controlled by job <%s/%s>", name, job.Namespace, job.Name)
+		}
+		return nil
+	} else if !apierrors.IsNotFound(err) {
+		klog.V(3).Infof("Failed to get Service %s for Job <%s/%s>: %v", name, job.Namespace, job.Name, err)
+		return err
+	}
+
+	svc := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: job.Namespace,
+			Name:      name,
+			Labels: map[string]string{
+				batch.JobNameKey:  job.Name,
+				batch.TaskSpecKey: ts.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(job, helpers.JobKind),
+			},
+		},
+		Spec: v1.ServiceSpec{
+			ClusterIP: "None",
+			Selector: map[string]string{
+				batch.JobNameKey:      job.Name,
+				batch.JobNamespaceKey: job.Namespace,
+				batch.TaskSpecKey:     ts.Name,
+			},
+			Ports:                    ServicePorts(ts.Template.Spec.Containers),
+			PublishNotReadyAddresses: sp.publishNotReadyAddresses,
+		},
+	}
+	if splitNames(sp.clusterIPTasks).Has(ts.Name) {
+		if len(svc.Spec.Ports) == 0 {
+			return fmt.Errorf("service of task %s of job %s/%s has a cluster IP but no container port", ts.Name, job.Namespace, job.Name)
+		}
+		svc.Spec.ClusterIP = ""
+	}
+
+	if _, err := sp.Clientset.KubeClients.CoreV1().Services(job.Namespace).Create(context.TODO(), svc, metav1.CreateOptions{}); err != nil {
+		klog.V(3).Infof("Failed to create Service %s for Job <%s/%s>: %v", name, job.Namespace, job.Name, err)
+		return err
+	}
+	return nil
+}
+
+// createAllowedNetworkPolicyIfNotExist allows the configured namespaces and pods to access the allowed tasks of the job,
+// it is added to the network policy of the job as network policies are additive.
+func (sp *servicePlugin) createAllowedNetworkPolicyIfNotExist(job *batch.Job) error {
+	name := fmt.Sprintf(AllowedNetworkPolicyNameFmt, job.Name)
+	if _, err := sp.Clientset.KubeClients.NetworkingV1().NetworkPolicies(job.Namespace).Get(context.TODO(), name, metav1.GetOptions{}); err == nil {
+		return nil
+	} else if !apierrors.IsNotFound(err) {
+		klog.V(3).Infof("Failed to get NetworkPolicy %s for Job <%s/%s>: %v", name, job.Namespace, job.Name, err)
+		return err
+	}
+
+	peers, err := sp.allowedPeers()
+	if err != nil {
+		return err
+	}
+	podSelector := metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			batch.JobNameKey:      job.Name,
+			batch.JobNamespaceKey: job.Namespace,
+		},
+	}
+	if tasks := splitNames(sp.allowTasks); tasks.Len() != 0 {
+		podSelector.MatchExpressions = []metav1.LabelSelectorRequirement{{
+			Key:      batch.TaskSpecKey,
+			Operator: metav1.LabelSelectorOpIn,
+			Values:   sets.List(tasks),
+		}}
+	}
+
+	networkpolicy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: job.Namespace,
+			Name:      name,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(job, helpers.JobKind),
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: podSelector,
+			Ingress:     []networkingv1.NetworkPolicyIngressRule{{From: peers}},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		},
+	}
+
+	if _, e := sp.Clientset.KubeClients.NetworkingV1().NetworkPolicies(job.Namespace).Create(context.TODO(), networkpolicy, metav1.CreateOptions{}); e != nil {
+		klog.V(3).Infof("Failed to create NetworkPolicy %s for Job <%s/%s>: %v", name, job.Namespace, job.Name, e)
+		return e
+	}
+	return nil
+}
+
+func (sp *servicePlugin) hasAllowedPeers() bool {
+	return sp.allowNamespaces != "" || sp.allowNamespaceSelector != "" || sp.allowPodSelector != ""
+}
+
+// allowedPeers returns the peers allowed by allow-namespaces, allow-namespace-selector and allow-pod-selector.
+func (sp *servicePlugin) allowedPeers() ([]networkingv1.NetworkPolicyPeer, error) {
+	var peers []networkingv1.NetworkPolicyPeer
+	if namespaces := splitNames(sp.allowNamespaces); namespaces.Len() != 0 {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{
+					Key:      v1.LabelMetadataName,
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   sets.List(namespaces),
+				}},
+			},
+		})
+	}
+	if sp.allowNamespaceSelector != "" {
+		selector, err := metav1.ParseToLabelSelector(sp.allowNamespaceSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allow-namespace-selector %q: %v", sp.allowNamespaceSelector, err)
+		}
+		peers = append(peers, networkingv1.NetworkPolicyPeer{NamespaceSelector: selector})
+	}
+	if sp.allowPodSelector != "" {
+		selector, err := metav1.ParseToLabelSelector(sp.allowPodSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allow-pod-selector %q: %v", sp.allowPodSelector, err)
+		}
+		peers = append(peers, networkingv1.NetworkPolicyPeer{PodSelector: selector})
+	}
+	return peers, nil
+}
+
+// ValidateArguments checks the arguments of the svc plugin of the job, it is used to validate the job.
+func ValidateArguments(arguments []string, job *batch.Job) error {
+	sp := servicePlugin{pluginArguments: arguments}
+	sp.addFlags()
+
+	tasks := sets.New[string]()
+	for _, ts := range job.Spec.Tasks {
+		tasks.Insert(ts.Name)
+		if sp.perTaskService {
+			name := TaskServiceName(job.Name, ts.Name)
+			if errs := validation.IsDNS1035Label(name); len(errs) != 0 {
+				return fmt.Errorf("invalid service name %s of task %s: %s", name, ts.Name, strings.Join(errs, ", "))
+			}
+		}
+	}
+	clusterIPTasks := splitNames(sp.clusterIPTasks)
+	if clusterIPTasks.Len() != 0 && !sp.perTaskService {
+		return fmt.Errorf("cluster-ip-tasks requires per-task-service")
+	}
+	if unknown := clusterIPTasks.Difference(tasks); unknown.Len() != 0 {
+		return fmt.Errorf("unknown cluster-ip-tasks %v", sets.List(unknown))
+	}
+	allowTasks := splitNames(sp.allowTasks)
+	if allowTasks.Len() != 0 && !sp.hasAllowedPeers() {
+		return fmt.Errorf("allow-tasks requires allow-namespaces, allow-namespace-selector or allow-pod-selector")
+	}
+	if unknown := allowTasks.Difference(tasks); unknown.Len() != 0 {
+		return fmt.Errorf("unknown allow-tasks %v", sets.List(unknown))
+	}
+	if sp.hasAllowedPeers() && sp.disableNetworkPolicy {
+		return fmt.Errorf("allowed namespaces and pods can not be set when the network policy is disabled")
+	}
+	_, err := sp.allowedPeers()
+	return err
+}
+
+// TaskServiceName returns the name of the service of the task.
+func TaskServiceName(jobName, taskName string) string {
+	return fmt.Sprintf(TaskServiceNameFmt, jobName, taskName)
+}
+
+// ServicePorts returns the service ports of the container ports, a port without name is named `<protocol>-<port>`.
+// Ports duplicated by number and protocol or by name are only published once.
+func ServicePorts(containers []v1.Container) []v1.ServicePort {
+	var ports []v1.ServicePort
+	names := sets.New[string]()
+	published := sets.New[string]()
+	for _, c := range containers {
+		for _, port := range c.Ports {
+			protocol := port.Protocol
+			if protocol == "" {
+				protocol = v1.ProtocolTCP
+			}
+			key := fmt.Sprintf("%s/%d", protocol, port.ContainerPort)
+			name := port.Name
+			if name == "" {
+				name = fmt.Sprintf("%s-%d", strings.ToLower(string(protocol)), port.ContainerPort)
+			}
+			if published.Has(key) || names.Has(name) {
+				continue
+			}
+			published.Insert(key)
+			names.Insert(name)
+			ports = append(ports, v1.ServicePort{
+				Name:       name,
+				Protocol:   protocol,
+				Port:       port.ContainerPort,
+				TargetPort: intstr.FromInt32(port.ContainerPort),
+			})
+		}
+	}
+	return ports
+}
+
+func splitNames(s string) sets.Set[string] {
+	names := sets.New[string]()
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names.Insert(name)
+		}
+	}
+	return names
+}
+
 func (sp *servicePlugin) cmName(job *batch.Job) string {
 	return fmt.Sprintf("%s-%s", job.Name, sp.Name())
 }
@@ -0,0 +1,213 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package svc
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	"volcano.sh/apis/pkg/apis/helpers"
+	pluginsinterface "volcano.sh/volcano/pkg/controllers/job/plugins/interface"
+)
+
+func newTestJob() *batch.Job {
+	return &batch.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "job1", Namespace: "default", UID: "uid1"},
+		Spec: batch.JobSpec{
+			Tasks: []batch.TaskSpec{
+				{
+					Name:     "ps",
+					Replicas: 1,
+					Template: v1.PodTemplateSpec{Spec: v1.PodSpec{Containers: []v1.Container{{
+						Name:  "ps",
+						Ports: []v1.ContainerPort{{Name: "grpc", ContainerPort: 2222}, {ContainerPort: 8080}},
+					}}}},
+				},
+				{
+					Name:     "worker",
+					Replicas: 2,
+					Template: v1.PodTemplateSpec{Spec: v1.PodSpec{Containers: []v1.Container{{Name: "worker"}}}},
+				},
+			},
+		},
+		Status: batch.JobStatus{ControlledResources: map[string]string{}},
+	}
+}
+
+func TestServicePorts(t *testing.T) {
+	containers := []v1.Container{
+		{Ports: []v1.ContainerPort{{Name: "grpc", ContainerPort: 2222}, {ContainerPort: 9090, Protocol: v1.ProtocolUDP}}},
+		{Ports: []v1.ContainerPort{{Name: "other", ContainerPort: 2222}, {Name: "grpc", ContainerPort: 3333}}},
+	}
+	expected := []v1.ServicePort{
+		{Name: "grpc", Protocol: v1.ProtocolTCP, Port: 2222, TargetPort: intstr.FromInt32(2222)},
+		{Name: "udp-9090", Protocol: v1.ProtocolUDP, Port: 9090, TargetPort: intstr.FromInt32(9090)},
+	}
+	if ports := ServicePorts(containers); !reflect.DeepEqual(ports, expected) {
+		t.Errorf("expected ports %v, got %v", expected, ports)
+	}
+}
+
+func TestPerTaskServices(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+	job := newTestJob()
+	plugin := New(pluginsinterface.PluginClientset{KubeClients: kubeClient}, []string{"--per-task-service", "--cluster-ip-tasks=ps"})
+
+	if err := plugin.OnJobAdd(job); err != nil {
+		t.Fatal(err)
+	}
+
+	ps, err := kubeClient.CoreV1().Services("default").Get(context.TODO(), "job1-ps", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected service of ps to be created: %v", err)
+	}
+	if ps.Spec.ClusterIP == v1.ClusterIPNone || len(ps.Spec.Ports) != 2 || ps.Spec.Selector[batch.TaskSpecKey] != "ps" {
+		t.Errorf("expected a cluster IP service selecting ps with 2 ports, got %v", ps.Spec)
+	}
+	worker, err := kubeClient.CoreV1().Services("default").Get(context.TODO(), "job1-worker", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected service of worker to be created: %v", err)
+	}
+	if worker.Spec.ClusterIP != v1.ClusterIPNone {
+		t.Errorf("expected the service of worker to be headless, got %q", worker.Spec.ClusterIP)
+	}
+	if _, err := kubeClient.CoreV1().Services("default").Get(context.TODO(), "job1", metav1.GetOptions{}); err != nil {
+		t.Errorf("expected service of the job to be created: %v", err)
+	}
+
+	if err := plugin.OnJobDelete(job); err != nil {
+		t.Fatal(err)
+	}
+	services, _ := kubeClient.CoreV1().Services("default").List(context.TODO(), metav1.ListOptions{})
+	if len(services.Items) != 0 {
+		t.Errorf("expected all services to be deleted, got %d", len(services.Items))
+	}
+}
+
+func TestPerTaskServiceOfOtherJob(t *testing.T) {
+	job := newTestJob()
+	// job "job1-ps" has no task, its service name collides with the service of task "ps" of job "job1"
+	other := &batch.Job{ObjectMeta: metav1.ObjectMeta{Name: "job1-ps", Namespace: "default", UID: "uid2"}}
+	kubeClient := fake.NewSimpleClientset(&v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "job1-ps",
+			Namespace:       "default",
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(other, helpers.JobKind)},
+		},
+	})
+	plugin := New(pluginsinterface.PluginClientset{KubeClients: kubeClient}, []string{"--per-task-service"})
+
+	if err := plugin.OnJobAdd(job); err == nil {
+		t.Errorf("expected an error for the service of task ps owned by another job")
+	}
+
+	if err := plugin.OnJobDelete(job); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := kubeClient.CoreV1().Services("default").Get(context.TODO(), "job1-ps", metav1.GetOptions{}); err != nil {
+		t.Errorf("expected the service of another job to be kept: %v", err)
+	}
+}
+
+func TestAllowedNetworkPolicy(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+	job := newTestJob()
+	plugin := New(pluginsinterface.PluginClientset{KubeClients: kubeClient},
+		[]string{"--allow-namespaces=inference,monitoring", "--allow-pod-selector=app=prometheus", "--allow-tasks=ps"})
+
+	if err := plugin.OnJobAdd(job); err != nil {
+		t.Fatal(err)
+	}
+
+	np, err := kubeClient.NetworkingV1().NetworkPolicies("default").Get(context.TODO(), "job1-allowed", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected allowed network policy to be created: %v", err)
+	}
+	expressions := np.Spec.PodSelector.MatchExpressions
+	if len(expressions) != 1 || expressions[0].Key != batch.TaskSpecKey || !reflect.DeepEqual(expressions[0].Values, []string{"ps"}) {
+		t.Errorf("expected the policy to select the ps task, got %v", np.Spec.PodSelector)
+	}
+	peers := np.Spec.Ingress[0].From
+	if len(peers) != 2 || !reflect.DeepEqual(peers[0].NamespaceSelector.MatchExpressions[0].Values, []string{"inference", "monitoring"}) ||
+		peers[1].PodSelector.MatchLabels["app"] != "prometheus" {
+		t.Errorf("unexpected peers %v", peers)
+	}
+	if _, err := kubeClient.NetworkingV1().NetworkPolicies("default").Get(context.TODO(), "job1", metav1.GetOptions{}); err != nil {
+		t.Errorf("expected network policy of the job to be created: %v", err)
+	}
+
+	if err := plugin.OnJobDelete(job); err != nil {
+		t.Fatal(err)
+	}
+	policies, _ := kubeClient.NetworkingV1().NetworkPolicies("default").List(context.TODO(), metav1.ListOptions{})
+	if len(policies.Items) != 0 {
+		t.Errorf("expected all network policies to be deleted, got %d", len(policies.Items))
+	}
+}
+
+func TestValidateArguments(t *testing.T) {
+	tests := []struct {
+		name      string
+		arguments []string
+		wantErr   bool
+	}{
+		{
+			name:      "valid",
+			arguments: []string{"--per-task-service", "--cluster-ip-tasks=ps", "--allow-namespace-selector=team in (ml)", "--allow-tasks=ps"},
+		},
+		{
+			name:      "cluster ip tasks without per task service",
+			arguments: []string{"--cluster-ip-tasks=ps"},
+			wantErr:   true,
+		},
+		{
+			name:      "unknown cluster ip task",
+			arguments: []string{"--per-task-service", "--cluster-ip-tasks=chief"},
+			wantErr:   true,
+		},
+		{
+			name:      "allow tasks without peers",
+			arguments: []string{"--allow-tasks=ps"},
+			wantErr:   true,
+		},
+		{
+			name:      "invalid selector",
+			arguments: []string{"--allow-pod-selector=app in prometheus"},
+			wantErr:   true,
+		},
+		{
+			name:      "peers with network policy disabled",
+			arguments: []string{"--allow-namespaces=inference", "--disable-network-policy"},
+			wantErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := ValidateArguments(test.arguments, newTestJob()); (err != nil) != test.wantErr {
+				t.Errorf("expected error %v, got %v", test.wantErr, err)
+			}
+		})
+	}
+}
@@ -29,6 +29,12 @@ type abortedState struct {
 func (as *abortedState) Execute(action Action) error {
 	switch action.Action {
 	case v1alpha1.ResumeJobAction:
+		if apis.IsResuming(as.job.Job) {
+			return ResumeJob(as.job, func(status *vcbatch.JobStatus) bool {
+				status.State.Phase = vcbatch.Pending
+				return true
+			})
+		}
 		return KillJob(as.job, PodRetainPhaseSoft, func(status *vcbatch.JobStatus) bool {
 			status.State.Phase = vcbatch.Restarting
 			status.RetryCount++
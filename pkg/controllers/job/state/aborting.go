@@ -29,6 +29,12 @@ type abortingState struct {
 func (ps *abortingState) Execute(action Action) error {
 	switch action.Action {
 	case v1alpha1.ResumeJobAction:
+		if apis.IsResuming(ps.job.Job) {
+			return ResumeJob(ps.job, func(status *vcbatch.JobStatus) bool {
+				status.State.Phase = vcbatch.Pending
+				return true
+			})
+		}
 		return KillJob(ps.job, PodRetainPhaseSoft, func(status *vcbatch.JobStatus) bool {
 			status.State.Phase = vcbatch.Restarting
 			status.RetryCount++
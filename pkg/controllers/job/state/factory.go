@@ -55,6 +55,11 @@ var (
 	KillJob KillActionFn
 	// KillTarget kill the target with given name.
 	KillTarget KillTargetFn
+	// SuspendJob records the progress of Job and kills its Pods with phase not in PodRetainPhaseSoft,
+	// the PodGroup is kept and its resources are released back to the queue.
+	SuspendJob ActionFn
+	// ResumeJob resumes a suspended Job in place, the Pods are recreated with the same names.
+	ResumeJob ActionFn
 )
 
 type TargetType string
@@ -40,6 +40,11 @@ func (ps *pendingState) Execute(action Action) error {
 			status.State.Phase = vcbatch.Restarting
 			return true
 		})
+	case apis.SuspendJobAction:
+		return SuspendJob(ps.job, func(status *vcbatch.JobStatus) bool {
+			status.State.Phase = vcbatch.Aborting
+			return true
+		})
 	case v1alpha1.AbortJobAction:
 		return KillJob(ps.job, PodRetainPhaseSoft, func(status *vcbatch.JobStatus) bool {
 			status.State.Phase = vcbatch.Aborting
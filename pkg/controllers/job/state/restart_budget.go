@@ -0,0 +1,238 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vcbatch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	"volcano.sh/apis/pkg/apis/bus/v1alpha1"
+)
+
+const (
+	// RestartBudgetAnnotationKey is the annotation key of the RestartBudget of a Job.
+	RestartBudgetAnnotationKey = "volcano.sh/restart-budget"
+	// TaskRestartsAnnotationKey is the annotation key of the restart history of each task,
+	// it is maintained by the job controller.
+	TaskRestartsAnnotationKey = "volcano.sh/task-restarts"
+
+	// DefaultBackoffFactor is the factor the restart delay is multiplied by after each restart.
+	DefaultBackoffFactor = 2.0
+	// DefaultEscalationAction is executed when a task exceeds its restart budget.
+	DefaultEscalationAction = v1alpha1.AbortJobAction
+)
+
+// RestartBudget limits the restarts of the tasks of a Job by the RestartTask and RestartPod actions,
+// the fields of TaskRestartBudget apply to all tasks and can be overridden per task.
+type RestartBudget struct {
+	TaskRestartBudget
+	Tasks map[string]TaskRestartBudget `json:"tasks,omitempty"`
+}
+
+// TaskRestartBudget limits the restarts of a task.
+type TaskRestartBudget struct {
+	// MaxRestarts is the number of times the task can be restarted.
+	MaxRestarts *int32 `json:"maxRestarts,omitempty"`
+	// Backoff delays the restarts of the task exponentially.
+	Backoff *RestartBackoff `json:"backoff,omitempty"`
+	// CrashLoop detects a task restarted too often.
+	CrashLoop *CrashLoopPolicy `json:"crashLoop,omitempty"`
+	// Action is executed instead of the restart when the task exceeds MaxRestarts
+	// or is crash looping, defaults to AbortJob.
+	Action v1alpha1.Action `json:"action,omitempty"`
+}
+
+// RestartBackoff delays the n-th restart of a task by InitialDelay * Factor^n, up to MaxDelay.
+type RestartBackoff struct {
+	InitialDelay metav1.Duration  `json:"initialDelay"`
+	MaxDelay     *metav1.Duration `json:"maxDelay,omitempty"`
+	Factor       *float64         `json:"factor,omitempty"`
+}
+
+// CrashLoopPolicy treats a task restarted Restarts times within Window as crash looping.
+type CrashLoopPolicy struct {
+	Restarts int32           `json:"restarts"`
+	Window   metav1.Duration `json:"window"`
+}
+
+// TaskRestartHistory records the restarts of a task.
+type TaskRestartHistory struct {
+	// Count is the number of times the task has been restarted.
+	Count int32 `json:"count"`
+	// Recent is the time of the restarts within the crash loop window.
+	Recent []metav1.Time `json:"recent,omitempty"`
+}
+
+// RestartDecision tells how a restart of a task is executed.
+type RestartDecision struct {
+	// Delay is the backoff before the restart.
+	Delay time.Duration
+	// Escalate is the action executed instead of the restart, if not empty.
+	Escalate v1alpha1.Action
+	// Reason tells why the restart is escalated.
+	Reason string
+}
+
+// GetRestartBudget returns the restart budget of the Job, or nil if not set.
+func GetRestartBudget(job *vcbatch.Job) (*RestartBudget, error) {
+	value, found := job.Annotations[RestartBudgetAnnotationKey]
+	if !found {
+		return nil, nil
+	}
+	budget := &RestartBudget{}
+	if err := json.Unmarshal([]byte(value), budget); err != nil {
+		return nil, fmt.Errorf("invalid annotation %s: %v", RestartBudgetAnnotationKey, err)
+	}
+	return budget, nil
+}
+
+// ForTask returns the budget of the task, the fields set for the task override the ones of the Job.
+func (b *RestartBudget) ForTask(taskName string) TaskRestartBudget {
+	budget := b.TaskRestartBudget
+	override, found := b.Tasks[taskName]
+	if !found {
+		return budget
+	}
+	if override.MaxRestarts != nil {
+		budget.MaxRestarts = override.MaxRestarts
+	}
+	if override.Backoff != nil {
+		budget.Backoff = override.Backoff
+	}
+	if override.CrashLoop != nil {
+		budget.CrashLoop = override.CrashLoop
+	}
+	if override.Action != "" {
+		budget.Action = override.Action
+	}
+	return budget
+}
+
+// GetTaskRestarts returns the restart history of each task of the Job.
+func GetTaskRestarts(job *vcbatch.Job) (map[string]TaskRestartHistory, error) {
+	restarts := map[string]TaskRestartHistory{}
+	value, found := job.Annotations[TaskRestartsAnnotationKey]
+	if !found {
+		return restarts, nil
+	}
+	if err := json.Unmarshal([]byte(value), &restarts); err != nil {
+		return nil, fmt.Errorf("invalid annotation %s: %v", TaskRestartsAnnotationKey, err)
+	}
+	return restarts, nil
+}
+
+// CheckTaskRestart decides how the next restart of the task is executed according to the restart budget.
+func CheckTaskRestart(job *vcbatch.Job, taskName string, now time.Time) (RestartDecision, error) {
+	decision := RestartDecision{}
+	budget, err := GetRestartBudget(job)
+	if err != nil || budget == nil {
+		return decision, err
+	}
+	restarts, err := GetTaskRestarts(job)
+	if err != nil {
+		return decision, err
+	}
+
+	taskBudget := budget.ForTask(taskName)
+	history := restarts[taskName]
+	escalate := taskBudget.Action
+	if escalate == "" {
+		escalate = DefaultEscalationAction
+	}
+
+	if taskBudget.MaxRestarts != nil && history.Count >= *taskBudget.MaxRestarts {
+		decision.Escalate = escalate
+		decision.Reason = fmt.Sprintf("task %s has been restarted %d times, exceeding its limit %d",
+			taskName, history.Count, *taskBudget.MaxRestarts)
+		return decision, nil
+	}
+
+	if crashLoop := taskBudget.CrashLoop; crashLoop != nil {
+		if recent := recentRestarts(history.Recent, crashLoop.Window.Duration, now); int32(len(recent)) >= crashLoop.Restarts {
+			decision.Escalate = escalate
+			decision.Reason = fmt.Sprintf("task %s is crash looping, it has been restarted %d times in %s",
+				taskName, len(recent), crashLoop.Window.Duration)
+			return decision, nil
+		}
+	}
+
+	if backoff := taskBudget.Backoff; backoff != nil {
+		decision.Delay = backoff.delay(history.Count)
+	}
+	return decision, nil
+}
+
+// RecordTaskRestart adds a restart of the task to the restart history of the Job.
+func RecordTaskRestart(job *vcbatch.Job, taskName string, now time.Time) error {
+	budget, err := GetRestartBudget(job)
+	if err != nil || budget == nil {
+		return err
+	}
+	restarts, err := GetTaskRestarts(job)
+	if err != nil {
+		return err
+	}
+
+	history := restarts[taskName]
+	history.Count++
+	// Only the restarts within the crash loop window are kept to bound the size of the annotation.
+	history.Recent = nil
+	if crashLoop := budget.ForTask(taskName).CrashLoop; crashLoop != nil {
+		history.Recent = append(recentRestarts(restarts[taskName].Recent, crashLoop.Window.Duration, now), metav1.NewTime(now))
+	}
+	restarts[taskName] = history
+
+	value, err := json.Marshal(restarts)
+	if err != nil {
+		return err
+	}
+	if job.Annotations == nil {
+		job.Annotations = map[string]string{}
+	}
+	job.Annotations[TaskRestartsAnnotationKey] = string(value)
+	return nil
+}
+
+func recentRestarts(restarts []metav1.Time, window time.Duration, now time.Time) []metav1.Time {
+	var recent []metav1.Time
+	for _, t := range restarts {
+		if now.Sub(t.Time) < window {
+			recent = append(recent, t)
+		}
+	}
+	return recent
+}
+
+func (b *RestartBackoff) delay(restarts int32) time.Duration {
+	factor := DefaultBackoffFactor
+	if b.Factor != nil {
+		factor = *b.Factor
+	}
+	delay := float64(b.InitialDelay.Duration) * math.Pow(factor, float64(restarts))
+	if b.MaxDelay != nil && delay > float64(b.MaxDelay.Duration) {
+		return b.MaxDelay.Duration
+	}
+	if delay > math.MaxInt64 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(delay)
+}
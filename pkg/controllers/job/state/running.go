@@ -44,6 +44,11 @@ func (ps *runningState) Execute(action Action) error {
 			status.RetryCount++
 			return true
 		})
+	case apis.SuspendJobAction:
+		return SuspendJob(ps.job, func(status *vcbatch.JobStatus) bool {
+			status.State.Phase = vcbatch.Aborting
+			return true
+		})
 	case v1alpha1.AbortJobAction:
 		return KillJob(ps.job, PodRetainPhaseSoft, func(status *vcbatch.JobStatus) bool {
 			status.State.Phase = vcbatch.Aborting
@@ -67,6 +72,22 @@ func (ps *runningState) Execute(action Action) error {
 				return false
 			}
 
+			// The sidecar tasks are ignored, the completing state terminates them once the other tasks succeed.
+			if HasSidecarTask(ps.job.Job) {
+				if phase, changed := mainTasksPhase(ps.job.Job, status); changed {
+					status.State.Phase = phase
+					if phase == vcbatch.Failed {
+						UpdateJobFailed(fmt.Sprintf("%s/%s", ps.job.Job.Namespace, ps.job.Job.Name), ps.job.Job.Spec.Queue)
+					}
+					return true
+				}
+				if status.Pending > jobReplicas-ps.job.Job.Spec.MinAvailable {
+					status.State.Phase = vcbatch.Pending
+					return true
+				}
+				return false
+			}
+
 			minSuccess := ps.job.Job.Spec.MinSuccess
 			if minSuccess != nil && status.Succeeded >= *minSuccess {
 				status.State.Phase = vcbatch.Completed
@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	v1 "k8s.io/api/core/v1"
+
+	vcbatch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+)
+
+const (
+	// TaskRoleAnnotationKey is the annotation key on the template of a task setting its role.
+	TaskRoleAnnotationKey = "volcano.sh/task-role"
+	// TaskRoleSidecar is the role of a task which only assists the other tasks of the Job, such as
+	// a log shipper or a proxy. It is ignored when deciding whether the Job completed or failed,
+	// and terminated once all the other tasks finish.
+	TaskRoleSidecar = "sidecar"
+)
+
+// IsSidecarTask checks whether the task has the sidecar role.
+func IsSidecarTask(task *vcbatch.TaskSpec) bool {
+	return task.Template.Annotations[TaskRoleAnnotationKey] == TaskRoleSidecar
+}
+
+// HasSidecarTask checks whether any task of the Job has the sidecar role.
+func HasSidecarTask(job *vcbatch.Job) bool {
+	for i := range job.Spec.Tasks {
+		if IsSidecarTask(&job.Spec.Tasks[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// mainTasksPhase returns the phase of a Job with sidecar tasks according to its other tasks, and whether
+// the phase changes. The Job is Completing when they succeed, so that the sidecars are terminated first.
+func mainTasksPhase(job *vcbatch.Job, status *vcbatch.JobStatus) (vcbatch.JobPhase, bool) {
+	var replicas, minAvailable, succeeded, failed int32
+	taskMinAvailableMet := true
+	for i := range job.Spec.Tasks {
+		task := &job.Spec.Tasks[i]
+		if IsSidecarTask(task) {
+			continue
+		}
+		taskSucceeded := status.TaskStatusCount[task.Name].Phase[v1.PodSucceeded]
+		replicas += task.Replicas
+		succeeded += taskSucceeded
+		failed += status.TaskStatusCount[task.Name].Phase[v1.PodFailed]
+		if task.MinAvailable != nil {
+			minAvailable += *task.MinAvailable
+			taskMinAvailableMet = taskMinAvailableMet && taskSucceeded >= *task.MinAvailable
+		} else {
+			minAvailable += task.Replicas
+		}
+	}
+	// The minAvailable of the Job may include the sidecars, it can not require more than the other tasks.
+	if job.Spec.MinAvailable < minAvailable {
+		minAvailable = job.Spec.MinAvailable
+	}
+
+	minSuccess := job.Spec.MinSuccess
+	if minSuccess != nil && succeeded >= *minSuccess {
+		return vcbatch.Completing, true
+	}
+	if replicas == 0 || succeeded+failed < replicas {
+		return "", false
+	}
+	if !taskMinAvailableMet || (minSuccess != nil && succeeded < *minSuccess) || succeeded < minAvailable {
+		return vcbatch.Failed, true
+	}
+	return vcbatch.Completing, true
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vcbatch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+)
+
+const (
+	// StartupPolicyAnnotationKey is the annotation key of the StartupPolicy of a Job.
+	StartupPolicyAnnotationKey = "volcano.sh/startup-policy"
+)
+
+// StartupCondition is the condition a pod of a task must reach to count as started for the tasks depending on it.
+type StartupCondition string
+
+const (
+	// StartupConditionReady requires the pod to be Ready.
+	StartupConditionReady StartupCondition = "Ready"
+	// StartupConditionRunning requires the pod to be Running, regardless of its readiness.
+	StartupConditionRunning StartupCondition = "Running"
+	// StartupConditionReadinessGate requires the pod condition named by ReadinessGate to be True.
+	StartupConditionReadinessGate StartupCondition = "ReadinessGate"
+)
+
+// StartupPolicy defines when the tasks with dependsOn are started, the fields of TaskStartupPolicy
+// apply to all tasks and can be overridden per task. The policy of a task applies to the tasks it depends on.
+type StartupPolicy struct {
+	TaskStartupPolicy
+	Tasks map[string]TaskStartupPolicy `json:"tasks,omitempty"`
+}
+
+// TaskStartupPolicy defines when the dependencies of a task are met.
+type TaskStartupPolicy struct {
+	// Condition the pods of the dependencies must reach, defaults to Ready.
+	// Succeeded pods always meet the condition.
+	Condition StartupCondition `json:"condition,omitempty"`
+	// ReadinessGate is the pod condition type checked by the ReadinessGate condition.
+	ReadinessGate v1.PodConditionType `json:"readinessGate,omitempty"`
+	// MinPods is the number of pods of each dependency which must meet the condition,
+	// defaults to the minAvailable of the dependency, or its replicas if minAvailable is not set.
+	MinPods *int32 `json:"minPods,omitempty"`
+	// Timeout is how long the task waits for its dependencies once all their pods are created,
+	// a TaskFailed event of the task is raised when it is exceeded, so that the lifecycle policies apply.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// GetStartupPolicy returns the startup policy of the Job, or nil if not set.
+func GetStartupPolicy(job *vcbatch.Job) (*StartupPolicy, error) {
+	value, found := job.Annotations[StartupPolicyAnnotationKey]
+	if !found {
+		return nil, nil
+	}
+	policy := &StartupPolicy{}
+	if err := json.Unmarshal([]byte(value), policy); err != nil {
+		return nil, fmt.Errorf("invalid annotation %s: %v", StartupPolicyAnnotationKey, err)
+	}
+	return policy, nil
+}
+
+// ForTask returns the startup policy of the task, the fields set for the task override the ones of the Job.
+func (p *StartupPolicy) ForTask(taskName string) TaskStartupPolicy {
+	policy := p.TaskStartupPolicy
+	if override, found := p.Tasks[taskName]; found {
+		if override.Condition != "" {
+			policy.Condition = override.Condition
+			policy.ReadinessGate = override.ReadinessGate
+		}
+		if override.MinPods != nil {
+			policy.MinPods = override.MinPods
+		}
+		if override.Timeout != nil {
+			policy.Timeout = override.Timeout
+		}
+	}
+	if policy.Condition == "" {
+		policy.Condition = StartupConditionReady
+	}
+	return policy
+}
+
+// PodStarted checks whether the pod meets the startup condition of the policy.
+func (p TaskStartupPolicy) PodStarted(pod *v1.Pod) bool {
+	if pod.Status.Phase == v1.PodSucceeded {
+		return true
+	}
+	if pod.Status.Phase != v1.PodRunning {
+		return false
+	}
+
+	conditionType := v1.PodReady
+	switch p.Condition {
+	case StartupConditionRunning:
+		return true
+	case StartupConditionReadinessGate:
+		conditionType = p.ReadinessGate
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == conditionType {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -16,6 +16,8 @@ limitations under the License.
 
 package jobflow
 
+import "time"
+
 const (
 	// Volcano string of volcano apiVersion
 	Volcano = "volcano"
@@ -25,4 +27,7 @@ const (
 	CreatedByJobTemplate = "volcano.sh/createdByJobTemplate"
 	// CreatedByJobFlow the vcjob annotation and label of created by jobFlow
 	CreatedByJobFlow = "volcano.sh/createdByJobFlow"
+
+	// recreateInterval is the interval to recreate the retried job while the failed one is being deleted
+	recreateInterval = 5 * time.Second
 )
@@ -0,0 +1,293 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flowpolicy
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/cel-go/cel"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	flow "volcano.sh/apis/pkg/apis/flow/v1alpha1"
+)
+
+const (
+	// FlowPoliciesAnnotation sets the fan-out, retry and when condition of the flows in JobFlow by flow name,
+	// e.g. {"train":{"items":["a","b"],"retry":{"limit":2},"when":"jobs.prepare.phase == 'Completed'"}}
+	FlowPoliciesAnnotation = "volcano.sh/flow-policies"
+	// AttemptAnnotation records how many times the job has been retried by JobFlow
+	AttemptAnnotation = "volcano.sh/flow-attempt"
+	// InstanceIndexAnnotation records the index of the job in the instances of a fan-out flow
+	InstanceIndexAnnotation = "volcano.sh/flow-instance-index"
+
+	// Skipped is the phase of flow skipped by its when condition in JobFlow status
+	Skipped batch.JobPhase = "Skipped"
+	// Retrying is the phase of a failed job deleted to be recreated in JobFlow status, the restart count of
+	// the job status records the attempt of the recreated job
+	Retrying batch.JobPhase = "Retrying"
+
+	defaultBackoffSeconds    = 10
+	defaultMaxBackoffSeconds = 300
+)
+
+// RetryPolicy recreates the failed jobs of a flow with exponential backoff.
+type RetryPolicy struct {
+	Limit             int32 `json:"limit"`
+	BackoffSeconds    int32 `json:"backoffSeconds,omitempty"`
+	MaxBackoffSeconds int32 `json:"maxBackoffSeconds,omitempty"`
+}
+
+// FlowPolicy is the policy of a flow in JobFlow.
+type FlowPolicy struct {
+	// Replicas runs the flow N times, the instance index is substituted for {{instance.index}}
+	Replicas int32 `json:"replicas,omitempty"`
+	// Items runs the flow once per item, the item is substituted for {{instance.item}}
+	Items []string `json:"items,omitempty"`
+	// Retry recreates the failed jobs of the flow
+	Retry *RetryPolicy `json:"retry,omitempty"`
+	// When is a CEL expression evaluated once the dependencies of the flow are finished, the flow is skipped if false
+	When string `json:"when,omitempty"`
+}
+
+// Policies returns the policies of flows in JobFlow by flow name.
+func Policies(jobFlow *flow.JobFlow) (map[string]FlowPolicy, error) {
+	value, found := jobFlow.Annotations[FlowPoliciesAnnotation]
+	if !found {
+		return nil, nil
+	}
+	policies := map[string]FlowPolicy{}
+	if err := json.Unmarshal([]byte(value), &policies); err != nil {
+		return nil, fmt.Errorf("invalid annotation %s of JobFlow %s: %v", FlowPoliciesAnnotation, jobFlow.Name, err)
+	}
+	for name, policy := range policies {
+		if err := policy.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid policy of flow %s: %v", name, err)
+		}
+	}
+	return policies, nil
+}
+
+// Validate checks the policy.
+func (p FlowPolicy) Validate() error {
+	if p.Replicas < 0 {
+		return fmt.Errorf("replicas must be >= 0")
+	}
+	if p.Replicas > 0 && len(p.Items) > 0 {
+		return fmt.Errorf("replicas and items are mutually exclusive")
+	}
+	if p.Retry != nil && (p.Retry.Limit < 0 || p.Retry.BackoffSeconds < 0 || p.Retry.MaxBackoffSeconds < 0) {
+		return fmt.Errorf("retry limit and backoff must be >= 0")
+	}
+	if p.When != "" {
+		if _, err := compile(p.When); err != nil {
+			return fmt.Errorf("invalid when condition: %v", err)
+		}
+	}
+	return nil
+}
+
+// FanOut returns whether the flow runs more than one instance.
+func (p FlowPolicy) FanOut() bool {
+	return p.Replicas > 0 || len(p.Items) > 0
+}
+
+// JobNames returns the job names of the flow instances, baseName is the job name of the flow without fan-out.
+func (p FlowPolicy) JobNames(baseName string) []string {
+	if !p.FanOut() {
+		return []string{baseName}
+	}
+	count := int(p.Replicas)
+	if len(p.Items) > 0 {
+		count = len(p.Items)
+	}
+	names := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		names = append(names, baseName+"-"+strconv.Itoa(i))
+	}
+	return names
+}
+
+// Item returns the item of instance, it is empty if the flow does not fan out over items.
+func (p FlowPolicy) Item(index int) string {
+	if index < len(p.Items) {
+		return p.Items[index]
+	}
+	return ""
+}
+
+// RetryLimit returns how many times the failed job of the flow can be retried.
+func (p FlowPolicy) RetryLimit() int32 {
+	if p.Retry == nil {
+		return 0
+	}
+	return p.Retry.Limit
+}
+
+// Backoff returns how long to wait before retrying the job failed at the attempt.
+func (p FlowPolicy) Backoff(attempt int32) time.Duration {
+	backoff, maxBackoff := int64(defaultBackoffSeconds), int64(defaultMaxBackoffSeconds)
+	if p.Retry != nil && p.Retry.BackoffSeconds > 0 {
+		backoff = int64(p.Retry.BackoffSeconds)
+	}
+	if p.Retry != nil && p.Retry.MaxBackoffSeconds > 0 {
+		maxBackoff = int64(p.Retry.MaxBackoffSeconds)
+	}
+	for i := int32(0); i < attempt && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return time.Duration(backoff) * time.Second
+}
+
+// Attempt returns how many times the job has been retried by JobFlow.
+func Attempt(job *batch.Job) int32 {
+	attempt, err := strconv.ParseInt(job.Annotations[AttemptAnnotation], 10, 32)
+	if err != nil {
+		return 0
+	}
+	return int32(attempt)
+}
+
+// IsFinished returns whether the job is in a final phase.
+func IsFinished(phase batch.JobPhase) bool {
+	switch phase {
+	case batch.Completed, batch.Failed, batch.Terminated, batch.Aborted, Skipped:
+		return true
+	}
+	return false
+}
+
+// IsFailed returns whether the job is finished without completion.
+func IsFailed(phase batch.JobPhase) bool {
+	switch phase {
+	case batch.Failed, batch.Terminated, batch.Aborted:
+		return true
+	}
+	return false
+}
+
+// Phase aggregates the phases of flow instances: the flow is failed if any instance is failed,
+// and completed only if all instances are completed.
+func Phase(phases []batch.JobPhase) batch.JobPhase {
+	if len(phases) == 0 {
+		return batch.Pending
+	}
+	result := batch.Completed
+	for _, phase := range phases {
+		switch {
+		case phase == Skipped:
+			return Skipped
+		case IsFailed(phase):
+			return batch.Failed
+		case phase != batch.Completed:
+			result = phase
+		}
+	}
+	return result
+}
+
+var (
+	envOnce sync.Once
+	env     *cel.Env
+	envErr  error
+)
+
+func compile(expression string) (cel.Program, error) {
+	envOnce.Do(func() {
+		env, envErr = cel.NewEnv(cel.Variable("jobs", cel.MapType(cel.StringType, cel.DynType)))
+	})
+	if envErr != nil {
+		return nil, envErr
+	}
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		return nil, fmt.Errorf("expression must be evaluated to bool, got %v", ast.OutputType())
+	}
+	return env.Program(ast)
+}
+
+// Upstream is the outcome of a flow which the when condition depends on.
+type Upstream struct {
+	Phase batch.JobPhase
+	Jobs  []*batch.Job
+}
+
+// EvaluateWhen evaluates the when condition with the upstream flows by name. Each flow is exposed as
+// jobs.<flow> with `phase`, the aggregated phase, and `instances`, the phase, annotations and status of
+// each job; the annotations and status of the first job are also exposed as `annotations` and `status`.
+func EvaluateWhen(expression string, upstreams map[string]Upstream) (bool, error) {
+	program, err := compile(expression)
+	if err != nil {
+		return false, err
+	}
+
+	jobs := map[string]interface{}{}
+	for name, upstream := range upstreams {
+		instances := make([]interface{}, 0, len(upstream.Jobs))
+		for _, job := range upstream.Jobs {
+			instances = append(instances, instanceOf(job))
+		}
+		value := map[string]interface{}{
+			"phase":       string(upstream.Phase),
+			"instances":   instances,
+			"annotations": map[string]interface{}{},
+			"status":      map[string]interface{}{},
+		}
+		if len(instances) > 0 {
+			first := instances[0].(map[string]interface{})
+			value["annotations"] = first["annotations"]
+			value["status"] = first["status"]
+		}
+		jobs[name] = value
+	}
+
+	val, _, err := program.Eval(map[string]interface{}{"jobs": jobs})
+	if err != nil {
+		return false, err
+	}
+	result, ok := val.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression evaluated to %v instead of bool", val.Value())
+	}
+	return result, nil
+}
+
+func instanceOf(job *batch.Job) map[string]interface{} {
+	annotations := map[string]interface{}{}
+	for k, v := range job.Annotations {
+		annotations[k] = v
+	}
+	return map[string]interface{}{
+		"name":        job.Name,
+		"phase":       string(job.Status.State.Phase),
+		"annotations": annotations,
+		"status": map[string]interface{}{
+			"succeeded":  int64(job.Status.Succeeded),
+			"failed":     int64(job.Status.Failed),
+			"retryCount": int64(job.Status.RetryCount),
+		},
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flowpolicy
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	flow "volcano.sh/apis/pkg/apis/flow/v1alpha1"
+)
+
+func TestPolicies(t *testing.T) {
+	testCases := []struct {
+		name       string
+		annotation string
+		expectErr  bool
+	}{
+		{name: "valid policies", annotation: `{"a":{"replicas":3,"retry":{"limit":2}},"b":{"items":["x"],"when":"jobs.a.phase == 'Failed'"}}`},
+		{name: "replicas and items", annotation: `{"a":{"replicas":3,"items":["x"]}}`, expectErr: true},
+		{name: "negative retry limit", annotation: `{"a":{"retry":{"limit":-1}}}`, expectErr: true},
+		{name: "invalid when condition", annotation: `{"a":{"when":"jobs.b.phase =="}}`, expectErr: true},
+		{name: "when condition is not bool", annotation: `{"a":{"when":"'Completed'"}}`, expectErr: true},
+		{name: "invalid annotation", annotation: `{"a":`, expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			jobFlow := &flow.JobFlow{ObjectMeta: metav1.ObjectMeta{
+				Name:        "flow",
+				Annotations: map[string]string{FlowPoliciesAnnotation: tc.annotation},
+			}}
+			_, err := Policies(jobFlow)
+			if (err != nil) != tc.expectErr {
+				t.Errorf("expected error %v, got %v", tc.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestJobNames(t *testing.T) {
+	if names := (FlowPolicy{}).JobNames("flow-a"); !reflect.DeepEqual(names, []string{"flow-a"}) {
+		t.Errorf("unexpected job names %v", names)
+	}
+	if names := (FlowPolicy{Replicas: 2}).JobNames("flow-a"); !reflect.DeepEqual(names, []string{"flow-a-0", "flow-a-1"}) {
+		t.Errorf("unexpected job names %v", names)
+	}
+	policy := FlowPolicy{Items: []string{"x", "y", "z"}}
+	if names := policy.JobNames("flow-a"); len(names) != 3 || policy.Item(2) != "z" {
+		t.Errorf("unexpected job names %v", names)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	policy := FlowPolicy{Retry: &RetryPolicy{Limit: 5, BackoffSeconds: 10, MaxBackoffSeconds: 60}}
+	expected := []time.Duration{10 * time.Second, 20 * time.Second, 40 * time.Second, 60 * time.Second, 60 * time.Second}
+	for attempt, backoff := range expected {
+		if got := policy.Backoff(int32(attempt)); got != backoff {
+			t.Errorf("attempt %d: expected backoff %v, got %v", attempt, backoff, got)
+		}
+	}
+	if got := (FlowPolicy{}).Backoff(0); got != defaultBackoffSeconds*time.Second {
+		t.Errorf("expected default backoff, got %v", got)
+	}
+}
+
+func TestPhase(t *testing.T) {
+	testCases := []struct {
+		phases   []batch.JobPhase
+		expected batch.JobPhase
+	}{
+		{phases: nil, expected: batch.Pending},
+		{phases: []batch.JobPhase{batch.Completed, batch.Completed}, expected: batch.Completed},
+		{phases: []batch.JobPhase{batch.Completed, batch.Running}, expected: batch.Running},
+		{phases: []batch.JobPhase{batch.Running, batch.Failed}, expected: batch.Failed},
+		{phases: []batch.JobPhase{batch.Completed, batch.Terminated}, expected: batch.Failed},
+	}
+	for _, tc := range testCases {
+		if got := Phase(tc.phases); got != tc.expected {
+			t.Errorf("phases %v: expected %s, got %s", tc.phases, tc.expected, got)
+		}
+	}
+}
+
+func TestEvaluateWhen(t *testing.T) {
+	upstreams := map[string]Upstream{
+		"prepare": {
+			Phase: batch.Completed,
+			Jobs: []*batch.Job{{
+				ObjectMeta: metav1.ObjectMeta{Name: "flow-prepare", Annotations: map[string]string{"example.com/shards": "4"}},
+				Status:     batch.JobStatus{State: batch.JobState{Phase: batch.Completed}, Succeeded: 2},
+			}},
+		},
+		"train": {
+			Phase: batch.Failed,
+			Jobs: []*batch.Job{
+				{ObjectMeta: metav1.ObjectMeta{Name: "flow-train-0"}, Status: batch.JobStatus{State: batch.JobState{Phase: batch.Completed}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "flow-train-1"}, Status: batch.JobStatus{State: batch.JobState{Phase: batch.Failed}}},
+			},
+		},
+	}
+
+	testCases := []struct {
+		expression string
+		expected   bool
+		expectErr  bool
+	}{
+		{expression: "jobs.prepare.phase == 'Completed'", expected: true},
+		{expression: "jobs.train.phase == 'Completed'", expected: false},
+		{expression: "jobs.train.instances.exists(i, i.phase == 'Completed')", expected: true},
+		{expression: "jobs.prepare.annotations['example.com/shards'] == '4' && jobs.prepare.status.succeeded == 2", expected: true},
+		{expression: "jobs.evaluate.phase == 'Completed'", expectErr: true},
+	}
+	for _, tc := range testCases {
+		got, err := EvaluateWhen(tc.expression, upstreams)
+		if (err != nil) != tc.expectErr {
+			t.Errorf("%s: expected error %v, got %v", tc.expression, tc.expectErr, err)
+		}
+		if got != tc.expected {
+			t.Errorf("%s: expected %v, got %v", tc.expression, tc.expected, got)
+		}
+	}
+}
@@ -18,7 +18,10 @@ package jobflow
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -32,6 +35,9 @@ import (
 	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
 	v1alpha1flow "volcano.sh/apis/pkg/apis/flow/v1alpha1"
 	"volcano.sh/apis/pkg/client/clientset/versioned/scheme"
+	"volcano.sh/volcano/pkg/controllers/apis"
+	"volcano.sh/volcano/pkg/controllers/jobflow/flowpolicy"
+	"volcano.sh/volcano/pkg/controllers/jobflow/parameter"
 	"volcano.sh/volcano/pkg/controllers/jobflow/state"
 )
 
@@ -61,8 +67,12 @@ func (jf *jobflowcontroller) syncJobFlow(jobFlow *v1alpha1flow.JobFlow, updateSt
 	if err != nil {
 		return err
 	}
+	expectedJobs, err := jf.updateFlowPolicyStatus(jobFlow, jobFlowStatus)
+	if err != nil {
+		return err
+	}
 	jobFlow.Status = *jobFlowStatus
-	updateStateFn(&jobFlow.Status, len(jobFlow.Spec.Flows))
+	updateStateFn(&jobFlow.Status, expectedJobs)
 	_, err = jf.vcClient.FlowV1alpha1().JobFlows(jobFlow.Namespace).UpdateStatus(context.Background(), jobFlow, metav1.UpdateOptions{})
 	if err != nil {
 		klog.Errorf("Failed to update status of JobFlow %v/%v: %v",
@@ -74,63 +84,259 @@ func (jf *jobflowcontroller) syncJobFlow(jobFlow *v1alpha1flow.JobFlow, updateSt
 }
 
 func (jf *jobflowcontroller) deployJob(jobFlow *v1alpha1flow.JobFlow) error {
+	policies, err := flowpolicy.Policies(jobFlow)
+	if err != nil {
+		return err
+	}
+
 	// load jobTemplate by flow and deploy it
 	for _, flow := range jobFlow.Spec.Flows {
-		jobName := getJobName(jobFlow.Name, flow.Name)
-		if _, err := jf.jobLister.Jobs(jobFlow.Namespace).Get(jobName); err != nil {
-			if errors.IsNotFound(err) {
-				// If it is not distributed, judge whether the dependency of the VcJob meets the requirements
-				if flow.DependsOn == nil || flow.DependsOn.Targets == nil {
-					if err := jf.createJob(jobFlow, flow); err != nil {
-						return err
-					}
-				} else {
-					// query whether the dependencies of the job have been met
-					flag, err := jf.judge(jobFlow, flow)
-					if err != nil {
-						return err
-					}
-					if flag {
-						if err := jf.createJob(jobFlow, flow); err != nil {
-							return err
-						}
-					}
+		if isFlowSkipped(jobFlow, flow.Name) {
+			continue
+		}
+		policy := policies[flow.Name]
+		jobNames := policy.JobNames(getJobName(jobFlow.Name, flow.Name))
+
+		var missing []int
+		for index, jobName := range jobNames {
+			job, err := jf.jobLister.Jobs(jobFlow.Namespace).Get(jobName)
+			if err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+			// the failed job has been deleted to be retried, recreate it with the recorded attempt
+			if attempt := retryAttempt(jobFlow, jobName); attempt > 0 && (job == nil || flowpolicy.Attempt(job) < attempt) {
+				if err := jf.createJob(jobFlow, flow, policy, index, attempt); err != nil {
+					return err
 				}
 				continue
 			}
-			return err
+			if job == nil {
+				missing = append(missing, index)
+				continue
+			}
+			if err := jf.retryJob(jobFlow, flow, policy, index, job); err != nil {
+				return err
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+
+		// the dependencies have been met if any instance of the flow has been created
+		if len(missing) == len(jobNames) {
+			// query whether the dependencies of the job have been met
+			flag, err := jf.judge(jobFlow, flow, policies)
+			if err != nil {
+				return err
+			}
+			if !flag {
+				continue
+			}
+		}
+		for _, index := range missing {
+			if err := jf.createJob(jobFlow, flow, policy, index, 0); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
 }
 
-// judge query whether the dependencies of the job have been met. If it is satisfied, create the job, if not, judge the next job. Create the job if satisfied
-func (jf *jobflowcontroller) judge(jobFlow *v1alpha1flow.JobFlow, flow v1alpha1flow.Flow) (bool, error) {
-	for _, targetName := range flow.DependsOn.Targets {
-		targetJobName := getJobName(jobFlow.Name, targetName)
-		job, err := jf.jobLister.Jobs(jobFlow.Namespace).Get(targetJobName)
+// judge query whether the dependencies of the job have been met. Without when condition, the dependencies are met
+// if all targets are completed or skipped; with when condition, the condition is evaluated once all targets are
+// finished. The flow is marked as skipped if the dependencies can never be met.
+func (jf *jobflowcontroller) judge(jobFlow *v1alpha1flow.JobFlow, flow v1alpha1flow.Flow, policies map[string]flowpolicy.FlowPolicy) (bool, error) {
+	policy := policies[flow.Name]
+	if (flow.DependsOn == nil || flow.DependsOn.Targets == nil) && policy.When == "" {
+		return true, nil
+	}
+
+	upstreams := map[string]flowpolicy.Upstream{}
+	if flow.DependsOn != nil {
+		for _, targetName := range flow.DependsOn.Targets {
+			upstream, err := jf.getUpstream(jobFlow, targetName, policies[targetName])
+			if err != nil {
+				return false, err
+			}
+			if policy.When == "" && upstream.Phase != v1alpha1.Completed && upstream.Phase != flowpolicy.Skipped {
+				if !flowpolicy.IsFinished(upstream.Phase) {
+					return false, nil
+				}
+				jf.skipFlow(jobFlow, flow.Name, fmt.Sprintf("dependency %s is %s", targetName, upstream.Phase))
+				return false, nil
+			}
+			if !flowpolicy.IsFinished(upstream.Phase) {
+				return false, nil
+			}
+			upstreams[targetName] = upstream
+		}
+	}
+	if policy.When == "" {
+		return true, nil
+	}
+
+	matched, err := flowpolicy.EvaluateWhen(policy.When, upstreams)
+	if err != nil {
+		jf.recorder.Eventf(jobFlow, corev1.EventTypeWarning, "EvaluateFailed",
+			fmt.Sprintf("failed to evaluate when condition of flow %v: %v", flow.Name, err))
+	}
+	if !matched {
+		jf.skipFlow(jobFlow, flow.Name, fmt.Sprintf("when condition %q is not met", policy.When))
+		return false, nil
+	}
+	return true, nil
+}
+
+// getUpstream returns the outcome of the flow, a failed job to be retried is not finished yet.
+func (jf *jobflowcontroller) getUpstream(jobFlow *v1alpha1flow.JobFlow, flowName string, policy flowpolicy.FlowPolicy) (flowpolicy.Upstream, error) {
+	if isFlowSkipped(jobFlow, flowName) {
+		return flowpolicy.Upstream{Phase: flowpolicy.Skipped}, nil
+	}
+
+	upstream := flowpolicy.Upstream{}
+	var phases []v1alpha1.JobPhase
+	for _, jobName := range policy.JobNames(getJobName(jobFlow.Name, flowName)) {
+		job, err := jf.jobLister.Jobs(jobFlow.Namespace).Get(jobName)
 		if err != nil {
 			if errors.IsNotFound(err) {
-				klog.Info(fmt.Sprintf("No %v Job found！", targetJobName))
-				return false, nil
+				klog.V(4).Infof("No %v Job found!", jobName)
+				return flowpolicy.Upstream{Phase: v1alpha1.Pending}, nil
 			}
-			return false, err
+			return upstream, err
 		}
-		if job.Status.State.Phase != v1alpha1.Completed {
-			return false, nil
+		phase := job.Status.State.Phase
+		if isRetrying(job, policy) {
+			phase = v1alpha1.Restarting
 		}
+		phases = append(phases, phase)
+		upstream.Jobs = append(upstream.Jobs, job)
 	}
-	return true, nil
+	upstream.Phase = flowpolicy.Phase(phases)
+	return upstream, nil
+}
+
+// skipFlow records the flow as skipped in the JobFlow status, which is persisted along with the status of jobs.
+func (jf *jobflowcontroller) skipFlow(jobFlow *v1alpha1flow.JobFlow, flowName string, reason string) {
+	jobFlow.Status.JobStatusList = append(jobFlow.Status.JobStatusList, v1alpha1flow.JobStatus{
+		Name:           getJobName(jobFlow.Name, flowName),
+		State:          flowpolicy.Skipped,
+		StartTimestamp: metav1.Now(),
+	})
+	jf.recorder.Eventf(jobFlow, corev1.EventTypeNormal, "Skipped", fmt.Sprintf("skip flow %v because %v", flowName, reason))
+}
+
+func isFlowSkipped(jobFlow *v1alpha1flow.JobFlow, flowName string) bool {
+	jobName := getJobName(jobFlow.Name, flowName)
+	for _, jobStatus := range jobFlow.Status.JobStatusList {
+		if jobStatus.Name == jobName && jobStatus.State == flowpolicy.Skipped {
+			return true
+		}
+	}
+	return false
+}
+
+func isRetrying(job *v1alpha1.Job, policy flowpolicy.FlowPolicy) bool {
+	return job.Status.State.Phase == v1alpha1.Failed && flowpolicy.Attempt(job) < policy.RetryLimit()
+}
+
+// retryJob recreates the failed job once the backoff of the attempt has elapsed.
+func (jf *jobflowcontroller) retryJob(jobFlow *v1alpha1flow.JobFlow, flow v1alpha1flow.Flow, policy flowpolicy.FlowPolicy, index int, job *v1alpha1.Job) error {
+	if !isRetrying(job, policy) {
+		return nil
+	}
+
+	attempt := flowpolicy.Attempt(job)
+	if wait := policy.Backoff(attempt) - time.Since(job.Status.State.LastTransitionTime.Time); wait > 0 {
+		jf.requeueAfter(jobFlow, wait)
+		return nil
+	}
+
+	// persist the attempt before deleting the job, otherwise it is lost along with the job
+	recordRetry(jobFlow, job.Name, attempt+1)
+	updated, err := jf.vcClient.FlowV1alpha1().JobFlows(jobFlow.Namespace).UpdateStatus(context.Background(), jobFlow, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+	jobFlow.ResourceVersion = updated.ResourceVersion
+
+	propagationPolicy := metav1.DeletePropagationBackground
+	err = jf.vcClient.BatchV1alpha1().Jobs(jobFlow.Namespace).Delete(context.Background(), job.Name, metav1.DeleteOptions{
+		PropagationPolicy: &propagationPolicy,
+	})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	jf.recorder.Eventf(jobFlow, corev1.EventTypeNormal, "Retrying",
+		fmt.Sprintf("retry the failed job %v, attempt %d of %d", job.Name, attempt+1, policy.RetryLimit()))
+	return jf.createJob(jobFlow, flow, policy, index, attempt+1)
+}
+
+// recordRetry records the job deleted to be retried in the JobFlow status with the attempt as its restart count,
+// so that the attempt survives the deletion of the job until the job is recreated.
+func recordRetry(jobFlow *v1alpha1flow.JobFlow, jobName string, attempt int32) {
+	for i := range jobFlow.Status.JobStatusList {
+		if jobFlow.Status.JobStatusList[i].Name == jobName {
+			jobFlow.Status.JobStatusList[i].State = flowpolicy.Retrying
+			jobFlow.Status.JobStatusList[i].RestartCount = attempt
+			return
+		}
+	}
+	jobFlow.Status.JobStatusList = append(jobFlow.Status.JobStatusList, v1alpha1flow.JobStatus{
+		Name:           jobName,
+		State:          flowpolicy.Retrying,
+		StartTimestamp: metav1.Now(),
+		RestartCount:   attempt,
+	})
+}
+
+// retryAttempt returns the attempt of the job recorded as being retried, or 0 if it is not.
+func retryAttempt(jobFlow *v1alpha1flow.JobFlow, jobName string) int32 {
+	for _, jobStatus := range jobFlow.Status.JobStatusList {
+		if jobStatus.Name == jobName && jobStatus.State == flowpolicy.Retrying {
+			return jobStatus.RestartCount
+		}
+	}
+	return 0
+}
+
+// isReplacedJob returns whether the job is the failed one deleted to be retried, which may still be cached.
+func isReplacedJob(jobFlow *v1alpha1flow.JobFlow, job *v1alpha1.Job) bool {
+	return flowpolicy.Attempt(job) < retryAttempt(jobFlow, job.Name)
+}
+
+func (jf *jobflowcontroller) requeueAfter(jobFlow *v1alpha1flow.JobFlow, wait time.Duration) {
+	jf.queue.AddAfter(apis.FlowRequest{
+		Namespace:   jobFlow.Namespace,
+		JobFlowName: jobFlow.Name,
+		Action:      v1alpha1flow.SyncJobFlowAction,
+		Event:       v1alpha1flow.OutOfSyncEvent,
+	}, wait)
 }
 
-// createJob
-func (jf *jobflowcontroller) createJob(jobFlow *v1alpha1flow.JobFlow, flow v1alpha1flow.Flow) error {
+// createJob creates the job of the flow instance
+func (jf *jobflowcontroller) createJob(jobFlow *v1alpha1flow.JobFlow, flow v1alpha1flow.Flow, policy flowpolicy.FlowPolicy, index int, attempt int32) error {
 	job := new(v1alpha1.Job)
-	if err := jf.loadJobTemplateAndSetJob(jobFlow, flow.Name, getJobName(jobFlow.Name, flow.Name), job); err != nil {
+	jobName := policy.JobNames(getJobName(jobFlow.Name, flow.Name))[index]
+	if err := jf.loadJobTemplateAndSetJob(jobFlow, flow.Name, jobName, index, job); err != nil {
 		return err
 	}
+	if policy.FanOut() {
+		spec, err := parameter.SubstituteInstance(&job.Spec, index, policy.Item(index))
+		if err != nil {
+			return err
+		}
+		job.Spec = *spec
+		job.Annotations[flowpolicy.InstanceIndexAnnotation] = strconv.Itoa(index)
+	}
+	if attempt > 0 {
+		job.Annotations[flowpolicy.AttemptAnnotation] = strconv.Itoa(int(attempt))
+	}
 	if _, err := jf.vcClient.BatchV1alpha1().Jobs(jobFlow.Namespace).Create(context.Background(), job, metav1.CreateOptions{}); err != nil {
 		if errors.IsAlreadyExists(err) {
+			// the retried job is still being deleted, keep the attempt and create it later
+			if attempt > 0 {
+				jf.requeueAfter(jobFlow, recreateInterval)
+			}
 			return nil
 		}
 		return err
@@ -139,6 +345,75 @@ func (jf *jobflowcontroller) createJob(jobFlow *v1alpha1flow.JobFlow, flow v1alp
 	return nil
 }
 
+// recoverableFailures returns the failed jobs which do not fail the JobFlow, i.e. the jobs to be retried
+// and the jobs of flows depended on by a flow with when condition.
+func (jf *jobflowcontroller) recoverableFailures(jobFlow *v1alpha1flow.JobFlow, policies map[string]flowpolicy.FlowPolicy) (retrying, handled map[string]bool, err error) {
+	handledFlows := map[string]bool{}
+	for _, flow := range jobFlow.Spec.Flows {
+		if policies[flow.Name].When == "" || flow.DependsOn == nil {
+			continue
+		}
+		for _, target := range flow.DependsOn.Targets {
+			handledFlows[target] = true
+		}
+	}
+
+	jobList, err := jf.getAllJobsCreatedByJobFlow(jobFlow)
+	if err != nil {
+		return nil, nil, err
+	}
+	retrying, handled = map[string]bool{}, map[string]bool{}
+	for _, job := range jobList {
+		if !flowpolicy.IsFailed(job.Status.State.Phase) {
+			continue
+		}
+		flowName := strings.TrimPrefix(job.Labels[CreatedByJobTemplate], jobFlow.Namespace+".")
+		if isRetrying(job, policies[flowName]) {
+			retrying[job.Name] = true
+		} else if handledFlows[flowName] {
+			handled[job.Name] = true
+		}
+	}
+	return retrying, handled, nil
+}
+
+// updateFlowPolicyStatus removes the recoverable failures from the failed jobs of JobFlow status,
+// and returns the number of jobs expected to complete for the JobFlow to succeed.
+func (jf *jobflowcontroller) updateFlowPolicyStatus(jobFlow *v1alpha1flow.JobFlow, status *v1alpha1flow.JobFlowStatus) (int, error) {
+	policies, err := flowpolicy.Policies(jobFlow)
+	if err != nil {
+		return 0, err
+	}
+
+	expected := 0
+	for _, flow := range jobFlow.Spec.Flows {
+		if isFlowSkipped(jobFlow, flow.Name) {
+			continue
+		}
+		expected += len(policies[flow.Name].JobNames(getJobName(jobFlow.Name, flow.Name)))
+	}
+	if len(policies) == 0 {
+		return expected, nil
+	}
+
+	retrying, handled, err := jf.recoverableFailures(jobFlow, policies)
+	if err != nil {
+		return 0, err
+	}
+	filter := func(jobs []string) []string {
+		result := make([]string, 0, len(jobs))
+		for _, job := range jobs {
+			if !retrying[job] && !handled[job] {
+				result = append(result, job)
+			}
+		}
+		return result
+	}
+	status.FailedJobs = filter(status.FailedJobs)
+	status.TerminatedJobs = filter(status.TerminatedJobs)
+	return expected - len(handled), nil
+}
+
 // getAllJobStatus Get the information of all created jobs
 func (jf *jobflowcontroller) getAllJobStatus(jobFlow *v1alpha1flow.JobFlow) (*v1alpha1flow.JobFlowStatus, error) {
 	jobList, err := jf.getAllJobsCreatedByJobFlow(jobFlow)
@@ -159,6 +434,13 @@ func (jf *jobflowcontroller) getAllJobStatus(jobFlow *v1alpha1flow.JobFlow) (*v1
 
 	UnKnowJobs := make([]string, 0)
 	conditions := make(map[string]v1alpha1flow.Condition)
+	current := make([]*v1alpha1.Job, 0, len(jobList))
+	for _, job := range jobList {
+		if !isReplacedJob(jobFlow, job) {
+			current = append(current, job)
+		}
+	}
+	jobList = current
 	for _, job := range jobList {
 		if _, ok := statusListJobMap[job.Status.State.Phase]; ok {
 			statusListJobMap[job.Status.State.Phase] = append(statusListJobMap[job.Status.State.Phase], job.Name)
@@ -187,7 +469,7 @@ func (jf *jobflowcontroller) getAllJobStatus(jobFlow *v1alpha1flow.JobFlow) (*v1
 			State:            job.Status.State.Phase,
 			StartTimestamp:   job.CreationTimestamp,
 			EndTimestamp:     endTimeStamp,
-			RestartCount:     job.Status.RetryCount,
+			RestartCount:     job.Status.RetryCount + flowpolicy.Attempt(job),
 			RunningHistories: runningHistories,
 		}
 		jobFlag := true
@@ -251,7 +533,7 @@ func getRunningHistories(jobStatusList []v1alpha1flow.JobStatus, job *v1alpha1.J
 	return runningHistories
 }
 
-func (jf *jobflowcontroller) loadJobTemplateAndSetJob(jobFlow *v1alpha1flow.JobFlow, flowName string, jobName string, job *v1alpha1.Job) error {
+func (jf *jobflowcontroller) loadJobTemplateAndSetJob(jobFlow *v1alpha1flow.JobFlow, flowName string, jobName string, index int, job *v1alpha1.Job) error {
 	// load jobTemplate
 	jobTemplate, err := jf.jobTemplateLister.JobTemplates(jobFlow.Namespace).Get(flowName)
 	if err != nil {
@@ -275,9 +557,75 @@ func (jf *jobflowcontroller) loadJobTemplateAndSetJob(jobFlow *v1alpha1flow.JobF
 		Status: v1alpha1.JobStatus{},
 	}
 
+	if err := jf.setJobParameters(jobFlow, jobTemplate, flowName, index, job); err != nil {
+		return err
+	}
+
 	return controllerutil.SetControllerReference(jobFlow, job, scheme.Scheme)
 }
 
+// setJobParameters substitutes the parameters of JobTemplate with the values passed by JobFlow,
+// the outputs of upstream jobs referenced by the values are resolved when the job is created.
+// An upstream flow fanned out is referenced by its instance of the same index as the job,
+// or by its first instance if it has fewer instances.
+func (jf *jobflowcontroller) setJobParameters(jobFlow *v1alpha1flow.JobFlow, jobTemplate *v1alpha1flow.JobTemplate, flowName string, index int, job *v1alpha1.Job) error {
+	params, err := parameter.TemplateParameters(jobTemplate)
+	if err != nil {
+		return err
+	}
+	flowValues, err := parameter.FlowParameters(jobFlow)
+	if err != nil {
+		return err
+	}
+	if len(params) == 0 && len(flowValues[flowName]) == 0 {
+		return nil
+	}
+
+	values, err := parameter.Values(params, flowValues[flowName])
+	if err != nil {
+		return fmt.Errorf("invalid parameters of flow %s: %v", flowName, err)
+	}
+	policies, err := flowpolicy.Policies(jobFlow)
+	if err != nil {
+		return err
+	}
+	getJob := func(name string) (*v1alpha1.Job, error) {
+		return jf.jobLister.Jobs(jobFlow.Namespace).Get(upstreamJobName(jobFlow, name, policies[name], index))
+	}
+	for _, p := range params {
+		resolved, err := parameter.Resolve(values[p.Name], getJob)
+		if err != nil {
+			return fmt.Errorf("failed to resolve parameter %s of flow %s: %v", p.Name, flowName, err)
+		}
+		if err := p.Validate(resolved); err != nil {
+			return fmt.Errorf("invalid parameter of flow %s: %v", flowName, err)
+		}
+		values[p.Name] = resolved
+	}
+
+	spec, err := parameter.Substitute(&job.Spec, values)
+	if err != nil {
+		return fmt.Errorf("failed to substitute parameters of flow %s: %v", flowName, err)
+	}
+	job.Spec = *spec
+
+	resolved, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	job.Annotations[parameter.ResolvedParametersAnnotation] = string(resolved)
+	return nil
+}
+
+// upstreamJobName returns the name of the upstream job referenced by the instance of the given index.
+func upstreamJobName(jobFlow *v1alpha1flow.JobFlow, flowName string, policy flowpolicy.FlowPolicy, index int) string {
+	jobNames := policy.JobNames(getJobName(jobFlow.Name, flowName))
+	if index < len(jobNames) {
+		return jobNames[index]
+	}
+	return jobNames[0]
+}
+
 func (jf *jobflowcontroller) deleteAllJobsCreatedByJobFlow(jobFlow *v1alpha1flow.JobFlow) error {
 	jobList, err := jf.getAllJobsCreatedByJobFlow(jobFlow)
 	if err != nil {
@@ -22,6 +22,7 @@ import (
 	"testing"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/informers"
@@ -35,6 +36,8 @@ import (
 	"volcano.sh/apis/pkg/client/clientset/versioned/scheme"
 	informerfactory "volcano.sh/apis/pkg/client/informers/externalversions"
 	"volcano.sh/volcano/pkg/controllers/framework"
+	"volcano.sh/volcano/pkg/controllers/jobflow/flowpolicy"
+	"volcano.sh/volcano/pkg/controllers/jobflow/parameter"
 )
 
 func newFakeController() *jobflowcontroller {
@@ -825,7 +828,7 @@ func TestLoadJobTemplateAndSetJobFunc(t *testing.T) {
 				t.Error("Error While add vcjob")
 			}
 
-			if got := fakeController.loadJobTemplateAndSetJob(tt.args.jobFlow, tt.args.flowName, tt.args.jobName, tt.args.job); got != tt.want.Err {
+			if got := fakeController.loadJobTemplateAndSetJob(tt.args.jobFlow, tt.args.flowName, tt.args.jobName, 0, tt.args.job); got != tt.want.Err {
 				t.Error("Expected loadJobTemplateAndSetJob() return nil, but not nil")
 			}
 			if !equality.Semantic.DeepEqual(tt.args.job.OwnerReferences, tt.want.OwnerReference) {
@@ -841,6 +844,126 @@ func TestLoadJobTemplateAndSetJobFunc(t *testing.T) {
 	}
 }
 
+func TestLoadJobTemplateWithParametersFunc(t *testing.T) {
+	jobTemplate := &jobflowv1alpha1.JobTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "train",
+			Namespace: "default",
+			Annotations: map[string]string{
+				parameter.TemplateParametersAnnotation: `[{"name":"model"},{"name":"epochs","type":"int","default":"10"}]`,
+			},
+		},
+		Spec: v1alpha1.JobSpec{
+			Tasks: []v1alpha1.TaskSpec{{
+				Name: "worker",
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "worker", Args: []string{"--model={{params.model}}", "--epochs={{params.epochs}}"}}},
+					},
+				},
+			}},
+		},
+	}
+	upstreamJob := &v1alpha1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        getJobName("jobflow", "prepare"),
+			Namespace:   "default",
+			Annotations: map[string]string{"example.com/model": "resnet"},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		parameters   string
+		expectedArgs []string
+		expectErr    bool
+	}{
+		{
+			name:         "parameters from upstream job and defaults",
+			parameters:   `{"train":{"model":"{{jobs.prepare.annotations.example.com/model}}"}}`,
+			expectedArgs: []string{"--model=resnet", "--epochs=10"},
+		},
+		{
+			name:       "upstream output does not match the type",
+			parameters: `{"train":{"model":"resnet","epochs":"{{jobs.prepare.annotations.example.com/model}}"}}`,
+			expectErr:  true,
+		},
+		{
+			name:       "required parameter is missing",
+			parameters: `{}`,
+			expectErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeController := newFakeController()
+			if err := fakeController.jobTemplateInformer.Informer().GetIndexer().Add(jobTemplate); err != nil {
+				t.Fatalf("Error While add jobtemplate: %v", err)
+			}
+			if err := fakeController.jobInformer.Informer().GetIndexer().Add(upstreamJob); err != nil {
+				t.Fatalf("Error While add vcjob: %v", err)
+			}
+			jobFlow := &jobflowv1alpha1.JobFlow{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "jobflow",
+					Namespace:   "default",
+					Annotations: map[string]string{parameter.FlowParametersAnnotation: tt.parameters},
+				},
+			}
+
+			job := &v1alpha1.Job{}
+			err := fakeController.loadJobTemplateAndSetJob(jobFlow, "train", getJobName("jobflow", "train"), 0, job)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("expected error %v, got %v", tt.expectErr, err)
+			}
+			if tt.expectErr {
+				return
+			}
+			if args := job.Spec.Tasks[0].Template.Spec.Containers[0].Args; !equality.Semantic.DeepEqual(args, tt.expectedArgs) {
+				t.Errorf("expected args %v, got %v", tt.expectedArgs, args)
+			}
+			if job.Annotations[parameter.ResolvedParametersAnnotation] != `{"epochs":"10","model":"resnet"}` {
+				t.Errorf("unexpected resolved parameters %s", job.Annotations[parameter.ResolvedParametersAnnotation])
+			}
+		})
+	}
+}
+
+func TestUpstreamJobNameFunc(t *testing.T) {
+	jobFlow := &jobflowv1alpha1.JobFlow{ObjectMeta: metav1.ObjectMeta{Name: "jobflow", Namespace: "default"}}
+	tests := []struct {
+		name     string
+		policy   flowpolicy.FlowPolicy
+		index    int
+		expected string
+	}{
+		{
+			name:     "upstream without fan-out",
+			index:    1,
+			expected: "jobflow-prepare",
+		},
+		{
+			name:     "instance of the same index",
+			policy:   flowpolicy.FlowPolicy{Items: []string{"a", "b"}},
+			index:    1,
+			expected: "jobflow-prepare-1",
+		},
+		{
+			name:     "first instance if upstream has fewer instances",
+			policy:   flowpolicy.FlowPolicy{Replicas: 2},
+			index:    3,
+			expected: "jobflow-prepare-0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := upstreamJobName(jobFlow, "prepare", tt.policy, tt.index); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
 func TestDeployJobFunc(t *testing.T) {
 	type args struct {
 		jobFlow         *jobflowv1alpha1.JobFlow
@@ -900,6 +1023,148 @@ func TestDeployJobFunc(t *testing.T) {
 	}
 }
 
+func TestDeployJobWithFlowPoliciesFunc(t *testing.T) {
+	newJobTemplate := func(name string) *jobflowv1alpha1.JobTemplate {
+		return &jobflowv1alpha1.JobTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: v1alpha1.JobSpec{
+				Tasks: []v1alpha1.TaskSpec{{
+					Name: "worker",
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "worker", Args: []string{"--shard={{instance.index}}", "--item={{instance.item}}"}}},
+						},
+					},
+				}},
+			},
+		}
+	}
+	newJob := func(flowName string, phase v1alpha1.JobPhase) *v1alpha1.Job {
+		return &v1alpha1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      getJobName("jobflow", flowName),
+				Namespace: "default",
+				Labels:    map[string]string{CreatedByJobTemplate: GenerateObjectString("default", flowName)},
+			},
+			Status: v1alpha1.JobStatus{State: v1alpha1.JobState{
+				Phase:              phase,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+			}},
+		}
+	}
+	jobFlow := &jobflowv1alpha1.JobFlow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "jobflow",
+			Namespace: "default",
+			Annotations: map[string]string{
+				flowpolicy.FlowPoliciesAnnotation: `{"prepare":{"retry":{"limit":1}},"train":{"items":["a","b"]},` +
+					`"cleanup":{"when":"jobs.prepare.phase == 'Failed'"}}`,
+			},
+		},
+		Spec: jobflowv1alpha1.JobFlowSpec{
+			Flows: []jobflowv1alpha1.Flow{
+				{Name: "prepare"},
+				{Name: "train", DependsOn: &jobflowv1alpha1.DependsOn{Targets: []string{"prepare"}}},
+				{Name: "cleanup", DependsOn: &jobflowv1alpha1.DependsOn{Targets: []string{"prepare"}}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		prepare       *v1alpha1.Job
+		retrying      int32
+		expectedJobs  map[string]string
+		expectSkipped bool
+		expectAttempt int32
+	}{
+		{
+			name:    "fan out and skip the flow whose when condition is not met",
+			prepare: newJob("prepare", v1alpha1.Completed),
+			expectedJobs: map[string]string{
+				"jobflow-train-0": "--item=a",
+				"jobflow-train-1": "--item=b",
+			},
+			expectSkipped: true,
+		},
+		{
+			name:    "retry the failed job",
+			prepare: newJob("prepare", v1alpha1.Failed),
+			expectedJobs: map[string]string{
+				"jobflow-prepare": "--item={{instance.item}}",
+			},
+			expectAttempt: 1,
+		},
+		{
+			name:     "recreate the retried job deleted with the recorded attempt",
+			retrying: 1,
+			expectedJobs: map[string]string{
+				"jobflow-prepare": "--item={{instance.item}}",
+			},
+			expectAttempt: 1,
+		},
+		{
+			name:          "wait for the retried job being deleted",
+			prepare:       newJob("prepare", v1alpha1.Failed),
+			retrying:      1,
+			expectAttempt: -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeController := newFakeController()
+			for _, name := range []string{"prepare", "train", "cleanup"} {
+				if err := fakeController.jobTemplateInformer.Informer().GetIndexer().Add(newJobTemplate(name)); err != nil {
+					t.Fatalf("Error While add jobTemplate: %v", err)
+				}
+			}
+			if tt.prepare != nil {
+				if err := fakeController.jobInformer.Informer().GetIndexer().Add(tt.prepare); err != nil {
+					t.Fatalf("Error While add vcjob: %v", err)
+				}
+				if _, err := fakeController.vcClient.BatchV1alpha1().Jobs("default").Create(context.Background(), tt.prepare, metav1.CreateOptions{}); err != nil {
+					t.Fatalf("Error While create vcjob: %v", err)
+				}
+			}
+
+			jf := jobFlow.DeepCopy()
+			if tt.retrying > 0 {
+				recordRetry(jf, "jobflow-prepare", tt.retrying)
+			}
+			if _, err := fakeController.vcClient.FlowV1alpha1().JobFlows("default").Create(context.Background(), jf, metav1.CreateOptions{}); err != nil {
+				t.Fatalf("Error While create jobflow: %v", err)
+			}
+			if err := fakeController.deployJob(jf); err != nil {
+				t.Fatalf("Expected deployJob() return nil, but got %v", err)
+			}
+			for jobName, arg := range tt.expectedJobs {
+				job, err := fakeController.vcClient.BatchV1alpha1().Jobs("default").Get(context.Background(), jobName, metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("Expected job %s created, but got %v", jobName, err)
+				}
+				if args := job.Spec.Tasks[0].Template.Spec.Containers[0].Args; args[1] != arg {
+					t.Errorf("Expected job %s with arg %s, but got %v", jobName, arg, args)
+				}
+			}
+			if isFlowSkipped(jf, "cleanup") != tt.expectSkipped {
+				t.Errorf("Expected flow cleanup skipped %v", tt.expectSkipped)
+			}
+			if tt.expectAttempt != 0 {
+				job, err := fakeController.vcClient.BatchV1alpha1().Jobs("default").Get(context.Background(), "jobflow-prepare", metav1.GetOptions{})
+				if tt.expectAttempt < 0 && (err != nil || flowpolicy.Attempt(job) != 0) {
+					t.Errorf("Expected the job being deleted kept, but got %v, %v", job, err)
+				}
+				if tt.expectAttempt > 0 && (err != nil || flowpolicy.Attempt(job) != tt.expectAttempt) {
+					t.Errorf("Expected the retried job with attempt %d, but got %v, %v", tt.expectAttempt, job, err)
+				}
+				if retryAttempt(jf, "jobflow-prepare") != 1 {
+					t.Errorf("Expected the attempt recorded in JobFlow status, but got %v", jf.Status.JobStatusList)
+				}
+			}
+		})
+	}
+}
+
 func TestDeleteAllJobsCreateByJobFlowFunc(t *testing.T) {
 	type args struct {
 		jobFlow *jobflowv1alpha1.JobFlow
@@ -0,0 +1,302 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parameter
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	flow "volcano.sh/apis/pkg/apis/flow/v1alpha1"
+)
+
+const (
+	// TemplateParametersAnnotation declares the parameters of a JobTemplate,
+	// e.g. [{"name":"epochs","type":"int","default":"10"}]
+	TemplateParametersAnnotation = "volcano.sh/template-parameters"
+	// FlowParametersAnnotation passes the parameter values to the JobTemplates by flow name,
+	// e.g. {"train":{"epochs":"20","model":"{{jobs.prepare.annotations.example.com/model}}"}}
+	FlowParametersAnnotation = "volcano.sh/flow-parameters"
+	// ResolvedParametersAnnotation records the parameter values substituted into the job
+	ResolvedParametersAnnotation = "volcano.sh/parameters"
+)
+
+// Type is the type of parameter
+type Type string
+
+const (
+	String Type = "string"
+	Int    Type = "int"
+	Bool   Type = "bool"
+)
+
+// Parameter is a parameter declared by JobTemplate, a parameter without default value is required.
+type Parameter struct {
+	Name        string  `json:"name"`
+	Type        Type    `json:"type,omitempty"`
+	Default     *string `json:"default,omitempty"`
+	Description string  `json:"description,omitempty"`
+}
+
+// Reference is an output of upstream job referenced by a parameter value,
+// Kind is either annotations or status, and Path is the annotation key or the dotted status field.
+type Reference struct {
+	Flow string
+	Kind string
+	Path string
+}
+
+var (
+	// placeholderRegex matches the placeholders in JobTemplate spec, e.g. {{params.epochs}}
+	placeholderRegex = regexp.MustCompile(`\{\{\s*params\.([A-Za-z0-9_-]+)\s*\}\}`)
+	// instanceRegex matches the placeholders of flow instance in JobTemplate spec, e.g. {{instance.index}}
+	instanceRegex = regexp.MustCompile(`\{\{\s*instance\.(index|item)\s*\}\}`)
+	// referenceRegex matches the upstream outputs in parameter values, e.g. {{jobs.prepare.status.state.phase}}
+	referenceRegex = regexp.MustCompile(`\{\{\s*jobs\.([a-z0-9]([-a-z0-9]*[a-z0-9])?)\.(annotations|status)\.([^}\s]+)\s*\}\}`)
+)
+
+// TemplateParameters returns the parameters declared by the JobTemplate.
+func TemplateParameters(template *flow.JobTemplate) ([]Parameter, error) {
+	value, found := template.Annotations[TemplateParametersAnnotation]
+	if !found {
+		return nil, nil
+	}
+	var params []Parameter
+	if err := json.Unmarshal([]byte(value), &params); err != nil {
+		return nil, fmt.Errorf("invalid annotation %s of JobTemplate %s: %v", TemplateParametersAnnotation, template.Name, err)
+	}
+
+	names := map[string]bool{}
+	for i := range params {
+		if params[i].Type == "" {
+			params[i].Type = String
+		}
+		if names[params[i].Name] {
+			return nil, fmt.Errorf("parameter %s is declared more than once in JobTemplate %s", params[i].Name, template.Name)
+		}
+		names[params[i].Name] = true
+		if params[i].Default != nil {
+			if err := params[i].Validate(*params[i].Default); err != nil {
+				return nil, fmt.Errorf("invalid default value of JobTemplate %s: %v", template.Name, err)
+			}
+		}
+	}
+	return params, nil
+}
+
+// FlowParameters returns the parameter values of the flows in JobFlow by flow name.
+func FlowParameters(jobFlow *flow.JobFlow) (map[string]map[string]string, error) {
+	value, found := jobFlow.Annotations[FlowParametersAnnotation]
+	if !found {
+		return nil, nil
+	}
+	values := map[string]map[string]string{}
+	if err := json.Unmarshal([]byte(value), &values); err != nil {
+		return nil, fmt.Errorf("invalid annotation %s of JobFlow %s: %v", FlowParametersAnnotation, jobFlow.Name, err)
+	}
+	return values, nil
+}
+
+// Validate checks whether the value matches the type of parameter.
+func (p Parameter) Validate(value string) error {
+	var err error
+	switch p.Type {
+	case String, "":
+	case Int:
+		_, err = strconv.ParseInt(value, 10, 64)
+	case Bool:
+		_, err = strconv.ParseBool(value)
+	default:
+		return fmt.Errorf("parameter %s has unsupported type %s", p.Name, p.Type)
+	}
+	if err != nil {
+		return fmt.Errorf("value %q of parameter %s is not %s", value, p.Name, p.Type)
+	}
+	return nil
+}
+
+// References returns the upstream outputs referenced by the value.
+func References(value string) []Reference {
+	var refs []Reference
+	for _, match := range referenceRegex.FindAllStringSubmatch(value, -1) {
+		refs = append(refs, Reference{Flow: match[1], Kind: match[3], Path: match[4]})
+	}
+	return refs
+}
+
+// Resolve replaces the upstream outputs referenced by the value, getJob returns the job created for the flow.
+func Resolve(value string, getJob func(flowName string) (*batch.Job, error)) (string, error) {
+	var resolveErr error
+	resolved := referenceRegex.ReplaceAllStringFunc(value, func(placeholder string) string {
+		if resolveErr != nil {
+			return placeholder
+		}
+		ref := References(placeholder)[0]
+		job, err := getJob(ref.Flow)
+		if err != nil {
+			resolveErr = err
+			return placeholder
+		}
+		output, err := outputOf(job, ref)
+		if err != nil {
+			resolveErr = err
+			return placeholder
+		}
+		return output
+	})
+	return resolved, resolveErr
+}
+
+func outputOf(job *batch.Job, ref Reference) (string, error) {
+	if ref.Kind == "annotations" {
+		output, found := job.Annotations[ref.Path]
+		if !found {
+			return "", fmt.Errorf("annotation %s not found in job %s", ref.Path, job.Name)
+		}
+		return output, nil
+	}
+
+	status, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&job.Status)
+	if err != nil {
+		return "", err
+	}
+	var field interface{} = status
+	for _, key := range strings.Split(ref.Path, ".") {
+		fields, ok := field.(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("status field %s not found in job %s", ref.Path, job.Name)
+		}
+		if field, ok = fields[key]; !ok {
+			return "", fmt.Errorf("status field %s not found in job %s", ref.Path, job.Name)
+		}
+	}
+	switch f := field.(type) {
+	case string:
+		return f, nil
+	case int64, bool, float64:
+		return fmt.Sprint(f), nil
+	default:
+		return "", fmt.Errorf("status field %s of job %s is not a scalar", ref.Path, job.Name)
+	}
+}
+
+// Values merges the values with the defaults of parameters, and checks them by the declarations.
+func Values(params []Parameter, values map[string]string) (map[string]string, error) {
+	declared := map[string]Parameter{}
+	for _, p := range params {
+		declared[p.Name] = p
+	}
+	for name := range values {
+		if _, found := declared[name]; !found {
+			return nil, fmt.Errorf("parameter %s is not declared", name)
+		}
+	}
+
+	result := map[string]string{}
+	for _, p := range params {
+		value, found := values[p.Name]
+		if !found {
+			if p.Default == nil {
+				return nil, fmt.Errorf("required parameter %s is not set", p.Name)
+			}
+			value = *p.Default
+		}
+		// the outputs of upstream jobs are only known when the job is created
+		if len(References(value)) == 0 {
+			if err := p.Validate(value); err != nil {
+				return nil, err
+			}
+		}
+		result[p.Name] = value
+	}
+	return result, nil
+}
+
+// Placeholders returns the parameter names used by the spec.
+func Placeholders(spec *batch.JobSpec) ([]string, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	names := map[string]bool{}
+	for _, match := range placeholderRegex.FindAllStringSubmatch(string(data), -1) {
+		names[match[1]] = true
+	}
+	result := make([]string, 0, len(names))
+	for name := range names {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// Substitute replaces the placeholders in spec with the values.
+func Substitute(spec *batch.JobSpec, values map[string]string) (*batch.JobSpec, error) {
+	return substitute(spec, placeholderRegex, func(name string) (string, error) {
+		value, found := values[name]
+		if !found {
+			return "", fmt.Errorf("parameter %s is not declared", name)
+		}
+		return value, nil
+	})
+}
+
+// SubstituteInstance replaces the placeholders of flow instance in spec, i.e. {{instance.index}} and {{instance.item}}.
+func SubstituteInstance(spec *batch.JobSpec, index int, item string) (*batch.JobSpec, error) {
+	return substitute(spec, instanceRegex, func(name string) (string, error) {
+		if name == "index" {
+			return strconv.Itoa(index), nil
+		}
+		return item, nil
+	})
+}
+
+func substitute(spec *batch.JobSpec, regex *regexp.Regexp, valueOf func(name string) (string, error)) (*batch.JobSpec, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	var substituteErr error
+	substituted := regex.ReplaceAllStringFunc(string(data), func(placeholder string) string {
+		value, err := valueOf(regex.FindStringSubmatch(placeholder)[1])
+		if err != nil {
+			if substituteErr == nil {
+				substituteErr = err
+			}
+			return placeholder
+		}
+		// the placeholders are always inside JSON strings, so the value must be escaped
+		escaped, _ := json.Marshal(value)
+		return string(escaped[1 : len(escaped)-1])
+	})
+	if substituteErr != nil {
+		return nil, substituteErr
+	}
+
+	result := &batch.JobSpec{}
+	if err := json.Unmarshal([]byte(substituted), result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
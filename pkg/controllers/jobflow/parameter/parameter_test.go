@@ -0,0 +1,215 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parameter
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	batch "volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	flow "volcano.sh/apis/pkg/apis/flow/v1alpha1"
+)
+
+func TestTemplateParameters(t *testing.T) {
+	testCases := []struct {
+		name       string
+		annotation string
+		expected   []Parameter
+		expectErr  bool
+	}{
+		{
+			name: "no parameters",
+		},
+		{
+			name:       "default type is string",
+			annotation: `[{"name":"model"},{"name":"epochs","type":"int","default":"10"}]`,
+			expected: []Parameter{
+				{Name: "model", Type: String},
+				{Name: "epochs", Type: Int, Default: ptr("10")},
+			},
+		},
+		{
+			name:       "invalid default value",
+			annotation: `[{"name":"epochs","type":"int","default":"ten"}]`,
+			expectErr:  true,
+		},
+		{
+			name:       "duplicated parameters",
+			annotation: `[{"name":"epochs"},{"name":"epochs"}]`,
+			expectErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			template := &flow.JobTemplate{ObjectMeta: metav1.ObjectMeta{Name: "train"}}
+			if tc.annotation != "" {
+				template.Annotations = map[string]string{TemplateParametersAnnotation: tc.annotation}
+			}
+			params, err := TemplateParameters(template)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error %v, got %v", tc.expectErr, err)
+			}
+			if !tc.expectErr && !reflect.DeepEqual(params, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, params)
+			}
+		})
+	}
+}
+
+func TestValues(t *testing.T) {
+	params := []Parameter{
+		{Name: "model", Type: String},
+		{Name: "epochs", Type: Int, Default: ptr("10")},
+		{Name: "debug", Type: Bool, Default: ptr("false")},
+	}
+	testCases := []struct {
+		name      string
+		values    map[string]string
+		expected  map[string]string
+		expectErr bool
+	}{
+		{
+			name:     "defaults are used",
+			values:   map[string]string{"model": "resnet"},
+			expected: map[string]string{"model": "resnet", "epochs": "10", "debug": "false"},
+		},
+		{
+			name:     "references are validated after resolving",
+			values:   map[string]string{"model": "resnet", "epochs": "{{jobs.prepare.annotations.example.com/epochs}}"},
+			expected: map[string]string{"model": "resnet", "epochs": "{{jobs.prepare.annotations.example.com/epochs}}", "debug": "false"},
+		},
+		{
+			name:      "required parameter is missing",
+			values:    map[string]string{"epochs": "20"},
+			expectErr: true,
+		},
+		{
+			name:      "undeclared parameter",
+			values:    map[string]string{"model": "resnet", "lr": "0.1"},
+			expectErr: true,
+		},
+		{
+			name:      "mismatched type",
+			values:    map[string]string{"model": "resnet", "debug": "yes"},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			values, err := Values(params, tc.values)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error %v, got %v", tc.expectErr, err)
+			}
+			if !tc.expectErr && !reflect.DeepEqual(values, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, values)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	prepare := &batch.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "flow-prepare",
+			Annotations: map[string]string{"example.com/model": "s3://bucket/model"},
+		},
+		Status: batch.JobStatus{
+			State:     batch.JobState{Phase: batch.Completed},
+			Succeeded: 2,
+		},
+	}
+	getJob := func(name string) (*batch.Job, error) {
+		if name == "prepare" {
+			return prepare, nil
+		}
+		return nil, fmt.Errorf("job of flow %s not found", name)
+	}
+
+	testCases := []struct {
+		value     string
+		expected  string
+		expectErr bool
+	}{
+		{value: "plain", expected: "plain"},
+		{value: "{{jobs.prepare.annotations.example.com/model}}", expected: "s3://bucket/model"},
+		{value: "{{ jobs.prepare.status.state.phase }}-{{jobs.prepare.status.succeeded}}", expected: "Completed-2"},
+		{value: "{{jobs.prepare.status.unknown}}", expectErr: true},
+		{value: "{{jobs.other.status.succeeded}}", expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		resolved, err := Resolve(tc.value, getJob)
+		if (err != nil) != tc.expectErr {
+			t.Errorf("value %s: expected error %v, got %v", tc.value, tc.expectErr, err)
+		}
+		if !tc.expectErr && resolved != tc.expected {
+			t.Errorf("value %s: expected %s, got %s", tc.value, tc.expected, resolved)
+		}
+	}
+}
+
+func TestSubstitute(t *testing.T) {
+	spec := &batch.JobSpec{
+		Queue: "{{params.queue}}",
+		Tasks: []batch.TaskSpec{{
+			Name:     "worker",
+			Replicas: 2,
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{{
+						Name:    "worker",
+						Command: []string{"train", "--epochs={{ params.epochs }}", "--name={{params.name}}"},
+					}},
+				},
+			},
+		}},
+	}
+
+	placeholders, err := Placeholders(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(placeholders, []string{"epochs", "name", "queue"}) {
+		t.Errorf("unexpected placeholders %v", placeholders)
+	}
+
+	result, err := Substitute(spec, map[string]string{"queue": "gpu", "epochs": "20", "name": `say "hi"`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Queue != "gpu" {
+		t.Errorf("expected queue gpu, got %s", result.Queue)
+	}
+	expected := []string{"train", "--epochs=20", `--name=say "hi"`}
+	if command := result.Tasks[0].Template.Spec.Containers[0].Command; !reflect.DeepEqual(command, expected) {
+		t.Errorf("expected command %v, got %v", expected, command)
+	}
+
+	if _, err := Substitute(spec, map[string]string{"queue": "gpu"}); err == nil {
+		t.Errorf("expected error for undeclared parameters")
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
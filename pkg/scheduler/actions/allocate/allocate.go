@@ -125,6 +125,9 @@ func (alloc *Action) pickUpQueuesAndJobs(queues *util.PriorityQueue, jobsMap map
 func (alloc *Action) allocateResources(queues *util.PriorityQueue, jobsMap map[api.QueueID]*util.PriorityQueue) {
 	ssn := alloc.session
 	pendingTasks := map[api.JobID]*util.PriorityQueue{}
+	// groupStmts holds the statements of jobs in a job group which are ready on their own,
+	// they are committed only after all jobs of the group are ready.
+	groupStmts := map[string][]*framework.Statement{}
 
 	allNodes := ssn.NodeList
 
@@ -205,13 +208,46 @@ func (alloc *Action) allocateResources(queues *util.PriorityQueue, jobsMap map[a
 		}
 
 		if stmt != nil {
-			stmt.Commit()
+			alloc.commitStatement(stmt, job, groupStmts)
 		}
 
 		// Put back the queue to priority queue after job's resource allocating finished,
 		// To ensure that the priority of the queue is calculated based on the latest resource allocation situation.
 		queues.Push(queue)
 	}
+
+	// None of the jobs in a job group could be bound if any of its members is not ready.
+	for groupKey, stmts := range groupStmts {
+		klog.V(3).Infof("Job group <%s> is not ready, discard allocations of its %d statements", groupKey, len(stmts))
+		for i := len(stmts) - 1; i >= 0; i-- {
+			stmts[i].Discard()
+		}
+	}
+}
+
+// commitStatement commits the statement of a ready job. For a job in a job group, the statement
+// is held until all jobs of the group are ready, and then statements of the whole group are committed together.
+func (alloc *Action) commitStatement(stmt *framework.Statement, job *api.JobInfo, groupStmts map[string][]*framework.Statement) {
+	groupKey := job.JobGroupKey()
+	if len(groupKey) == 0 {
+		stmt.Commit()
+		return
+	}
+
+	groupStmts[groupKey] = append(groupStmts[groupKey], stmt)
+	for _, member := range alloc.session.JobGroupMembers(job) {
+		if !alloc.session.JobReady(member) {
+			klog.V(4).Infof("Job <%s/%s> of group <%s> is not ready, hold the statement of Job <%s/%s>",
+				member.Namespace, member.Name, groupKey, job.Namespace, job.Name)
+			return
+		}
+	}
+
+	klog.V(3).Infof("All jobs of group <%s> are ready, commit %d statements", groupKey, len(groupStmts[groupKey]))
+	for _, s := range groupStmts[groupKey] {
+		s.Commit()
+	}
+	delete(groupStmts, groupKey)
 }
 
 func (alloc *Action) allocateResourceForTasksWithTopology(tasks *util.PriorityQueue, job *api.JobInfo, queue *api.QueueInfo, highestAllowedTier int) (*framework.Statement, *util.PriorityQueue) {
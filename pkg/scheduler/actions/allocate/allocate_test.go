@@ -225,6 +225,64 @@ func TestAllocate(t *testing.T) {
 			},
 			ExpectBindsNum: 1,
 		},
+		{
+			Name: "jobs in a job group can not be allocated if not all of them are ready",
+			PodGroups: []*schedulingv1.PodGroup{
+				buildJobGroupPodGroup(util.BuildPodGroup("pg1", "c1", "c1", 1, nil, schedulingv1.PodGroupInqueue), "rl", "2"),
+				buildJobGroupPodGroup(util.BuildPodGroup("pg2", "c1", "c1", 1, nil, schedulingv1.PodGroupInqueue), "rl", "2"),
+			},
+			Pods: []*v1.Pod{
+				util.BuildPod("c1", "p1", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg1", nil, nil),
+				util.BuildPod("c1", "p2", "", v1.PodPending, api.BuildResourceList("2", "1G"), "pg2", nil, nil),
+			},
+			Nodes: []*v1.Node{
+				util.BuildNode("n1", api.BuildResourceList("2", "4Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil),
+			},
+			Queues: []*schedulingv1.Queue{
+				util.BuildQueue("c1", 1, nil),
+			},
+			ExpectBindMap:  map[string]string{},
+			ExpectBindsNum: 0,
+		},
+		{
+			Name: "jobs in a job group are allocated together when all of them are ready",
+			PodGroups: []*schedulingv1.PodGroup{
+				buildJobGroupPodGroup(util.BuildPodGroup("pg1", "c1", "c1", 1, nil, schedulingv1.PodGroupInqueue), "rl", "2"),
+				buildJobGroupPodGroup(util.BuildPodGroup("pg2", "c1", "c1", 1, nil, schedulingv1.PodGroupInqueue), "rl", "2"),
+			},
+			Pods: []*v1.Pod{
+				util.BuildPod("c1", "p1", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg1", nil, nil),
+				util.BuildPod("c1", "p2", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg2", nil, nil),
+			},
+			Nodes: []*v1.Node{
+				util.BuildNode("n1", api.BuildResourceList("2", "4Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil),
+			},
+			Queues: []*schedulingv1.Queue{
+				util.BuildQueue("c1", 1, nil),
+			},
+			ExpectBindMap: map[string]string{
+				"c1/p1": "n1",
+				"c1/p2": "n1",
+			},
+			ExpectBindsNum: 2,
+		},
+		{
+			Name: "jobs in a job group can not be allocated if the group has not enough jobs",
+			PodGroups: []*schedulingv1.PodGroup{
+				buildJobGroupPodGroup(util.BuildPodGroup("pg1", "c1", "c1", 1, nil, schedulingv1.PodGroupInqueue), "rl", "2"),
+			},
+			Pods: []*v1.Pod{
+				util.BuildPod("c1", "p1", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg1", nil, nil),
+			},
+			Nodes: []*v1.Node{
+				util.BuildNode("n1", api.BuildResourceList("2", "4Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil),
+			},
+			Queues: []*schedulingv1.Queue{
+				util.BuildQueue("c1", 1, nil),
+			},
+			ExpectBindMap:  map[string]string{},
+			ExpectBindsNum: 0,
+		},
 	}
 
 	trueValue := true
@@ -274,6 +332,15 @@ func TestAllocate(t *testing.T) {
 	}
 }
 
+func buildJobGroupPodGroup(pg *schedulingv1.PodGroup, group, minMember string) *schedulingv1.PodGroup {
+	if pg.Annotations == nil {
+		pg.Annotations = map[string]string{}
+	}
+	pg.Annotations[api.JobGroupAnnotationKey] = group
+	pg.Annotations[api.JobGroupMinMemberAnnotationKey] = minMember
+	return pg
+}
+
 // BenchmarkAllocate can help analyze the performance differences before and after changes to the scheduling framework. Currently, it is hardcoded to schedule 1000 pods
 func BenchmarkAllocate(b *testing.B) {
 	plugins := map[string]framework.PluginBuilder{
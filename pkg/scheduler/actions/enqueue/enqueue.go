@@ -78,6 +78,9 @@ func (enqueue *Action) Execute(ssn *framework.Session) {
 
 	klog.V(3).Infof("Try to enqueue PodGroup to %d Queues", len(jobsMap))
 
+	// Jobs of a job group are only moved to Inqueue together, after all pending members are enqueueable.
+	enqueuedGroupJobs := map[string][]*api.JobInfo{}
+
 	for {
 		if queues.Empty() {
 			break
@@ -93,14 +96,44 @@ func (enqueue *Action) Execute(ssn *framework.Session) {
 		job := jobs.Pop().(*api.JobInfo)
 
 		if job.PodGroup.Spec.MinResources == nil || ssn.JobEnqueueable(job) {
+			if groupKey := job.JobGroupKey(); len(groupKey) != 0 {
+				enqueuedGroupJobs[groupKey] = append(enqueuedGroupJobs[groupKey], job)
+			} else {
+				ssn.JobEnqueued(job)
+				job.PodGroup.Status.Phase = scheduling.PodGroupInqueue
+				ssn.Jobs[job.UID] = job
+			}
+		}
+
+		// Added Queue back until no job in Queue.
+		queues.Push(queue)
+	}
+
+	for groupKey, jobs := range enqueuedGroupJobs {
+		if !jobGroupEnqueueable(ssn, jobs) {
+			klog.V(3).Infof("Not all pending jobs of group <%s> are enqueueable, keep them pending", groupKey)
+			continue
+		}
+		for _, job := range jobs {
 			ssn.JobEnqueued(job)
 			job.PodGroup.Status.Phase = scheduling.PodGroupInqueue
 			ssn.Jobs[job.UID] = job
 		}
+	}
+}
 
-		// Added Queue back until no job in Queue.
-		queues.Push(queue)
+// jobGroupEnqueueable checks whether every pending member of the job group is in the enqueued jobs.
+func jobGroupEnqueueable(ssn *framework.Session, enqueued []*api.JobInfo) bool {
+	enqueuedSet := sets.NewString()
+	for _, job := range enqueued {
+		enqueuedSet.Insert(string(job.UID))
+	}
+	for _, member := range ssn.JobGroupMembers(enqueued[0]) {
+		if member.IsPending() && !enqueuedSet.Has(string(member.UID)) {
+			return false
+		}
 	}
+	return true
 }
 
 func (enqueue *Action) UnInitialize() {}
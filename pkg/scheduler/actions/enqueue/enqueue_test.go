@@ -29,6 +29,7 @@ import (
 	"volcano.sh/volcano/pkg/scheduler/framework"
 	"volcano.sh/volcano/pkg/scheduler/plugins/drf"
 	"volcano.sh/volcano/pkg/scheduler/plugins/gang"
+	"volcano.sh/volcano/pkg/scheduler/plugins/overcommit"
 	"volcano.sh/volcano/pkg/scheduler/plugins/proportion"
 	"volcano.sh/volcano/pkg/scheduler/plugins/sla"
 	"volcano.sh/volcano/pkg/scheduler/uthelper"
@@ -165,3 +166,93 @@ func TestEnqueue(t *testing.T) {
 		})
 	}
 }
+
+func TestEnqueueJobGroup(t *testing.T) {
+	plugins := map[string]framework.PluginBuilder{
+		overcommit.PluginName: overcommit.New,
+	}
+	tests := []uthelper.TestCommonStruct{
+		{
+			Name: "jobs in a job group are enqueued together when all of them are enqueueable",
+			PodGroups: []*schedulingv1.PodGroup{
+				buildJobGroupPodGroup(util.BuildPodGroupWithMinResources("pg1", "c1", "c1", 1,
+					nil, api.BuildResourceList("1", "1G"), schedulingv1.PodGroupPending), "rl"),
+				buildJobGroupPodGroup(util.BuildPodGroupWithMinResources("pg2", "c1", "c1", 1,
+					nil, api.BuildResourceList("1", "1G"), schedulingv1.PodGroupPending), "rl"),
+			},
+			Pods: []*v1.Pod{
+				util.BuildPod("c1", "p1", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg1", make(map[string]string), make(map[string]string)),
+				util.BuildPod("c1", "p2", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg2", make(map[string]string), make(map[string]string)),
+			},
+			Nodes: []*v1.Node{
+				util.BuildNode("n1", api.BuildResourceList("4", "4G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil),
+			},
+			Queues: []*schedulingv1.Queue{
+				util.BuildQueue("c1", 1, api.BuildResourceList("4", "4G")),
+			},
+			ExpectStatus: map[api.JobID]scheduling.PodGroupPhase{
+				"c1/pg1": scheduling.PodGroupInqueue,
+				"c1/pg2": scheduling.PodGroupInqueue,
+			},
+		},
+		{
+			Name: "jobs in a job group stay pending and reserve no resources if one of them is not enqueueable",
+			PodGroups: []*schedulingv1.PodGroup{
+				buildJobGroupPodGroup(util.BuildPodGroupWithMinResources("pg1", "c1", "c1", 1,
+					nil, api.BuildResourceList("2", "2G"), schedulingv1.PodGroupPending), "rl"),
+				buildJobGroupPodGroup(util.BuildPodGroupWithMinResources("pg2", "c1", "c1", 1,
+					nil, api.BuildResourceList("8", "8G"), schedulingv1.PodGroupPending), "rl"),
+				util.BuildPodGroupWithMinResources("pg3", "c1", "c1", 1,
+					nil, api.BuildResourceList("3", "3G"), schedulingv1.PodGroupPending),
+			},
+			Pods: []*v1.Pod{
+				util.BuildPod("c1", "p1", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg1", make(map[string]string), make(map[string]string)),
+				util.BuildPod("c1", "p2", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg2", make(map[string]string), make(map[string]string)),
+				util.BuildPod("c1", "p3", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg3", make(map[string]string), make(map[string]string)),
+			},
+			Nodes: []*v1.Node{
+				util.BuildNode("n1", api.BuildResourceList("4", "4G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil),
+			},
+			Queues: []*schedulingv1.Queue{
+				util.BuildQueue("c1", 1, api.BuildResourceList("4", "4G")),
+			},
+			ExpectStatus: map[api.JobID]scheduling.PodGroupPhase{
+				"c1/pg1": scheduling.PodGroupPending,
+				"c1/pg2": scheduling.PodGroupPending,
+				"c1/pg3": scheduling.PodGroupInqueue,
+			},
+		},
+	}
+
+	trueValue := true
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:               overcommit.PluginName,
+					EnabledJobEnqueued: &trueValue,
+				},
+			},
+		},
+	}
+	for i, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			test.Plugins = plugins
+			test.RegisterSession(tiers, nil)
+			defer test.Close()
+			action := New()
+			test.Run([]framework.Action{action})
+			if err := test.CheckAll(i); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func buildJobGroupPodGroup(pg *schedulingv1.PodGroup, group string) *schedulingv1.PodGroup {
+	if pg.Annotations == nil {
+		pg.Annotations = map[string]string{}
+	}
+	pg.Annotations[api.JobGroupAnnotationKey] = group
+	return pg
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preempt
+
+import (
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+// gangCost estimates the resources wasted by evicting a set of tasks with gang-scheduling taken into account.
+// Evicting a task from a job which still has more ready tasks than its minAvailable only wastes the task itself,
+// but evicting the task which brings the job below its minAvailable breaks the whole gang and wastes all ready
+// tasks of the job; once a gang is broken, evicting more of its tasks wastes nothing more.
+type gangCost struct {
+	ssn *framework.Session
+
+	// evicted is the number of tasks of each job which have been counted as evicted
+	evicted map[api.JobID]int32
+	// charged is the resources of each job which have been counted as wasted
+	charged map[api.JobID]*api.Resource
+	// broken records the jobs whose gang has been broken by the counted evictions
+	broken map[api.JobID]bool
+}
+
+func newGangCost(ssn *framework.Session) *gangCost {
+	return &gangCost{
+		ssn:     ssn,
+		evicted: map[api.JobID]int32{},
+		charged: map[api.JobID]*api.Resource{},
+		broken:  map[api.JobID]bool{},
+	}
+}
+
+// marginalCost returns the resources wasted by evicting the task in addition to the already counted evictions.
+func (gc *gangCost) marginalCost(task *api.TaskInfo) *api.Resource {
+	job, found := gc.ssn.Jobs[task.Job]
+	if !found {
+		return task.Resreq.Clone()
+	}
+	if gc.broken[job.UID] {
+		return api.EmptyResource()
+	}
+
+	if job.ReadyTaskNum()-gc.evicted[job.UID]-1 >= job.MinAvailable {
+		return task.Resreq.Clone()
+	}
+
+	// The gang will be broken, all ready tasks of the job are wasted.
+	wasted := api.EmptyResource()
+	for _, t := range job.Tasks {
+		if api.AllocatedStatus(t.Status) || t.Status == api.Succeeded {
+			wasted.Add(t.Resreq)
+		}
+	}
+	if charged, found := gc.charged[job.UID]; found {
+		wasted.SubWithoutAssert(charged)
+	}
+	return wasted
+}
+
+// evict counts the eviction of the task.
+func (gc *gangCost) evict(task *api.TaskInfo) {
+	cost := gc.marginalCost(task)
+
+	job, found := gc.ssn.Jobs[task.Job]
+	if !found {
+		return
+	}
+	if job.ReadyTaskNum()-gc.evicted[job.UID]-1 < job.MinAvailable {
+		gc.broken[job.UID] = true
+	}
+	gc.evicted[job.UID]++
+	if _, found := gc.charged[job.UID]; !found {
+		gc.charged[job.UID] = api.EmptyResource()
+	}
+	gc.charged[job.UID].Add(cost)
+}
+
+// share converts resources into a single value, which is the sum of the shares of each resource dimension
+// in the total resources of the cluster.
+func (gc *gangCost) share(res *api.Resource) float64 {
+	var share float64
+	for _, rn := range res.ResourceNames() {
+		total := gc.ssn.TotalResource.Get(rn)
+		if total <= 0 {
+			continue
+		}
+		share += res.Get(rn) / total
+	}
+	return share
+}
+
+// victimsGangCost returns the total resources wasted by evicting all the victims, as a share of the cluster resources.
+func victimsGangCost(ssn *framework.Session, victims []*api.TaskInfo) float64 {
+	gc := newGangCost(ssn)
+	var cost float64
+	for _, victim := range victims {
+		cost += gc.share(gc.marginalCost(victim))
+		gc.evict(victim)
+	}
+	return cost
+}
+
+// buildGangAwareVictimsQueue reorders the victims popped from victimsQueue greedily, so that the victim wasting
+// the least resources, considering the gangs already broken by the victims before it, goes first. Victims with
+// the same cost keep their order in victimsQueue.
+func buildGangAwareVictimsQueue(ssn *framework.Session, victimsQueue *util.PriorityQueue) *util.PriorityQueue {
+	var remaining []*api.TaskInfo
+	for !victimsQueue.Empty() {
+		remaining = append(remaining, victimsQueue.Pop().(*api.TaskInfo))
+	}
+
+	gc := newGangCost(ssn)
+	victims := make([]*api.TaskInfo, 0, len(remaining))
+	order := make(map[api.TaskID]int, len(remaining))
+	for len(remaining) > 0 {
+		best := 0
+		bestCost := gc.share(gc.marginalCost(remaining[0]))
+		for i := 1; i < len(remaining); i++ {
+			if cost := gc.share(gc.marginalCost(remaining[i])); cost < bestCost {
+				best, bestCost = i, cost
+			}
+		}
+		gc.evict(remaining[best])
+		order[remaining[best].UID] = len(order)
+		victims = append(victims, remaining[best])
+		remaining = append(remaining[:best], remaining[best+1:]...)
+	}
+
+	orderedQueue := util.NewPriorityQueue(func(l, r interface{}) bool {
+		return order[l.(*api.TaskInfo).UID] < order[r.(*api.TaskInfo).UID]
+	})
+	for _, victim := range victims {
+		orderedQueue.Push(victim)
+	}
+	return orderedQueue
+}
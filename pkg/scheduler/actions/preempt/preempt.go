@@ -51,6 +51,12 @@ import (
 const (
 	EnableTopologyAwarePreemptionKey = "enableTopologyAwarePreemption"
 
+	// EnableGangAwarePreemptionKey enables selecting victims by the resources wasted when gangs are broken
+	EnableGangAwarePreemptionKey = "enableGangAwarePreemption"
+
+	// gangCostScale converts the wasted resources share into an integer score
+	gangCostScale = 1e6
+
 	TopologyAwarePreemptWorkerNumKey = "topologyAwarePreemptWorkerNum"
 
 	MinCandidateNodesPercentageKey = "minCandidateNodesPercentage"
@@ -65,6 +71,8 @@ type Action struct {
 
 	enableTopologyAwarePreemption bool
 
+	enableGangAwarePreemption bool
+
 	topologyAwarePreemptWorkerNum int
 	minCandidateNodesPercentage   int
 	minCandidateNodesAbsolute     int
@@ -75,6 +83,7 @@ func New() *Action {
 	return &Action{
 		enablePredicateErrorCache:     true,
 		enableTopologyAwarePreemption: false,
+		enableGangAwarePreemption:     false,
 		topologyAwarePreemptWorkerNum: 16,
 		minCandidateNodesPercentage:   10,
 		minCandidateNodesAbsolute:     1,
@@ -92,6 +101,7 @@ func (pmpt *Action) parseArguments(ssn *framework.Session) {
 	arguments := framework.GetArgOfActionFromConf(ssn.Configurations, pmpt.Name())
 	arguments.GetBool(&pmpt.enablePredicateErrorCache, conf.EnablePredicateErrCacheKey)
 	arguments.GetBool(&pmpt.enableTopologyAwarePreemption, EnableTopologyAwarePreemptionKey)
+	arguments.GetBool(&pmpt.enableGangAwarePreemption, EnableGangAwarePreemptionKey)
 	arguments.GetInt(&pmpt.topologyAwarePreemptWorkerNum, TopologyAwarePreemptWorkerNumKey)
 	arguments.GetInt(&pmpt.minCandidateNodesPercentage, MinCandidateNodesPercentageKey)
 	arguments.GetInt(&pmpt.minCandidateNodesAbsolute, MinCandidateNodesAbsoluteKey)
@@ -339,9 +349,27 @@ func (pmpt *Action) normalPreempt(
 		}
 
 		victimsQueue := ssn.BuildVictimsPriorityQueue(victims, preemptor)
+		// Prefer victims whose eviction wastes the least resources, e.g. tasks of jobs with ready tasks above
+		// minAvailable, or all tasks of a small gang, instead of breaking several gangs.
+		if pmpt.enableGangAwarePreemption {
+			victimsQueue = buildGangAwareVictimsQueue(ssn, victimsQueue)
+		}
 		// Preempt victims for tasks, pick lowest priority task first.
 		preempted := api.EmptyResource()
 
+		// The devices of resource claims are not counted in the resource requests, so the victims are also
+		// removed in a simulated cycle state until the resource claims of preemptor can be allocated on the node.
+		var state *k8sframework.CycleState
+		if len(preemptor.Pod.Spec.ResourceClaims) > 0 {
+			state = ssn.GetCycleState(preemptor.UID).Clone()
+		}
+		fits := func() bool {
+			if !ssn.Allocatable(currentQueue, preemptor) || !preemptor.InitResreq.LessEqual(node.FutureIdle(), api.Zero) {
+				return false
+			}
+			return state == nil || ssn.SimulatePredicateFn(context.TODO(), state, preemptor, node) == nil
+		}
+
 		for !victimsQueue.Empty() {
 			// If reclaimed enough resources, break loop to avoid Sub panic.
 			// Preempt action is about preempt in same queue, which job is not allocatable in allocate action, due to:
@@ -353,7 +381,7 @@ func (pmpt *Action) normalPreempt(
 			// so if current queue is not allocatable(the queue will be overused when consider current preemptor's requests)
 			// or current idle resource is not enough for preemptor, it need to continue preempting
 			// otherwise, break out
-			if ssn.Allocatable(currentQueue, preemptor) && preemptor.InitResreq.LessEqual(node.FutureIdle(), api.Zero) {
+			if fits() {
 				break
 			}
 			preemptee := victimsQueue.Pop().(*api.TaskInfo)
@@ -364,6 +392,11 @@ func (pmpt *Action) normalPreempt(
 					preemptee.Namespace, preemptee.Name, preemptor.Namespace, preemptor.Name, err)
 				continue
 			}
+			if state != nil {
+				if err := ssn.SimulateRemoveTaskFn(context.TODO(), state, preemptor, preemptee, node); err != nil {
+					klog.Errorf("Failed to simulate removing Task <%s/%s> from Node <%s>: %v", preemptee.Namespace, preemptee.Name, node.Name, err)
+				}
+			}
 			preempted.Add(preemptee.Resreq)
 		}
 
@@ -377,7 +410,7 @@ func (pmpt *Action) normalPreempt(
 			preempted, preemptor.Namespace, preemptor.Name, preemptor.InitResreq)
 
 		// If preemptor's queue is not allocatable, it means preemptor cannot be allocated. So no need care about the node idle resource
-		if ssn.Allocatable(currentQueue, preemptor) && preemptor.InitResreq.LessEqual(node.FutureIdle(), api.Zero) {
+		if fits() {
 			if err := stmt.Pipeline(preemptor, node.Name, evictionOccurred); err != nil {
 				klog.Errorf("Failed to pipeline Task <%s/%s> on Node <%s>",
 					preemptor.Namespace, preemptor.Name, node.Name)
@@ -456,7 +489,12 @@ func (pmpt *Action) topologyAwarePreempt(
 	}
 
 	// Find the best candidate.
-	bestCandidate := SelectCandidate(candidates)
+	var bestCandidate *candidate
+	if pmpt.enableGangAwarePreemption {
+		bestCandidate = selectCandidateByGangCost(ssn, candidates)
+	} else {
+		bestCandidate = SelectCandidate(candidates)
+	}
 	if bestCandidate == nil || len(bestCandidate.Name()) == 0 {
 		return false, fmt.Errorf("no candidate node for preemption")
 	}
@@ -847,6 +885,33 @@ func OrderedScoreFuncs(nodesToVictims map[string][]*api.TaskInfo) []func(node st
 	return nil
 }
 
+// selectCandidateByGangCost chooses the candidate whose victims waste the least resources with gang-scheduling
+// taken into account, ties are broken by the default criteria of pickOneNodeForPreemption.
+func selectCandidateByGangCost(ssn *framework.Session, candidates []*candidate) *candidate {
+	if len(candidates) == 0 {
+		return nil
+	}
+	if len(candidates) == 1 {
+		return candidates[0]
+	}
+
+	victimsMap := CandidatesToVictimsMap(candidates)
+	minGangCostScoreFunc := func(node string) int64 {
+		// The less resources wasted, the higher the score.
+		return -int64(victimsGangCost(ssn, victimsMap[node]) * gangCostScale)
+	}
+	scoreFuncs := append([]func(string) int64{minGangCostScoreFunc}, defaultScoreFuncs(victimsMap)...)
+	candidateNode := pickOneNodeForPreemption(victimsMap, scoreFuncs)
+	if victims := victimsMap[candidateNode]; victims != nil {
+		return &candidate{
+			victims: victims,
+			name:    candidateNode,
+		}
+	}
+
+	return candidates[0]
+}
+
 // pickOneNodeForPreemption chooses one node among the given nodes.
 // It assumes pods in each map entry are ordered by decreasing priority.
 // If the scoreFuncs is not empty, It picks a node based on score scoreFuncs returns.
@@ -871,53 +936,7 @@ func pickOneNodeForPreemption(nodesToVictims map[string][]*api.TaskInfo, scoreFu
 	}
 
 	if len(scoreFuncs) == 0 {
-		minHighestPriorityScoreFunc := func(node string) int64 {
-			// highestPodPriority is the highest priority among the victims on this node.
-			highestPodPriority := PodPriority(nodesToVictims[node][0].Pod)
-			// The smaller the highestPodPriority, the higher the score.
-			return -int64(highestPodPriority)
-		}
-		minSumPrioritiesScoreFunc := func(node string) int64 {
-			var sumPriorities int64
-			for _, task := range nodesToVictims[node] {
-				// We add MaxInt32+1 to all priorities to make all of them >= 0. This is
-				// needed so that a node with a few pods with negative priority is not
-				// picked over a node with a smaller number of pods with the same negative
-				// priority (and similar scenarios).
-				sumPriorities += int64(PodPriority(task.Pod)) + int64(math.MaxInt32+1)
-			}
-			// The smaller the sumPriorities, the higher the score.
-			return -sumPriorities
-		}
-		minNumPodsScoreFunc := func(node string) int64 {
-			// The smaller the length of pods, the higher the score.
-			return -int64(len(nodesToVictims[node]))
-		}
-		latestStartTimeScoreFunc := func(node string) int64 {
-			// Get the earliest start time of all pods on the current node.
-			earliestStartTimeOnNode := GetEarliestPodStartTime(nodesToVictims[node])
-			if earliestStartTimeOnNode == nil {
-				klog.Error(errors.New("earliestStartTime is nil for node"), "Should not reach here", "node", node)
-				return int64(math.MinInt64)
-			}
-			// The bigger the earliestStartTimeOnNode, the higher the score.
-			return earliestStartTimeOnNode.UnixNano()
-		}
-
-		// Each scoreFunc scores the nodes according to specific rules and keeps the name of the node
-		// with the highest score. If and only if the scoreFunc has more than one node with the highest
-		// score, we will execute the other scoreFunc in order of precedence.
-		scoreFuncs = []func(string) int64{
-			// A node with a minimum highest priority victim is preferable.
-			minHighestPriorityScoreFunc,
-			// A node with the smallest sum of priorities is preferable.
-			minSumPrioritiesScoreFunc,
-			// A node with the minimum number of pods is preferable.
-			minNumPodsScoreFunc,
-			// A node with the latest start time of all highest priority victims is preferable.
-			latestStartTimeScoreFunc,
-			// If there are still ties, then the first Node in the list is selected.
-		}
+		scoreFuncs = defaultScoreFuncs(nodesToVictims)
 	}
 
 	for _, f := range scoreFuncs {
@@ -942,6 +961,57 @@ func pickOneNodeForPreemption(nodesToVictims map[string][]*api.TaskInfo, scoreFu
 	return allCandidates[0]
 }
 
+// defaultScoreFuncs returns the default criteria used by pickOneNodeForPreemption, in order of precedence.
+func defaultScoreFuncs(nodesToVictims map[string][]*api.TaskInfo) []func(node string) int64 {
+	minHighestPriorityScoreFunc := func(node string) int64 {
+		// highestPodPriority is the highest priority among the victims on this node.
+		highestPodPriority := PodPriority(nodesToVictims[node][0].Pod)
+		// The smaller the highestPodPriority, the higher the score.
+		return -int64(highestPodPriority)
+	}
+	minSumPrioritiesScoreFunc := func(node string) int64 {
+		var sumPriorities int64
+		for _, task := range nodesToVictims[node] {
+			// We add MaxInt32+1 to all priorities to make all of them >= 0. This is
+			// needed so that a node with a few pods with negative priority is not
+			// picked over a node with a smaller number of pods with the same negative
+			// priority (and similar scenarios).
+			sumPriorities += int64(PodPriority(task.Pod)) + int64(math.MaxInt32+1)
+		}
+		// The smaller the sumPriorities, the higher the score.
+		return -sumPriorities
+	}
+	minNumPodsScoreFunc := func(node string) int64 {
+		// The smaller the length of pods, the higher the score.
+		return -int64(len(nodesToVictims[node]))
+	}
+	latestStartTimeScoreFunc := func(node string) int64 {
+		// Get the earliest start time of all pods on the current node.
+		earliestStartTimeOnNode := GetEarliestPodStartTime(nodesToVictims[node])
+		if earliestStartTimeOnNode == nil {
+			klog.Error(errors.New("earliestStartTime is nil for node"), "Should not reach here", "node", node)
+			return int64(math.MinInt64)
+		}
+		// The bigger the earliestStartTimeOnNode, the higher the score.
+		return earliestStartTimeOnNode.UnixNano()
+	}
+
+	// Each scoreFunc scores the nodes according to specific rules and keeps the name of the node
+	// with the highest score. If and only if the scoreFunc has more than one node with the highest
+	// score, we will execute the other scoreFunc in order of precedence.
+	return []func(string) int64{
+		// A node with a minimum highest priority victim is preferable.
+		minHighestPriorityScoreFunc,
+		// A node with the smallest sum of priorities is preferable.
+		minSumPrioritiesScoreFunc,
+		// A node with the minimum number of pods is preferable.
+		minNumPodsScoreFunc,
+		// A node with the latest start time of all highest priority victims is preferable.
+		latestStartTimeScoreFunc,
+		// If there are still ties, then the first Node in the list is selected.
+	}
+}
+
 // GetEarliestPodStartTime returns the earliest start time of all pods that
 // have the highest priority among all victims.
 func GetEarliestPodStartTime(tasks []*api.TaskInfo) *metav1.Time {
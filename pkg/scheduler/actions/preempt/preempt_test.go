@@ -23,12 +23,19 @@ package preempt
 
 import (
 	"flag"
+	"fmt"
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
+	resourcev1beta1 "k8s.io/api/resource/v1beta1"
 	schedulingv1 "k8s.io/api/scheduling/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
 	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/features"
+	"k8s.io/utils/ptr"
 
 	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 	"volcano.sh/volcano/cmd/scheduler/app/options"
@@ -629,3 +636,263 @@ func buildPodWithPodAntiAffinity(name, namespace, node string, phase v1.PodPhase
 
 	return pod
 }
+
+func TestGangAwarePreempt(t *testing.T) {
+	plugins := map[string]framework.PluginBuilder{
+		conformance.PluginName: conformance.New,
+		gang.PluginName:        gang.New,
+		priority.PluginName:    priority.New,
+		proportion.PluginName:  proportion.New,
+	}
+	highPrio := util.BuildPriorityClass("high-priority", 100000)
+	lowPrio := util.BuildPriorityClass("low-priority", 10)
+
+	tests := []uthelper.TestCommonStruct{
+		{
+			Name: "preempt task of job with ready tasks above min available instead of breaking a gang",
+			PodGroups: []*schedulingv1beta1.PodGroup{
+				util.BuildPodGroupWithPrio("pg1", "c1", "q1", 2, nil, schedulingv1beta1.PodGroupRunning, "low-priority"),
+				util.BuildPodGroupWithPrio("pg2", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning, "low-priority"),
+				util.BuildPodGroupWithPrio("pg3", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue, "high-priority"),
+			},
+			Pods: []*v1.Pod{
+				util.BuildPod("c1", "preemptee1", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", map[string]string{schedulingv1beta1.PodPreemptable: "true"}, make(map[string]string)),
+				util.BuildPod("c1", "preemptee2", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", map[string]string{schedulingv1beta1.PodPreemptable: "true"}, make(map[string]string)),
+				util.BuildPod("c1", "preemptee3", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg2", map[string]string{schedulingv1beta1.PodPreemptable: "true"}, make(map[string]string)),
+				util.BuildPod("c1", "preemptee4", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg2", map[string]string{schedulingv1beta1.PodPreemptable: "false"}, make(map[string]string)),
+				util.BuildPod("c1", "preemptor1", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg3", make(map[string]string), make(map[string]string)),
+			},
+			Nodes: []*v1.Node{
+				util.BuildNode("n1", api.BuildResourceList("4", "4G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
+			},
+			Queues: []*schedulingv1beta1.Queue{
+				util.BuildQueue("q1", 1, nil),
+			},
+			ExpectEvicted:  []string{"c1/preemptee3"},
+			ExpectEvictNum: 1,
+		},
+	}
+
+	trueValue := true
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:               conformance.PluginName,
+					EnabledPreemptable: &trueValue,
+				},
+				{
+					Name:                gang.PluginName,
+					EnabledJobPipelined: &trueValue,
+					EnabledJobStarving:  &trueValue,
+				},
+				{
+					Name:                priority.PluginName,
+					EnabledTaskOrder:    &trueValue,
+					EnabledJobOrder:     &trueValue,
+					EnabledPreemptable:  &trueValue,
+					EnabledJobPipelined: &trueValue,
+					EnabledJobStarving:  &trueValue,
+				},
+				{
+					Name:               proportion.PluginName,
+					EnabledOverused:    &trueValue,
+					EnabledAllocatable: &trueValue,
+					EnabledQueueOrder:  &trueValue,
+				},
+			},
+		}}
+
+	actions := []framework.Action{New()}
+	for i, test := range tests {
+		test.Plugins = plugins
+		test.PriClass = []*schedulingv1.PriorityClass{highPrio, lowPrio}
+		t.Run(test.Name, func(t *testing.T) {
+			test.RegisterSession(tiers, []conf.Configuration{{Name: actions[0].Name(),
+				Arguments: map[string]interface{}{EnableGangAwarePreemptionKey: true}}})
+			defer test.Close()
+			test.Run(actions)
+			if err := test.CheckAll(i); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestPreemptWithDRA(t *testing.T) {
+	featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, features.DynamicResourceAllocation, true)
+
+	plugins := map[string]framework.PluginBuilder{
+		conformance.PluginName: conformance.New,
+		gang.PluginName:        gang.New,
+		priority.PluginName:    priority.New,
+		proportion.PluginName:  proportion.New,
+		predicates.PluginName:  predicates.New,
+	}
+	highPrio := util.BuildPriorityClass("high-priority", 100000)
+	lowPrio := util.BuildPriorityClass("low-priority", 10)
+
+	newTest := func() uthelper.TestCommonStruct {
+		return uthelper.TestCommonStruct{
+			ResourceClaims: []*resourcev1beta1.ResourceClaim{
+				buildAllocatedResourceClaim("c1", "claim-low", "gpu-1", "c1-preemptee1"),
+				util.BuildResourceClaim("c1", "claim-high",
+					[]resourcev1beta1.DeviceRequest{util.BuildDeviceRequest("gpu", "gpu.example.com", nil, nil, nil)}, nil, nil),
+			},
+			ResourceSlices: []*resourcev1beta1.ResourceSlice{
+				util.BuildResourceSlice("n1-slice1", "gpu.example.com", "n1", resourcev1beta1.ResourcePool{Name: "gpu-worker", Generation: 1, ResourceSliceCount: 1},
+					[]resourcev1beta1.Device{util.BuildDevice("gpu-1", nil, nil)}),
+			},
+			DeviceClasses: []*resourcev1beta1.DeviceClass{
+				util.BuildDeviceClass("gpu.example.com", []resourcev1beta1.DeviceSelector{
+					{CEL: &resourcev1beta1.CELDeviceSelector{Expression: `device.driver == 'gpu.example.com'`}},
+				}, nil),
+			},
+			PodGroups: []*schedulingv1beta1.PodGroup{
+				util.BuildPodGroupWithPrio("pg1", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning, "low-priority"),
+				util.BuildPodGroupWithPrio("pg2", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue, "high-priority"),
+			},
+			// There are enough idle cpu and memory on the node, but the only device is claimed by preemptee1.
+			Pods: []*v1.Pod{
+				util.BuildPodWithResourceClaim("c1", "preemptee1", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", map[string]string{schedulingv1beta1.PodPreemptable: "true"}, make(map[string]string),
+					[]v1.ResourceClaim{{Name: "gpu"}}, []v1.PodResourceClaim{{Name: "gpu", ResourceClaimName: ptr.To("claim-low")}}),
+				util.BuildPodWithResourceClaim("c1", "preemptor1", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg2", make(map[string]string), make(map[string]string),
+					[]v1.ResourceClaim{{Name: "gpu"}}, []v1.PodResourceClaim{{Name: "gpu", ResourceClaimName: ptr.To("claim-high")}}),
+			},
+			Nodes: []*v1.Node{
+				util.BuildNode("n1", api.BuildResourceList("4", "4G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
+			},
+			Queues: []*schedulingv1beta1.Queue{
+				util.BuildQueue("q1", 1, nil),
+			},
+			ExpectEvicted:  []string{"c1/preemptee1"},
+			ExpectEvictNum: 1,
+		}
+	}
+
+	trueValue := true
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:               conformance.PluginName,
+					EnabledPreemptable: &trueValue,
+				},
+				{
+					Name:                gang.PluginName,
+					EnabledJobPipelined: &trueValue,
+					EnabledJobStarving:  &trueValue,
+				},
+				{
+					Name:                priority.PluginName,
+					EnabledTaskOrder:    &trueValue,
+					EnabledJobOrder:     &trueValue,
+					EnabledPreemptable:  &trueValue,
+					EnabledJobPipelined: &trueValue,
+					EnabledJobStarving:  &trueValue,
+				},
+				{
+					Name:               proportion.PluginName,
+					EnabledOverused:    &trueValue,
+					EnabledAllocatable: &trueValue,
+					EnabledQueueOrder:  &trueValue,
+					EnabledPredicate:   &trueValue,
+				},
+				{
+					Name:               predicates.PluginName,
+					EnabledPreemptable: &trueValue,
+					EnabledPredicate:   &trueValue,
+					Arguments: framework.Arguments{
+						predicates.DynamicResourceAllocationEnable: trueValue,
+					},
+				},
+			},
+		}}
+
+	for i, topologyAware := range []bool{false, true} {
+		test := newTest()
+		test.Name = fmt.Sprintf("preempt the task holding the claimed device, topology aware preemption: %v", topologyAware)
+		test.Plugins = plugins
+		test.PriClass = []*schedulingv1.PriorityClass{highPrio, lowPrio}
+		t.Run(test.Name, func(t *testing.T) {
+			actions := []framework.Action{New()}
+			test.RegisterSession(tiers, []conf.Configuration{{Name: actions[0].Name(),
+				Arguments: map[string]interface{}{EnableTopologyAwarePreemptionKey: topologyAware}}})
+			defer test.Close()
+			test.Run(actions)
+			if err := test.CheckAll(i); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// buildAllocatedResourceClaim builds a resource claim allocated with the device and reserved for the pod
+func buildAllocatedResourceClaim(namespace, name, device string, podUID types.UID) *resourcev1beta1.ResourceClaim {
+	claim := util.BuildResourceClaim(namespace, name,
+		[]resourcev1beta1.DeviceRequest{util.BuildDeviceRequest("gpu", "gpu.example.com", nil, nil, nil)}, nil, nil)
+	claim.Status = resourcev1beta1.ResourceClaimStatus{
+		Allocation: &resourcev1beta1.AllocationResult{
+			Devices: resourcev1beta1.DeviceAllocationResult{
+				Results: []resourcev1beta1.DeviceRequestAllocationResult{
+					{Request: "gpu", Driver: "gpu.example.com", Pool: "gpu-worker", Device: device},
+				},
+			},
+		},
+		ReservedFor: []resourcev1beta1.ResourceClaimConsumerReference{
+			{Resource: "pods", Name: "preemptee1", UID: podUID},
+		},
+	}
+	return claim
+}
+
+func TestSelectCandidateByGangCost(t *testing.T) {
+	test := uthelper.TestCommonStruct{
+		Name:    "select candidate which does not break gang",
+		Plugins: map[string]framework.PluginBuilder{gang.PluginName: gang.New},
+		PodGroups: []*schedulingv1beta1.PodGroup{
+			util.BuildPodGroup("pg1", "c1", "q1", 2, nil, schedulingv1beta1.PodGroupRunning),
+			util.BuildPodGroup("pg2", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning),
+		},
+		Pods: []*v1.Pod{
+			util.BuildPod("c1", "p1", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", make(map[string]string), make(map[string]string)),
+			util.BuildPod("c1", "p2", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", make(map[string]string), make(map[string]string)),
+			util.BuildPod("c1", "p3", "n2", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg2", make(map[string]string), make(map[string]string)),
+			util.BuildPod("c1", "p4", "n2", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg2", make(map[string]string), make(map[string]string)),
+		},
+		Nodes: []*v1.Node{
+			util.BuildNode("n1", api.BuildResourceList("2", "2G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
+			util.BuildNode("n2", api.BuildResourceList("2", "2G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
+		},
+		Queues: []*schedulingv1beta1.Queue{
+			util.BuildQueue("q1", 1, nil),
+		},
+	}
+	ssn := test.RegisterSession(nil, nil)
+	defer test.Close()
+
+	taskOf := func(name string) *api.TaskInfo {
+		for _, job := range ssn.Jobs {
+			for _, task := range job.Tasks {
+				if task.Name == name {
+					return task
+				}
+			}
+		}
+		t.Fatalf("task %s not found", name)
+		return nil
+	}
+	candidates := []*candidate{
+		{name: "n1", victims: []*api.TaskInfo{taskOf("p1")}},
+		{name: "n2", victims: []*api.TaskInfo{taskOf("p3")}},
+	}
+	if c := selectCandidateByGangCost(ssn, candidates); c == nil || c.Name() != "n2" {
+		t.Errorf("expect candidate n2, but got %v", c)
+	}
+
+	// Evicting the whole small gang wastes as much as evicting one task of it.
+	if whole, one := victimsGangCost(ssn, []*api.TaskInfo{taskOf("p1"), taskOf("p2")}),
+		victimsGangCost(ssn, []*api.TaskInfo{taskOf("p1")}); whole != one {
+		t.Errorf("expect the same cost for evicting one task and the whole gang, but got %v and %v", one, whole)
+	}
+}
@@ -23,8 +23,11 @@ limitations under the License.
 package reclaim
 
 import (
+	"context"
+
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
+	k8sframework "k8s.io/kubernetes/pkg/scheduler/framework"
 
 	"volcano.sh/volcano/pkg/scheduler/api"
 	"volcano.sh/volcano/pkg/scheduler/framework"
@@ -193,6 +196,16 @@ func (ra *Action) Execute(ssn *framework.Session) {
 			resreq := task.InitResreq.Clone()
 			reclaimed := api.EmptyResource()
 
+			// The devices of resource claims are not counted in the resource requests, so the victims are also
+			// removed in a simulated cycle state until the resource claims of task can be allocated on the node.
+			var state *k8sframework.CycleState
+			if len(task.Pod.Spec.ResourceClaims) > 0 {
+				state = ssn.GetCycleState(task.UID).Clone()
+			}
+			claimsFit := func() bool {
+				return state == nil || ssn.SimulatePredicateFn(context.TODO(), state, task, n) == nil
+			}
+
 			// Reclaim victims for tasks.
 			for !victimsQueue.Empty() {
 				reclaimee := victimsQueue.Pop().(*api.TaskInfo)
@@ -203,9 +216,14 @@ func (ra *Action) Execute(ssn *framework.Session) {
 						reclaimee.Namespace, reclaimee.Name, task.Namespace, task.Name, err)
 					continue
 				}
+				if state != nil {
+					if err := ssn.SimulateRemoveTaskFn(context.TODO(), state, task, reclaimee, n); err != nil {
+						klog.Errorf("Failed to simulate removing Task <%s/%s> from Node <%s>: %v", reclaimee.Namespace, reclaimee.Name, n.Name, err)
+					}
+				}
 				reclaimed.Add(reclaimee.Resreq)
 				// If reclaimed enough resources, break loop to avoid Sub panic.
-				if resreq.LessEqual(reclaimed, api.Zero) {
+				if resreq.LessEqual(reclaimed, api.Zero) && claimsFit() {
 					break
 				}
 			}
@@ -213,7 +231,7 @@ func (ra *Action) Execute(ssn *framework.Session) {
 			klog.V(3).Infof("Reclaimed <%v> for task <%s/%s> requested <%v>.",
 				reclaimed, task.Namespace, task.Name, task.InitResreq)
 
-			if task.InitResreq.LessEqual(reclaimed, api.Zero) {
+			if task.InitResreq.LessEqual(reclaimed, api.Zero) && claimsFit() {
 				if err := ssn.Pipeline(task, n.Name); err != nil {
 					klog.Errorf("Failed to pipeline Task <%s/%s> on Node <%s>",
 						task.Namespace, task.Name, n.Name)
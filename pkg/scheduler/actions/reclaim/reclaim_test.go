@@ -24,15 +24,22 @@ import (
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
+	resourcev1beta1 "k8s.io/api/resource/v1beta1"
 	schedulingv1 "k8s.io/api/scheduling/v1"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
+	"k8s.io/kubernetes/pkg/features"
+	"k8s.io/utils/ptr"
 
 	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/cmd/scheduler/app/options"
 	"volcano.sh/volcano/pkg/scheduler/api"
 	"volcano.sh/volcano/pkg/scheduler/conf"
 	"volcano.sh/volcano/pkg/scheduler/framework"
 	"volcano.sh/volcano/pkg/scheduler/plugins/capacity"
 	"volcano.sh/volcano/pkg/scheduler/plugins/conformance"
 	"volcano.sh/volcano/pkg/scheduler/plugins/gang"
+	"volcano.sh/volcano/pkg/scheduler/plugins/predicates"
 	"volcano.sh/volcano/pkg/scheduler/plugins/priority"
 	"volcano.sh/volcano/pkg/scheduler/plugins/proportion"
 	"volcano.sh/volcano/pkg/scheduler/uthelper"
@@ -265,3 +272,107 @@ func TestReclaim(t *testing.T) {
 		})
 	}
 }
+
+func TestReclaimWithDRA(t *testing.T) {
+	options.Default()
+	featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, features.DynamicResourceAllocation, true)
+
+	claimLow := util.BuildResourceClaim("c1", "claim-low",
+		[]resourcev1beta1.DeviceRequest{util.BuildDeviceRequest("gpu", "gpu.example.com", nil, nil, nil)}, nil, nil)
+	claimLow.Status = resourcev1beta1.ResourceClaimStatus{
+		Allocation: &resourcev1beta1.AllocationResult{
+			Devices: resourcev1beta1.DeviceAllocationResult{
+				Results: []resourcev1beta1.DeviceRequestAllocationResult{
+					{Request: "gpu", Driver: "gpu.example.com", Pool: "gpu-worker", Device: "gpu-1"},
+				},
+			},
+		},
+		ReservedFor: []resourcev1beta1.ResourceClaimConsumerReference{
+			{Resource: "pods", Name: "preemptee1", UID: "c1-preemptee1"},
+		},
+	}
+
+	test := uthelper.TestCommonStruct{
+		Name: "reclaim the task holding the claimed device from overusing queue",
+		Plugins: map[string]framework.PluginBuilder{
+			conformance.PluginName: conformance.New,
+			gang.PluginName:        gang.New,
+			proportion.PluginName:  proportion.New,
+			predicates.PluginName:  predicates.New,
+		},
+		ResourceClaims: []*resourcev1beta1.ResourceClaim{
+			claimLow,
+			util.BuildResourceClaim("c1", "claim-high",
+				[]resourcev1beta1.DeviceRequest{util.BuildDeviceRequest("gpu", "gpu.example.com", nil, nil, nil)}, nil, nil),
+		},
+		ResourceSlices: []*resourcev1beta1.ResourceSlice{
+			util.BuildResourceSlice("n1-slice1", "gpu.example.com", "n1", resourcev1beta1.ResourcePool{Name: "gpu-worker", Generation: 1, ResourceSliceCount: 1},
+				[]resourcev1beta1.Device{util.BuildDevice("gpu-1", nil, nil)}),
+		},
+		DeviceClasses: []*resourcev1beta1.DeviceClass{
+			util.BuildDeviceClass("gpu.example.com", []resourcev1beta1.DeviceSelector{
+				{CEL: &resourcev1beta1.CELDeviceSelector{Expression: `device.driver == 'gpu.example.com'`}},
+			}, nil),
+		},
+		PodGroups: []*schedulingv1beta1.PodGroup{
+			util.BuildPodGroup("pg1", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning),
+			util.BuildPodGroup("pg2", "c1", "q2", 1, nil, schedulingv1beta1.PodGroupInqueue),
+		},
+		// The device on the node is claimed by preemptee1, the preemptor can only be pipelined once it is reclaimed.
+		Pods: []*v1.Pod{
+			util.BuildPodWithResourceClaim("c1", "preemptee1", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", map[string]string{schedulingv1beta1.PodPreemptable: "true"}, make(map[string]string),
+				[]v1.ResourceClaim{{Name: "gpu"}}, []v1.PodResourceClaim{{Name: "gpu", ResourceClaimName: ptr.To("claim-low")}}),
+			util.BuildPod("c1", "preemptee2", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", map[string]string{schedulingv1beta1.PodPreemptable: "false"}, make(map[string]string)),
+			util.BuildPodWithResourceClaim("c1", "preemptor1", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg2", make(map[string]string), make(map[string]string),
+				[]v1.ResourceClaim{{Name: "gpu"}}, []v1.PodResourceClaim{{Name: "gpu", ResourceClaimName: ptr.To("claim-high")}}),
+		},
+		Nodes: []*v1.Node{
+			util.BuildNode("n1", api.BuildResourceList("2", "2Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
+		},
+		Queues: []*schedulingv1beta1.Queue{
+			util.BuildQueue("q1", 1, nil),
+			util.BuildQueue("q2", 1, nil),
+		},
+		ExpectPipeLined: map[string][]string{"c1/pg2": {"n1"}},
+		ExpectEvicted:   []string{"c1/preemptee1"},
+		ExpectEvictNum:  1,
+	}
+
+	trueValue := true
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:               conformance.PluginName,
+					EnabledReclaimable: &trueValue,
+				},
+				{
+					Name:               gang.PluginName,
+					EnabledReclaimable: &trueValue,
+					EnabledJobStarving: &trueValue,
+				},
+				{
+					Name:               proportion.PluginName,
+					EnabledReclaimable: &trueValue,
+					EnabledQueueOrder:  &trueValue,
+					EnablePreemptive:   &trueValue,
+				},
+				{
+					Name:               predicates.PluginName,
+					EnabledPredicate:   &trueValue,
+					EnabledPreemptable: &trueValue,
+					Arguments: framework.Arguments{
+						predicates.DynamicResourceAllocationEnable: trueValue,
+					},
+				},
+			},
+		},
+	}
+
+	test.RegisterSession(tiers, nil)
+	defer test.Close()
+	test.Run([]framework.Action{New()})
+	if err := test.CheckAll(0); err != nil {
+		t.Fatal(err)
+	}
+}
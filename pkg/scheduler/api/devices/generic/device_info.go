@@ -0,0 +1,353 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/api/devices"
+)
+
+// SharedDevices is the shared devices of a node published in the node annotation, which can be
+// GPUs, NPUs or any other accelerators of any vendor.
+type SharedDevices struct {
+	Name string
+
+	// Devices is the devices of each resource
+	Devices map[string][]*Device
+}
+
+// make sure SharedDevices implements Devices interface
+var _ api.Devices = new(SharedDevices)
+
+func init() {
+	api.RegisterDevice(DeviceName, func(nodeName string, node *v1.Node) api.Devices {
+		return NewSharedDevices(nodeName, node)
+	}, nil)
+}
+
+// NewSharedDevices creates the shared devices of the node, nil is returned if no device is published.
+// All published devices are tracked, and only those of EnabledResources are scheduled.
+func NewSharedDevices(name string, node *v1.Node) *SharedDevices {
+	if node == nil {
+		return nil
+	}
+
+	devs, err := parseSharedDevices(node)
+	if err != nil {
+		klog.Errorf("Failed to parse shared devices of node %s: %v", name, err)
+		return nil
+	}
+	if len(devs) == 0 {
+		return nil
+	}
+
+	return &SharedDevices{
+		Name:    name,
+		Devices: devs,
+	}
+}
+
+// GetIgnoredDevices return the memory and cores resources which are not published in node allocatable
+func (sd *SharedDevices) GetIgnoredDevices() []string {
+	resourceNames := sets.New[string](EnabledResources...)
+	if sd != nil {
+		for resourceName := range sd.Devices {
+			resourceNames.Insert(resourceName)
+		}
+	}
+
+	var ignored []string
+	for _, resourceName := range sets.List(resourceNames) {
+		ignored = append(ignored, resourceName+MemoryResourceSuffix, resourceName+CoresResourceSuffix)
+	}
+	return ignored
+}
+
+// AddResource adds the pod to the devices it is assigned
+func (sd *SharedDevices) AddResource(pod *v1.Pod) {
+	if sd == nil {
+		return
+	}
+
+	for resourceName := range sd.Devices {
+		usages, err := assignedDevicesOfPod(pod, resourceName)
+		if err != nil {
+			klog.Errorf("Failed to parse assigned %s of pod %s/%s: %v", resourceName, pod.Namespace, pod.Name, err)
+			continue
+		}
+		sd.addUsages(resourceName, string(pod.UID), usages)
+	}
+}
+
+// SubResource frees the devices hold by the pod
+func (sd *SharedDevices) SubResource(pod *v1.Pod) {
+	if sd == nil {
+		return
+	}
+
+	for resourceName := range sd.Devices {
+		sd.subUsages(resourceName, string(pod.UID))
+	}
+}
+
+func (sd *SharedDevices) addUsages(resourceName, podUID string, usages []DeviceUsage) {
+	for _, usage := range usages {
+		for _, dev := range sd.Devices[resourceName] {
+			if dev.ID != usage.ID {
+				continue
+			}
+			if _, found := dev.PodMap[podUID]; found {
+				continue
+			}
+			dev.PodMap[podUID] = usage
+			dev.UsedMemory += usage.Memory
+			dev.UsedCores += usage.Cores
+		}
+	}
+}
+
+func (sd *SharedDevices) subUsages(resourceName, podUID string) {
+	for _, dev := range sd.Devices[resourceName] {
+		usage, found := dev.PodMap[podUID]
+		if !found {
+			continue
+		}
+		delete(dev.PodMap, podUID)
+		dev.UsedMemory -= min(usage.Memory, dev.UsedMemory)
+		dev.UsedCores -= min(usage.Cores, dev.UsedCores)
+	}
+}
+
+// HasDeviceRequest checks if the pod requests any of the enabled resources
+func (sd *SharedDevices) HasDeviceRequest(pod *v1.Pod) bool {
+	for _, resourceName := range EnabledResources {
+		if requestOfPod(pod, resourceName).num > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// fitDevices returns the devices of the resource to place the request in the order of the policy.
+func (sd *SharedDevices) fitDevices(resourceName string, req deviceRequest, policy string) ([]DeviceUsage, error) {
+	var candidates []*Device
+	for _, dev := range sd.Devices[resourceName] {
+		if req.exclusive() {
+			if len(dev.PodMap) == 0 {
+				candidates = append(candidates, dev)
+			}
+			continue
+		}
+		if dev.UsedMemory+req.memory <= dev.Memory && dev.UsedCores+req.cores <= dev.Cores {
+			candidates = append(candidates, dev)
+		}
+	}
+	if len(candidates) < req.num {
+		return nil, fmt.Errorf("not enough %s on node %s, requested: %d, fit: %d",
+			resourceName, sd.Name, req.num, len(candidates))
+	}
+
+	switch policy {
+	case binpackPolicy:
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return usageRatio(candidates[i]) > usageRatio(candidates[j])
+		})
+	case spreadPolicy:
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return usageRatio(candidates[i]) < usageRatio(candidates[j])
+		})
+	}
+
+	usages := make([]DeviceUsage, 0, req.num)
+	for _, dev := range candidates[:req.num] {
+		usage := DeviceUsage{ID: dev.ID, Memory: req.memory, Cores: req.cores}
+		if req.exclusive() {
+			usage.Memory, usage.Cores = dev.Memory, dev.Cores
+		}
+		usages = append(usages, usage)
+	}
+	return usages, nil
+}
+
+// usageRatio returns the ratio of the used memory, or used cores if no memory is published, of the device
+func usageRatio(dev *Device) float64 {
+	if dev.Memory > 0 {
+		return float64(dev.UsedMemory) / float64(dev.Memory)
+	}
+	if dev.Cores > 0 {
+		return float64(dev.UsedCores) / float64(dev.Cores)
+	}
+	if len(dev.PodMap) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// FilterNode checks if the pod fits in the devices of the node
+func (sd *SharedDevices) FilterNode(pod *v1.Pod, schedulePolicy string) (int, string, error) {
+	for _, resourceName := range EnabledResources {
+		req := requestOfPod(pod, resourceName)
+		if req.num == 0 {
+			continue
+		}
+		if _, err := sd.fitDevices(resourceName, req, schedulePolicy); err != nil {
+			return devices.Unschedulable, fmt.Sprintf("%s %s", DeviceName, err.Error()), err
+		}
+	}
+	return devices.Success, "", nil
+}
+
+// ScoreNode scores the node by the usage of the devices after placing the pod, the higher usage the better in
+// binpack policy and the lower usage the better in spread policy
+func (sd *SharedDevices) ScoreNode(pod *v1.Pod, schedulePolicy string) float64 {
+	if schedulePolicy != binpackPolicy && schedulePolicy != spreadPolicy {
+		return 0
+	}
+
+	var ratio float64
+	var count int
+	for _, resourceName := range EnabledResources {
+		req := requestOfPod(pod, resourceName)
+		if req.num == 0 {
+			continue
+		}
+		usages, err := sd.fitDevices(resourceName, req, schedulePolicy)
+		if err != nil {
+			return 0
+		}
+		for _, usage := range usages {
+			for _, dev := range sd.Devices[resourceName] {
+				if dev.ID != usage.ID {
+					continue
+				}
+				ratio += usageRatio(&Device{
+					Memory:     dev.Memory,
+					Cores:      dev.Cores,
+					UsedMemory: dev.UsedMemory + usage.Memory,
+					UsedCores:  dev.UsedCores + usage.Cores,
+					PodMap:     map[string]DeviceUsage{"": usage},
+				})
+				count++
+			}
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+
+	ratio /= float64(count)
+	if schedulePolicy == spreadPolicy {
+		ratio = 1 - ratio
+	}
+	return ratio * scoreMultiplier
+}
+
+// Allocate assigns the devices to the pod and records them in the pod annotation
+func (sd *SharedDevices) Allocate(kubeClient kubernetes.Interface, pod *v1.Pod) error {
+	if sd == nil {
+		return fmt.Errorf("no %s published on the node of pod %s/%s", DeviceName, pod.Namespace, pod.Name)
+	}
+
+	annotations := map[string]interface{}{}
+	assigned := map[string][]DeviceUsage{}
+	for _, resourceName := range EnabledResources {
+		req := requestOfPod(pod, resourceName)
+		if req.num == 0 {
+			continue
+		}
+		usages, err := sd.fitDevices(resourceName, req, SchedulePolicy)
+		if err != nil {
+			return err
+		}
+		value, err := json.Marshal(usages)
+		if err != nil {
+			return err
+		}
+		annotations[AssignedDevicesAnnotation(resourceName)] = string(value)
+		assigned[resourceName] = usages
+	}
+
+	if err := patchPodAnnotations(kubeClient, pod, annotations); err != nil {
+		return err
+	}
+	for resourceName, usages := range assigned {
+		sd.addUsages(resourceName, string(pod.UID), usages)
+	}
+
+	klog.V(4).Infof("predicates with %s, update pod %s/%s allocate to node [%s]", DeviceName, pod.Namespace, pod.Name, sd.Name)
+	return nil
+}
+
+// Release frees the devices assigned to the pod and removes them from the pod annotation
+func (sd *SharedDevices) Release(kubeClient kubernetes.Interface, pod *v1.Pod) error {
+	if sd == nil {
+		return nil
+	}
+
+	annotations := map[string]interface{}{}
+	for resourceName := range sd.Devices {
+		if _, found := pod.Annotations[AssignedDevicesAnnotation(resourceName)]; found {
+			annotations[AssignedDevicesAnnotation(resourceName)] = nil
+		}
+	}
+
+	if len(annotations) > 0 {
+		if err := patchPodAnnotations(kubeClient, pod, annotations); err != nil {
+			return err
+		}
+	}
+	for resourceName := range sd.Devices {
+		sd.subUsages(resourceName, string(pod.UID))
+	}
+
+	klog.V(4).Infof("predicates with %s, update pod %s/%s deallocate from node [%s]", DeviceName, pod.Namespace, pod.Name, sd.Name)
+	return nil
+}
+
+func patchPodAnnotations(kubeClient kubernetes.Interface, pod *v1.Pod, annotations map[string]interface{}) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": annotations,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = kubeClient.CoreV1().Pods(pod.Namespace).Patch(context.TODO(), pod.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("patch pod %s/%s failed with patch %s: %v", pod.Namespace, pod.Name, patch, err)
+	}
+	return nil
+}
+
+// GetStatus used for debug and monitor
+func (sd *SharedDevices) GetStatus() string {
+	return ""
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generic
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"volcano.sh/volcano/pkg/scheduler/api/devices"
+)
+
+const npuResource = "huawei.com/npu"
+
+func buildNode() *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "n1",
+			Annotations: map[string]string{
+				SharedDevicesAnnotation: `{"huawei.com/npu": [` +
+					`{"id": "npu-1", "type": "Ascend910", "memory": 32768, "cores": 100},` +
+					`{"id": "npu-0", "type": "Ascend910", "memory": 32768, "cores": 100}]}`,
+			},
+		},
+	}
+}
+
+func buildPod(name string, num, memory, cores int64) *v1.Pod {
+	limits := v1.ResourceList{}
+	if num > 0 {
+		limits[npuResource] = *resource.NewQuantity(num, resource.DecimalSI)
+	}
+	if memory > 0 {
+		limits[npuResource+MemoryResourceSuffix] = *resource.NewQuantity(memory, resource.DecimalSI)
+	}
+	if cores > 0 {
+		limits[npuResource+CoresResourceSuffix] = *resource.NewQuantity(cores, resource.DecimalSI)
+	}
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID("uid-" + name)},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Resources: v1.ResourceRequirements{Limits: limits}}},
+		},
+	}
+}
+
+func TestNewSharedDevices(t *testing.T) {
+	sd := NewSharedDevices("n1", buildNode())
+	if sd == nil {
+		t.Fatalf("expects shared devices to be parsed")
+	}
+	devs := sd.Devices[npuResource]
+	if len(devs) != 2 || devs[0].ID != "npu-0" || devs[1].ID != "npu-1" {
+		t.Errorf("expects npu-0 and npu-1 in order, got %v", devs)
+	}
+
+	if sd := NewSharedDevices("n2", &v1.Node{}); sd != nil {
+		t.Errorf("expects nil for node without shared devices, got %v", sd)
+	}
+}
+
+func TestFilterAndScoreNode(t *testing.T) {
+	EnabledResources = []string{npuResource}
+	defer func() { EnabledResources = nil }()
+
+	testCases := []struct {
+		name        string
+		used        []DeviceUsage
+		pod         *v1.Pod
+		policy      string
+		expectCode  int
+		expectScore float64
+	}{
+		{
+			name:        "share an idle device",
+			pod:         buildPod("p1", 1, 16384, 50),
+			policy:      binpackPolicy,
+			expectCode:  devices.Success,
+			expectScore: 50,
+		},
+		{
+			name:        "binpack prefers the used device",
+			used:        []DeviceUsage{{ID: "npu-1", Memory: 8192, Cores: 25}},
+			pod:         buildPod("p1", 1, 8192, 25),
+			policy:      binpackPolicy,
+			expectCode:  devices.Success,
+			expectScore: 50,
+		},
+		{
+			name:        "spread prefers the idle device",
+			used:        []DeviceUsage{{ID: "npu-1", Memory: 8192, Cores: 25}},
+			pod:         buildPod("p1", 1, 8192, 25),
+			policy:      spreadPolicy,
+			expectCode:  devices.Success,
+			expectScore: 75,
+		},
+		{
+			name:       "whole devices are not available when one is shared",
+			used:       []DeviceUsage{{ID: "npu-1", Memory: 8192, Cores: 25}},
+			pod:        buildPod("p1", 2, 0, 0),
+			policy:     binpackPolicy,
+			expectCode: devices.Unschedulable,
+		},
+		{
+			name:       "not enough memory",
+			used:       []DeviceUsage{{ID: "npu-0", Memory: 30000}, {ID: "npu-1", Memory: 30000}},
+			pod:        buildPod("p1", 0, 8192, 0),
+			policy:     binpackPolicy,
+			expectCode: devices.Unschedulable,
+		},
+	}
+
+	for _, tc := range testCases {
+		sd := NewSharedDevices("n1", buildNode())
+		sd.addUsages(npuResource, "used", tc.used)
+
+		code, _, _ := sd.FilterNode(tc.pod, tc.policy)
+		if code != tc.expectCode {
+			t.Errorf("%s: expects code %d, got %d", tc.name, tc.expectCode, code)
+			continue
+		}
+		if code != devices.Success {
+			continue
+		}
+		if score := sd.ScoreNode(tc.pod, tc.policy); score != tc.expectScore {
+			t.Errorf("%s: expects score %v, got %v", tc.name, tc.expectScore, score)
+		}
+	}
+}
+
+func TestAllocateAndRelease(t *testing.T) {
+	EnabledResources = []string{npuResource}
+	defer func() { EnabledResources = nil }()
+
+	pod := buildPod("p1", 2, 0, 0)
+	client := fake.NewSimpleClientset(pod)
+	sd := NewSharedDevices("n1", buildNode())
+
+	if !sd.HasDeviceRequest(pod) {
+		t.Fatalf("expects pod to request %s", npuResource)
+	}
+	if err := sd.Allocate(client, pod); err != nil {
+		t.Fatalf("failed to allocate: %v", err)
+	}
+	for _, dev := range sd.Devices[npuResource] {
+		if dev.UsedMemory != dev.Memory || dev.UsedCores != dev.Cores {
+			t.Errorf("expects device %s to be used exclusively, got memory %d cores %d", dev.ID, dev.UsedMemory, dev.UsedCores)
+		}
+	}
+
+	patched, err := client.CoreV1().Pods(pod.Namespace).Get(context.TODO(), pod.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get pod: %v", err)
+	}
+	usages, err := assignedDevicesOfPod(patched, npuResource)
+	if err != nil || len(usages) != 2 {
+		t.Fatalf("expects 2 devices assigned in annotation, got %v, err %v", usages, err)
+	}
+
+	// The usage recovered from the annotation is the same as the allocated one.
+	recovered := NewSharedDevices("n1", buildNode())
+	recovered.AddResource(patched)
+	for i, dev := range recovered.Devices[npuResource] {
+		if dev.UsedMemory != sd.Devices[npuResource][i].UsedMemory {
+			t.Errorf("expects recovered usage of %s to be %d, got %d", dev.ID, sd.Devices[npuResource][i].UsedMemory, dev.UsedMemory)
+		}
+	}
+
+	if err := sd.Release(client, patched); err != nil {
+		t.Fatalf("failed to release: %v", err)
+	}
+	for _, dev := range sd.Devices[npuResource] {
+		if dev.UsedMemory != 0 || dev.UsedCores != 0 || len(dev.PodMap) != 0 {
+			t.Errorf("expects device %s to be released", dev.ID)
+		}
+	}
+	released, _ := client.CoreV1().Pods(pod.Namespace).Get(context.TODO(), pod.Name, metav1.GetOptions{})
+	if _, found := released.Annotations[AssignedDevicesAnnotation(npuResource)]; found {
+		t.Errorf("expects assigned devices annotation to be removed")
+	}
+}
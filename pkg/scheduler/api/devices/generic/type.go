@@ -0,0 +1,83 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generic
+
+const (
+	// DeviceName used to indicate this device
+	DeviceName = "GenericDevice"
+
+	// SharedDevicesAnnotation is the node annotation publishing the shared devices of each resource, e.g.
+	// {"amd.com/gpu": [{"id": "0", "type": "MI300X", "memory": 196608, "cores": 100}]}
+	SharedDevicesAnnotation = "volcano.sh/shared-devices"
+	// AssignedDevicesAnnotationPrefix is the prefix of the pod annotation recording the devices assigned
+	// to the pod, the resource name follows it with "/" replaced by "_", e.g. volcano.sh/devices.amd.com_gpu
+	AssignedDevicesAnnotationPrefix = "volcano.sh/devices."
+
+	// MemoryResourceSuffix is appended to the device resource name to request device memory of each device
+	MemoryResourceSuffix = "-memory"
+	// CoresResourceSuffix is appended to the device resource name to request device cores of each device
+	CoresResourceSuffix = "-cores"
+
+	// binpack means the devices with less resources remained are preferred
+	binpackPolicy = "binpack"
+	// spread means the devices with more resources remained are preferred
+	spreadPolicy = "spread"
+
+	scoreMultiplier = 100
+)
+
+var (
+	// EnabledResources is the resource names of the devices scheduled by the generic backend.
+	EnabledResources []string
+	// SchedulePolicy is the policy to choose devices when allocating them to a pod.
+	SchedulePolicy string
+)
+
+// Device is a shared device published in the node annotation.
+type Device struct {
+	ID     string `json:"id"`
+	Type   string `json:"type,omitempty"`
+	Memory uint64 `json:"memory,omitempty"`
+	Cores  uint64 `json:"cores,omitempty"`
+
+	UsedMemory uint64 `json:"-"`
+	UsedCores  uint64 `json:"-"`
+	// PodMap is the usage of the device of each pod
+	PodMap map[string]DeviceUsage `json:"-"`
+}
+
+// DeviceUsage is the resources of a device used by a pod.
+type DeviceUsage struct {
+	ID     string `json:"id"`
+	Memory uint64 `json:"memory"`
+	Cores  uint64 `json:"cores"`
+}
+
+// deviceRequest is the resources requested by a pod of a device resource.
+type deviceRequest struct {
+	// number of devices
+	num int
+	// memory of each device
+	memory uint64
+	// cores of each device
+	cores uint64
+}
+
+// exclusive returns whether the whole devices are requested.
+func (r deviceRequest) exclusive() bool {
+	return r.memory == 0 && r.cores == 0
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generic
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// AssignedDevicesAnnotation returns the pod annotation recording the assigned devices of the resource.
+func AssignedDevicesAnnotation(resourceName string) string {
+	return AssignedDevicesAnnotationPrefix + strings.ReplaceAll(resourceName, "/", "_")
+}
+
+// parseSharedDevices parses the devices of all resources published in the node annotation.
+func parseSharedDevices(node *v1.Node) (map[string][]*Device, error) {
+	value, found := node.Annotations[SharedDevicesAnnotation]
+	if !found {
+		return nil, nil
+	}
+
+	published := map[string][]*Device{}
+	if err := json.Unmarshal([]byte(value), &published); err != nil {
+		return nil, err
+	}
+
+	for resourceName, devs := range published {
+		if len(devs) == 0 {
+			delete(published, resourceName)
+			continue
+		}
+		for _, dev := range devs {
+			dev.PodMap = map[string]DeviceUsage{}
+		}
+		sort.SliceStable(devs, func(i, j int) bool {
+			return devs[i].ID < devs[j].ID
+		})
+	}
+	return published, nil
+}
+
+// quantityOfPod returns the total quantity of the resource requested by the containers of the pod.
+func quantityOfPod(pod *v1.Pod, resourceName v1.ResourceName) int64 {
+	var total int64
+	for _, container := range pod.Spec.Containers {
+		if quantity, found := container.Resources.Limits[resourceName]; found {
+			total += quantity.Value()
+		} else if quantity, found := container.Resources.Requests[resourceName]; found {
+			total += quantity.Value()
+		}
+	}
+	return total
+}
+
+// requestOfPod returns the devices of the resource requested by the pod.
+func requestOfPod(pod *v1.Pod, resourceName string) deviceRequest {
+	req := deviceRequest{
+		num:    int(quantityOfPod(pod, v1.ResourceName(resourceName))),
+		memory: uint64(quantityOfPod(pod, v1.ResourceName(resourceName+MemoryResourceSuffix))),
+		cores:  uint64(quantityOfPod(pod, v1.ResourceName(resourceName+CoresResourceSuffix))),
+	}
+	if req.num == 0 && !req.exclusive() {
+		req.num = 1
+	}
+	return req
+}
+
+// assignedDevicesOfPod returns the devices of the resource assigned to the pod.
+func assignedDevicesOfPod(pod *v1.Pod, resourceName string) ([]DeviceUsage, error) {
+	value, found := pod.Annotations[AssignedDevicesAnnotation(resourceName)]
+	if !found {
+		return nil, nil
+	}
+
+	var usages []DeviceUsage
+	if err := json.Unmarshal([]byte(value), &usages); err != nil {
+		return nil, err
+	}
+	return usages, nil
+}
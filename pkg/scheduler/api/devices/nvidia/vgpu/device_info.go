@@ -74,6 +74,8 @@ type GPUDevices struct {
 	Score float64
 
 	Device map[int]*GPUDevice
+	// Topology is the link type between each pair of GPUs by uuid
+	Topology map[string]map[string]string
 	// Sharing sharing handler
 	Sharing SharingFactory
 }
@@ -132,6 +134,7 @@ func NewGPUDevices(name string, node *v1.Node) *GPUDevices {
 		patchNodeAnnotations(node, tmppat)
 	}
 	nodedevices.Sharing = sharingHandler
+	nodedevices.Topology = parseTopology(node)
 	return nodedevices
 }
 
@@ -0,0 +1,179 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vgpu
+
+import (
+	"encoding/json"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// Scores of the links between two GPUs, the link types are the same as the output of `nvidia-smi topo -m`.
+const (
+	// NV# means the GPUs are connected by a bonded set of # NVLinks
+	nvlinkScore = 100
+	// PIX means the GPUs are connected by at most a single PCIe bridge
+	pixScore = 50
+	// PXB means the GPUs are connected by multiple PCIe bridges without traversing the host bridge
+	pxbScore = 40
+	// PHB means the GPUs are connected by a PCIe host bridge
+	phbScore = 30
+	// NODE means the GPUs are connected by the interconnect between host bridges within a NUMA node
+	nodeScore = 20
+	// SYS means the GPUs are connected by the interconnect between NUMA nodes
+	sysScore = 10
+)
+
+// parseTopology parses the links between GPUs published in the node annotation, which is like
+// {"GPU-uuid-0": {"GPU-uuid-1": "NV4", "GPU-uuid-2": "SYS"}}.
+func parseTopology(node *v1.Node) map[string]map[string]string {
+	value, ok := node.Annotations[GPUTopologyAnnotation]
+	if !ok {
+		return nil
+	}
+
+	topology := map[string]map[string]string{}
+	if err := json.Unmarshal([]byte(value), &topology); err != nil {
+		klog.Errorf("Failed to parse gpu topology of node %s: %v", node.Name, err)
+		return nil
+	}
+	return topology
+}
+
+func linkScore(link string) float64 {
+	switch {
+	case strings.HasPrefix(link, "NV"):
+		return nvlinkScore
+	case link == "PIX":
+		return pixScore
+	case link == "PXB":
+		return pxbScore
+	case link == "PHB":
+		return phbScore
+	case link == "NODE":
+		return nodeScore
+	case link == "SYS":
+		return sysScore
+	default:
+		return 0
+	}
+}
+
+// linkScoreBetween returns the score of the link between the two GPUs, the topology published may be one-sided.
+func (gs *GPUDevices) linkScoreBetween(l, r string) float64 {
+	if link, ok := gs.Topology[l][r]; ok {
+		return linkScore(link)
+	}
+	return linkScore(gs.Topology[r][l])
+}
+
+// topologyScore returns the average score of the links between each pair of the GPUs.
+func (gs *GPUDevices) topologyScore(uuids []string) float64 {
+	var total float64
+	var pairs int
+	for i := range uuids {
+		for j := i + 1; j < len(uuids); j++ {
+			total += gs.linkScoreBetween(uuids[i], uuids[j])
+			pairs++
+		}
+	}
+	if pairs == 0 {
+		return 0
+	}
+	return total / float64(pairs)
+}
+
+// deviceOrder returns the order of device index to try for the container request. Devices are tried from the
+// last one by default; with topology policy, the best connected set of the fitted devices is tried first.
+func (gs *GPUDevices) deviceOrder(pod *v1.Pod, val ContainerDeviceRequest, schedulePolicy string) []int {
+	order := make([]int, 0, len(gs.Device))
+	for i := len(gs.Device) - 1; i >= 0; i-- {
+		order = append(order, i)
+	}
+	if schedulePolicy != topologyPolicy || val.Nums <= 1 || len(gs.Topology) == 0 {
+		return order
+	}
+
+	var candidates []int
+	for _, i := range order {
+		if deviceFits(gs.Device[i], val) && checkType(pod.Annotations, *gs.Device[i], val) {
+			candidates = append(candidates, i)
+		}
+	}
+	best := gs.bestConnectedSet(candidates, int(val.Nums))
+	if best == nil {
+		return order
+	}
+
+	chosen := make(map[int]bool, len(best))
+	for _, i := range best {
+		chosen[i] = true
+	}
+	for _, i := range order {
+		if !chosen[i] {
+			best = append(best, i)
+		}
+	}
+	return best
+}
+
+// bestConnectedSet greedily picks num devices from the candidates with the best links among them, each candidate
+// is tried as the first device and the set with the highest total link score wins.
+func (gs *GPUDevices) bestConnectedSet(candidates []int, num int) []int {
+	if len(candidates) < num {
+		return nil
+	}
+
+	var best []int
+	bestScore := float64(-1)
+	for _, seed := range candidates {
+		set := []int{seed}
+		var setScore float64
+		for len(set) < num {
+			next, nextScore := -1, float64(-1)
+			for _, c := range candidates {
+				if containsIndex(set, c) {
+					continue
+				}
+				var s float64
+				for _, m := range set {
+					s += gs.linkScoreBetween(gs.Device[m].UUID, gs.Device[c].UUID)
+				}
+				if s > nextScore {
+					next, nextScore = c, s
+				}
+			}
+			set = append(set, next)
+			setScore += nextScore
+		}
+		if setScore > bestScore {
+			best, bestScore = set, setScore
+		}
+	}
+	return best
+}
+
+func containsIndex(set []int, i int) bool {
+	for _, s := range set {
+		if s == i {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vgpu
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/volcano/pkg/scheduler/api/devices/config"
+)
+
+// buildTopologyGPUDevices builds 4 GPUs, GPU-0 and GPU-3 are connected by NVLink, the others by PCIe host bridge.
+func buildTopologyGPUDevices() *GPUDevices {
+	gs := &GPUDevices{
+		Name:    "node1",
+		Device:  map[int]*GPUDevice{},
+		Sharing: &HAMICoreFactory{},
+	}
+	for i := 0; i < 4; i++ {
+		gs.Device[i] = &GPUDevice{
+			ID:     i,
+			UUID:   fmt.Sprintf("GPU-%d", i),
+			Memory: 16384,
+			Number: 10,
+			Type:   NvidiaGPUDevice,
+			Health: true,
+			PodMap: map[string]*GPUUsage{},
+		}
+	}
+	gs.Topology = parseTopology(&v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node1",
+			Annotations: map[string]string{
+				GPUTopologyAnnotation: `{"GPU-0": {"GPU-1": "PHB", "GPU-2": "PHB", "GPU-3": "NV4"},` +
+					`"GPU-1": {"GPU-2": "PHB", "GPU-3": "PHB"}, "GPU-2": {"GPU-3": "PHB"}}`,
+			},
+		},
+	})
+	return gs
+}
+
+func buildMultiGPUPod(num int64) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "p1", Namespace: "default"},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Resources: v1.ResourceRequirements{
+						Limits: v1.ResourceList{
+							config.VolcanoVGPUNumber: *resource.NewQuantity(num, resource.DecimalSI),
+							config.VolcanoVGPUMemory: resource.MustParse("1024"),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestTopologyPolicy(t *testing.T) {
+	testCases := []struct {
+		name          string
+		policy        string
+		expectDevices []string
+		expectScore   float64
+	}{
+		{
+			name:          "topology policy prefers GPUs connected by NVLink",
+			policy:        topologyPolicy,
+			expectDevices: []string{"GPU-0", "GPU-3"},
+			expectScore:   nvlinkScore,
+		},
+		{
+			name:          "default policy tries GPUs from the last one",
+			policy:        "",
+			expectDevices: []string{"GPU-2", "GPU-3"},
+			expectScore:   0,
+		},
+	}
+
+	for _, tc := range testCases {
+		gs := buildTopologyGPUDevices()
+		fit, devs, score, err := checkNodeGPUSharingPredicateAndScore(buildMultiGPUPod(2), gs, true, tc.policy)
+		if err != nil || !fit {
+			t.Fatalf("%s: expects fit, got %v, err %v", tc.name, fit, err)
+		}
+
+		var uuids []string
+		for _, dev := range devs[0] {
+			uuids = append(uuids, dev.UUID)
+		}
+		sort.Strings(uuids)
+		if fmt.Sprint(uuids) != fmt.Sprint(tc.expectDevices) {
+			t.Errorf("%s: expects devices %v, got %v", tc.name, tc.expectDevices, uuids)
+		}
+		if score != tc.expectScore {
+			t.Errorf("%s: expects score %v, got %v", tc.name, tc.expectScore, score)
+		}
+	}
+}
+
+func TestTopologyPolicyWithUsedGPU(t *testing.T) {
+	gs := buildTopologyGPUDevices()
+	// GPU-3 is taken exclusively, the best connected set falls back to PCIe.
+	gs.Device[3].UsedNum = 1
+	gs.Device[3].UsedCore = 100
+
+	fit, devs, score, err := checkNodeGPUSharingPredicateAndScore(buildMultiGPUPod(2), gs, true, topologyPolicy)
+	if err != nil || !fit {
+		t.Fatalf("expects fit, got %v, err %v", fit, err)
+	}
+	for _, dev := range devs[0] {
+		if dev.UUID == "GPU-3" {
+			t.Errorf("expects GPU-3 not to be chosen")
+		}
+	}
+	if score != phbScore {
+		t.Errorf("expects score %v, got %v", phbScore, score)
+	}
+}
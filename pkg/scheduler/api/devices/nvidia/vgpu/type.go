@@ -43,6 +43,11 @@ const (
 	binpackPolicy = "binpack"
 	// spread means better put this task into an idle GPU card than a shared GPU card
 	spreadPolicy = "spread"
+	// topology means better put this task into GPU cards connected by NVLink or under the same PCIe switch
+	topologyPolicy = "topology"
+
+	// GPUTopologyAnnotation is the node annotation publishing the link type between each pair of GPUs
+	GPUTopologyAnnotation = "volcano.sh/vgpu-topology"
 	// 101 means wo don't assign defaultMemPercentage value
 
 	DefaultMemPercentage = 101
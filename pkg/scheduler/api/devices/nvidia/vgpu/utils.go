@@ -288,10 +288,11 @@ func checkType(annos map[string]string, d GPUDevice, n ContainerDeviceRequest) b
 // getGPUDeviceSnapShot is not a strict deep copy, the pointer item is same with origin.
 func getGPUDeviceSnapShot(snap *GPUDevices) *GPUDevices {
 	ret := GPUDevices{
-		Name:    snap.Name,
-		Device:  make(map[int]*GPUDevice),
-		Score:   float64(0),
-		Sharing: snap.Sharing,
+		Name:     snap.Name,
+		Device:   make(map[int]*GPUDevice),
+		Score:    float64(0),
+		Sharing:  snap.Sharing,
+		Topology: snap.Topology,
 	}
 	for index, val := range snap.Device {
 		if val != nil {
@@ -380,27 +381,14 @@ func checkNodeGPUSharingPredicateAndScore(pod *v1.Pod, gssnap *GPUDevices, repli
 		}
 		klog.V(3).InfoS("Allocating device for container", "request", val)
 
-		for i := len(gs.Device) - 1; i >= 0; i-- {
+		var fitted []string
+		for _, i := range gs.deviceOrder(pod, val, schedulePolicy) {
 			klog.V(3).InfoS("Scoring pod request", "memReq", val.Memreq, "memPercentageReq", val.MemPercentagereq, "coresReq", val.Coresreq, "Nums", val.Nums, "Index", i, "ID", gs.Device[i].ID)
 			klog.V(3).InfoS("Current Device", "Index", i, "TotalMemory", gs.Device[i].Memory, "UsedMemory", gs.Device[i].UsedMem, "UsedCores", gs.Device[i].UsedCore, "replicate", replicate)
-			if gs.Device[i].Number <= uint(gs.Device[i].UsedNum) {
-				continue
-			}
 			if val.MemPercentagereq != 101 && val.Memreq == 0 {
 				val.Memreq = gs.Device[i].Memory * uint(val.MemPercentagereq/100)
 			}
-			if int(gs.Device[i].Memory)-int(gs.Device[i].UsedMem) < int(val.Memreq) {
-				continue
-			}
-			if gs.Device[i].UsedCore+val.Coresreq > 100 {
-				continue
-			}
-			// Coresreq=100 indicates it want this card exclusively
-			if val.Coresreq == 100 && gs.Device[i].UsedNum > 0 {
-				continue
-			}
-			// You can't allocate core=0 job to an already full GPU
-			if gs.Device[i].UsedCore == 100 && val.Coresreq == 0 {
+			if !deviceFits(gs.Device[i], val) {
 				continue
 			}
 			if !checkType(pod.Annotations, *gs.Device[i], val) {
@@ -424,6 +412,7 @@ func checkNodeGPUSharingPredicateAndScore(pod *v1.Pod, gssnap *GPUDevices, repli
 					Usedcores: val.Coresreq,
 				})
 				score += GPUScore(schedulePolicy, gs.Device[i])
+				fitted = append(fitted, gs.Device[i].UUID)
 			}
 			if val.Nums == 0 {
 				break
@@ -432,11 +421,40 @@ func checkNodeGPUSharingPredicateAndScore(pod *v1.Pod, gssnap *GPUDevices, repli
 		if val.Nums > 0 {
 			return false, []ContainerDevices{}, 0, fmt.Errorf("not enough gpu fitted on this node")
 		}
+		if schedulePolicy == topologyPolicy {
+			score += gs.topologyScore(fitted)
+		}
 		ctrdevs = append(ctrdevs, devs)
 	}
 	return true, ctrdevs, score, nil
 }
 
+// deviceFits checks whether the device has enough capacity for the container request.
+func deviceFits(device *GPUDevice, val ContainerDeviceRequest) bool {
+	memreq := val.Memreq
+	if val.MemPercentagereq != 101 && memreq == 0 {
+		memreq = device.Memory * uint(val.MemPercentagereq/100)
+	}
+	if device.Number <= device.UsedNum {
+		return false
+	}
+	if int(device.Memory)-int(device.UsedMem) < int(memreq) {
+		return false
+	}
+	if device.UsedCore+val.Coresreq > 100 {
+		return false
+	}
+	// Coresreq=100 indicates it want this card exclusively
+	if val.Coresreq == 100 && device.UsedNum > 0 {
+		return false
+	}
+	// You can't allocate core=0 job to an already full GPU
+	if device.UsedCore == 100 && val.Coresreq == 0 {
+		return false
+	}
+	return true
+}
+
 func GPUScore(schedulePolicy string, device *GPUDevice) float64 {
 	var score float64
 	switch schedulePolicy {
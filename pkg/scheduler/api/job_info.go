@@ -374,6 +374,11 @@ type JobInfo struct {
 	// * value means workload can use all the revocable node for during node active revocable time.
 	RevocableZone string
 	Budget        *DisruptionBudget
+
+	// JobGroup is the name of the job group which the job belongs to, empty means the job is not in any group.
+	JobGroup string
+	// JobGroupMinMember is the number of jobs that must be present in the job group.
+	JobGroupMinMember int32
 }
 
 // NewJobInfo creates a new jobInfo for set of tasks
@@ -428,6 +433,7 @@ func (ji *JobInfo) SetPodGroup(pg *PodGroup) {
 	ji.Preemptable = ji.extractPreemptable(pg)
 	ji.RevocableZone = ji.extractRevocableZone(pg)
 	ji.Budget = ji.extractBudget(pg)
+	ji.JobGroup, ji.JobGroupMinMember = ji.extractJobGroup(pg)
 
 	ji.ParseMinMemberInfo(pg)
 
@@ -517,6 +523,35 @@ func (ji *JobInfo) extractBudget(pg *PodGroup) *DisruptionBudget {
 	return NewDisruptionBudget("", "")
 }
 
+// extractJobGroup return volcano.sh/job-group and volcano.sh/job-group-min-member value for job
+func (ji *JobInfo) extractJobGroup(pg *PodGroup) (string, int32) {
+	group := pg.Annotations[JobGroupAnnotationKey]
+	if len(group) == 0 {
+		return "", 0
+	}
+
+	value, found := pg.Annotations[JobGroupMinMemberAnnotationKey]
+	if !found {
+		return group, 0
+	}
+	minMember, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || minMember < 0 {
+		klog.Warningf("invalid %s=%s", JobGroupMinMemberAnnotationKey, value)
+		return group, 0
+	}
+
+	return group, int32(minMember)
+}
+
+// JobGroupKey returns the key of the job group which the job belongs to, job groups are namespaced,
+// empty means the job is not in any group.
+func (ji *JobInfo) JobGroupKey() string {
+	if len(ji.JobGroup) == 0 {
+		return ""
+	}
+	return ji.Namespace + "/" + ji.JobGroup
+}
+
 // ParseMinMemberInfo set the information about job's min member
 // 1. set number of each role to TaskMinAvailable
 // 2. calculate sum of all roles' min members and set to TaskMinAvailableTotal
@@ -677,6 +712,8 @@ func (ji *JobInfo) Clone() *JobInfo {
 		Preemptable:           ji.Preemptable,
 		RevocableZone:         ji.RevocableZone,
 		Budget:                ji.Budget.Clone(),
+		JobGroup:              ji.JobGroup,
+		JobGroupMinMember:     ji.JobGroupMinMember,
 	}
 
 	ji.CreationTimestamp.DeepCopyInto(&info.CreationTimestamp)
@@ -27,9 +27,6 @@ import (
 
 	"volcano.sh/apis/pkg/apis/scheduling"
 	"volcano.sh/apis/pkg/apis/scheduling/v1beta1"
-
-	"volcano.sh/volcano/pkg/scheduler/api/devices/nvidia/gpushare"
-	"volcano.sh/volcano/pkg/scheduler/api/devices/nvidia/vgpu"
 )
 
 type AllocateFailError struct {
@@ -184,6 +181,11 @@ func (ni *NodeInfo) RefreshNumaSchedulerInfoByCrd() {
 
 	tmp := ni.NumaInfo.DeepCopy()
 	if ni.NumaChgFlag == NumaInfoMoreFlag {
+		// the devices are not reported by the numatopology, keep tracking their allocations
+		if ni.NumaSchedulerInfo != nil {
+			tmp.DeviceNumaAffinity = ni.NumaSchedulerInfo.DeviceNumaAffinity
+			tmp.DeviceAllocations = ni.NumaSchedulerInfo.DeviceAllocations
+		}
 		ni.NumaSchedulerInfo = tmp
 	} else if ni.NumaChgFlag == NumaInfoLessFlag {
 		numaResMap := ni.NumaSchedulerInfo.NumaResMap
@@ -198,6 +200,30 @@ func (ni *NodeInfo) RefreshNumaSchedulerInfoByCrd() {
 	}
 
 	ni.NumaChgFlag = NumaInfoResetFlag
+	ni.refreshNumaDevices()
+}
+
+// refreshNumaDevices updates the devices with NUMA affinity published in the node annotation,
+// the devices allocated to the tasks which are no longer on the node are released.
+func (ni *NodeInfo) refreshNumaDevices() {
+	if ni.NumaSchedulerInfo == nil {
+		return
+	}
+
+	affinity, err := ParseDeviceNumaAffinity(ni.Node)
+	if err != nil {
+		klog.Warningf("Failed to parse device numa affinity of node %s: %v", ni.Name, err)
+	}
+
+	holding := make(map[TaskID]bool, len(ni.Tasks))
+	for _, task := range ni.Tasks {
+		if AllocatedStatus(task.Status) || task.Status == Releasing {
+			holding[task.UID] = true
+		}
+	}
+	ni.NumaSchedulerInfo.SetDeviceNumaAffinity(affinity, func(taskID TaskID) bool {
+		return holding[taskID]
+	})
 }
 
 // Clone used to clone nodeInfo Object
@@ -347,12 +373,13 @@ func (ni *NodeInfo) setNodeOthersResource(node *v1.Node) {
 		return
 	}
 
-	ni.Others[gpushare.DeviceName] = gpushare.NewGPUDevices(ni.Name, node)
-	ni.Others[vgpu.DeviceName] = vgpu.NewGPUDevices(ni.Name, node)
-	IgnoredDevicesList.Set(
-		ni.Others[gpushare.DeviceName].(Devices).GetIgnoredDevices(),
-		ni.Others[vgpu.DeviceName].(Devices).GetIgnoredDevices(),
-	)
+	ignoredDevices := make([][]string, 0, len(RegisteredDevices))
+	for _, name := range RegisteredDevices {
+		devices := deviceBackends[name].builder(ni.Name, node)
+		ni.Others[name] = devices
+		ignoredDevices = append(ignoredDevices, devices.GetIgnoredDevices())
+	}
+	IgnoredDevicesList.Set(ignoredDevices...)
 }
 
 // setNode sets kubernetes node object to nodeInfo object without assertion
@@ -495,23 +522,26 @@ func (ni *NodeInfo) RemoveTask(ti *TaskInfo) error {
 
 // addResource is used to add sharable devices
 func (ni *NodeInfo) addResource(pod *v1.Pod) {
-	// In the upgrade scenario from volcano1.7+volcano-device-plugin to volcano1.12+hami-device-plugin (with VGPUEnable),
-	// pods scheduled by volcano 1.7 and using the volcano.sh/gpu-number resource will cause the scheduler pod in volcano 1.12 to panic
-	// at dev := gs.Device[id] ,where gs.Device is nil.
-
-	// Add an if judgment condition to fix the panic.
-	if gpushare.GpuSharingEnable || gpushare.GpuNumberEnable {
-		ni.Others[gpushare.DeviceName].(Devices).AddResource(pod)
+	for _, name := range RegisteredDevices {
+		if !deviceEnabled(name) {
+			continue
+		}
+		if devices, ok := ni.Others[name].(Devices); ok {
+			devices.AddResource(pod)
+		}
 	}
-	ni.Others[vgpu.DeviceName].(Devices).AddResource(pod)
 }
 
 // subResource is used to subtract sharable devices
 func (ni *NodeInfo) subResource(pod *v1.Pod) {
-	if gpushare.GpuSharingEnable || gpushare.GpuNumberEnable {
-		ni.Others[gpushare.DeviceName].(Devices).SubResource(pod)
+	for _, name := range RegisteredDevices {
+		if !deviceEnabled(name) {
+			continue
+		}
+		if devices, ok := ni.Others[name].(Devices); ok {
+			devices.SubResource(pod)
+		}
 	}
-	ni.Others[vgpu.DeviceName].(Devices).SubResource(pod)
 }
 
 // UpdateTask is used to update a task in nodeInfo object.
@@ -63,6 +63,12 @@ type NumatopoInfo struct {
 	NumaResMap  map[string]*ResourceInfo
 	CPUDetail   topology.CPUDetails
 	ResReserved v1.ResourceList
+	// DeviceNumaAffinity is the NUMA node of each device, indexed by resource name and device ID,
+	// it is published by the agent in the node annotation volcano.sh/numa-device-affinity.
+	DeviceNumaAffinity map[string]map[int]int
+	// DeviceAllocations is the devices allocated to each task by the scheduler, indexed by task ID,
+	// the allocatable devices in NumaResMap are the devices with known NUMA affinity not allocated to any task.
+	DeviceAllocations map[TaskID]ResNumaSets
 }
 
 // DeepCopy used to copy NumatopoInfo
@@ -110,6 +116,23 @@ func (info *NumatopoInfo) DeepCopy() *NumatopoInfo {
 		numaInfo.ResReserved[resName] = res
 	}
 
+	if info.DeviceNumaAffinity != nil {
+		numaInfo.DeviceNumaAffinity = make(map[string]map[int]int, len(info.DeviceNumaAffinity))
+		for resName, affinity := range info.DeviceNumaAffinity {
+			numaInfo.DeviceNumaAffinity[resName] = make(map[int]int, len(affinity))
+			for deviceID, numaID := range affinity {
+				numaInfo.DeviceNumaAffinity[resName][deviceID] = numaID
+			}
+		}
+	}
+
+	if info.DeviceAllocations != nil {
+		numaInfo.DeviceAllocations = make(map[TaskID]ResNumaSets, len(info.DeviceAllocations))
+		for taskID, resSets := range info.DeviceAllocations {
+			numaInfo.DeviceAllocations[taskID] = resSets.Clone()
+		}
+	}
+
 	return numaInfo
 }
 
@@ -132,6 +155,9 @@ func (info *NumatopoInfo) Compare(newInfo *NumatopoInfo) bool {
 // Allocate is the function to remove the allocated resource
 func (info *NumatopoInfo) Allocate(resSets ResNumaSets) {
 	for resName := range resSets {
+		if _, ok := info.NumaResMap[resName]; !ok {
+			continue
+		}
 		info.NumaResMap[resName].Allocatable = info.NumaResMap[resName].Allocatable.Difference(resSets[resName])
 	}
 }
@@ -139,10 +165,76 @@ func (info *NumatopoInfo) Allocate(resSets ResNumaSets) {
 // Release is the function to reclaim the allocated resource
 func (info *NumatopoInfo) Release(resSets ResNumaSets) {
 	for resName := range resSets {
+		if _, ok := info.NumaResMap[resName]; !ok {
+			continue
+		}
 		info.NumaResMap[resName].Allocatable = info.NumaResMap[resName].Allocatable.Union(resSets[resName])
 	}
 }
 
+// AllocateDevices records the devices allocated to the task, and removes them from the allocatable devices
+func (info *NumatopoInfo) AllocateDevices(taskID TaskID, resSets ResNumaSets) {
+	devices := make(ResNumaSets)
+	for resName, set := range resSets {
+		if _, ok := info.DeviceNumaAffinity[resName]; ok && set.Size() > 0 {
+			devices[resName] = set.Clone()
+		}
+	}
+	if len(devices) == 0 {
+		return
+	}
+
+	if info.DeviceAllocations == nil {
+		info.DeviceAllocations = make(map[TaskID]ResNumaSets)
+	}
+	info.DeviceAllocations[taskID] = devices
+	info.Allocate(devices)
+}
+
+// SetDeviceNumaAffinity sets the NUMA affinity of devices, and recalculates the allocatable devices in NumaResMap
+// from the devices allocated to the tasks which still hold them, the allocations of other tasks are released.
+func (info *NumatopoInfo) SetDeviceNumaAffinity(affinity map[string]map[int]int, holding func(TaskID) bool) {
+	// the resources reported by the numatopology are not tracked as devices
+	reported := make(map[string]bool)
+	for resName := range info.NumaResMap {
+		if _, seeded := info.DeviceNumaAffinity[resName]; seeded {
+			delete(info.NumaResMap, resName)
+		} else {
+			reported[resName] = true
+		}
+	}
+	info.DeviceNumaAffinity = make(map[string]map[int]int, len(affinity))
+	for resName, devices := range affinity {
+		if !reported[resName] {
+			info.DeviceNumaAffinity[resName] = devices
+		}
+	}
+
+	for taskID := range info.DeviceAllocations {
+		if !holding(taskID) {
+			delete(info.DeviceAllocations, taskID)
+		}
+	}
+
+	for resName, devices := range info.DeviceNumaAffinity {
+		resInfo := &ResourceInfo{
+			AllocatablePerNuma: make(map[int]float64),
+			UsedPerNuma:        make(map[int]float64),
+		}
+		deviceIDs := make([]int, 0, len(devices))
+		for deviceID, numaID := range devices {
+			deviceIDs = append(deviceIDs, deviceID)
+			resInfo.AllocatablePerNuma[numaID]++
+		}
+		resInfo.Allocatable = cpuset.New(deviceIDs...)
+		resInfo.Capacity = len(deviceIDs)
+		for _, resSets := range info.DeviceAllocations {
+			resInfo.Allocatable = resInfo.Allocatable.Difference(resSets[resName])
+		}
+		info.NumaResMap[resName] = resInfo
+	}
+}
+
 func GetPodResourceNumaInfo(ti *TaskInfo) map[int]v1.ResourceList {
 	if ti.NumaInfo != nil && len(ti.NumaInfo.ResMap) > 0 {
 		return ti.NumaInfo.ResMap
@@ -189,6 +281,24 @@ func (info *NumatopoInfo) RemoveTask(ti *TaskInfo) {
 	}
 }
 
+// ParseDeviceNumaAffinity return the NUMA affinity of devices published in the node annotation,
+// the annotation value is like {"nvidia.com/gpu": {"0": 0, "1": 1}}
+func ParseDeviceNumaAffinity(node *v1.Node) (map[string]map[int]int, error) {
+	if node == nil {
+		return nil, nil
+	}
+	value, ok := node.Annotations[NumaDeviceAffinityAnnotation]
+	if !ok || len(value) == 0 {
+		return nil, nil
+	}
+
+	affinity := make(map[string]map[int]int)
+	if err := json.Unmarshal([]byte(value), &affinity); err != nil {
+		return nil, err
+	}
+	return affinity, nil
+}
+
 // GenerateNodeResNumaSets return the idle resource sets of all node
 func GenerateNodeResNumaSets(nodes map[string]*NodeInfo) map[string]ResNumaSets {
 	nodeSlice := make(map[string]ResNumaSets)
@@ -76,9 +76,51 @@ type Devices interface {
 var _ Devices = new(gpushare.GPUDevices)
 var _ Devices = new(vgpu.GPUDevices)
 
-var RegisteredDevices = []string{
-	gpushare.DeviceName,
-	vgpu.DeviceName,
+// DeviceBuilder builds the shared devices of the node, a nil pointer of the device type
+// is returned if the node has no such device.
+type DeviceBuilder func(nodeName string, node *v1.Node) Devices
+
+// DeviceEnabled returns whether the device usage of pods should be accounted on nodes.
+type DeviceEnabled func() bool
+
+type deviceBackend struct {
+	builder DeviceBuilder
+	enabled DeviceEnabled
+}
+
+var deviceBackends = map[string]deviceBackend{}
+
+// RegisteredDevices is the names of the registered shared devices in registration order.
+var RegisteredDevices []string
+
+// RegisterDevice registers a shared device backend, the devices of each node are built by the builder
+// and used through the Devices interface in deviceshare and predicates plugins. It is not thread safe
+// and should be called in init of the backend; a nil enabled means the device is always enabled.
+func RegisterDevice(name string, builder DeviceBuilder, enabled DeviceEnabled) {
+	if _, found := deviceBackends[name]; !found {
+		RegisteredDevices = append(RegisteredDevices, name)
+	}
+	deviceBackends[name] = deviceBackend{builder: builder, enabled: enabled}
+}
+
+// deviceEnabled returns whether the registered device is enabled.
+func deviceEnabled(name string) bool {
+	backend, found := deviceBackends[name]
+	return found && (backend.enabled == nil || backend.enabled())
+}
+
+func init() {
+	RegisterDevice(gpushare.DeviceName, func(nodeName string, node *v1.Node) Devices {
+		return gpushare.NewGPUDevices(nodeName, node)
+	}, func() bool {
+		// In the upgrade scenario from volcano1.7+volcano-device-plugin to volcano1.12+hami-device-plugin (with VGPUEnable),
+		// pods scheduled by volcano 1.7 and using the volcano.sh/gpu-number resource will cause the scheduler pod in volcano 1.12 to panic
+		// at dev := gs.Device[id] ,where gs.Device is nil.
+		return gpushare.GpuSharingEnable || gpushare.GpuNumberEnable
+	})
+	RegisterDevice(vgpu.DeviceName, func(nodeName string, node *v1.Node) Devices {
+		return vgpu.NewGPUDevices(nodeName, node)
+	}, nil)
 }
 
 var IgnoredDevicesList = ignoredDevicesList{}
@@ -40,6 +40,9 @@ const (
 	// OfflineJobEvicting node will not schedule pod due to offline job evicting
 	OfflineJobEvicting = "volcano.sh/offline-job-evicting"
 
+	// NumaDeviceAffinityAnnotation is the key of node annotation about the NUMA node of each device, e.g. GPU and RDMA NIC
+	NumaDeviceAffinityAnnotation = "volcano.sh/numa-device-affinity"
+
 	// topologyDecisionAnnotation is the key of topology decision about pod request resource
 	topologyDecisionAnnotation = "volcano.sh/topology-decision"
 
@@ -47,4 +50,15 @@ const (
 	// to which the job is allocated. This typically represents the lowest common ancestor
 	// HyperNode in the scheduling hierarchy.
 	JobAllocatedHyperNode = "volcano.sh/job-allocated-hypernode"
+
+	// JobGroupAnnotationKey is the annotation key used to put several podgroups into one job group,
+	// all members of a job group in the same namespace are gang scheduled as a whole.
+	JobGroupAnnotationKey = "volcano.sh/job-group"
+	// JobGroupMinMemberAnnotationKey is the annotation key used to declare how many podgroups
+	// must be present in the job group before any of them can be scheduled.
+	JobGroupMinMemberAnnotationKey = "volcano.sh/job-group-min-member"
+
+	// PodGroupSuspendedAnnotationKey is the annotation key set by the job controller on the podgroup
+	// of a suspended job, the podgroup is kept but not scheduled until the job is resumed.
+	PodGroupSuspendedAnnotationKey = "volcano.sh/podgroup-suspended"
 )
@@ -171,6 +171,9 @@ type SchedulerCache struct {
 	// https://github.com/volcano-sh/volcano/blob/master/docs/design/deploy-multi-volcano-schedulers-without-using-selector.md
 	multiSchedulerInfo
 
+	// shardInfo holds the shard of current scheduler when several schedulers are active in sharding mode.
+	shardInfo
+
 	binderRegistry *BinderRegistry
 
 	// sharedDRAManager is used in DRA plugin, contains resourceClaimTracker, resourceSliceLister and deviceClassLister
@@ -538,6 +541,7 @@ func newSchedulerCache(config *rest.Config, schedulerNames []string, defaultQueu
 
 	sc.resyncPeriod = resyncPeriod
 	sc.schedulerPodName, sc.c = getMultiSchedulerInfo()
+	sc.shardInfo = newShardInfo(kubeClient)
 	ignoredProvisionersSet := sets.New[string]()
 	for _, provisioner := range append(ignoredProvisioners, defaultIgnoredProvisioners...) {
 		ignoredProvisionersSet.Insert(provisioner)
@@ -789,6 +793,9 @@ func (sc *SchedulerCache) Run(stopCh <-chan struct{}) {
 	sc.informerFactory.Start(stopCh)
 	sc.vcInformerFactory.Start(stopCh)
 	sc.WaitForCacheSync(stopCh)
+	if sc.shardCoordinator != nil {
+		sc.shardCoordinator.Run(stopCh)
+	}
 	for i := 0; i < int(sc.nodeWorkers); i++ {
 		go wait.Until(sc.runNodeWorker, 0, stopCh)
 	}
@@ -988,6 +995,28 @@ func (sc *SchedulerCache) UpdateSchedulerNumaInfo(AllocatedSets map[string]sched
 	return nil
 }
 
+// UpdateSchedulerNumaDevices used to record the devices allocated to tasks in scheduler node cache NumaSchedulerInfo
+func (sc *SchedulerCache) UpdateSchedulerNumaDevices(allocations map[string]map[schedulingapi.TaskID]schedulingapi.ResNumaSets) error {
+	sc.Mutex.Lock()
+	defer sc.Mutex.Unlock()
+
+	for nodeName, taskSets := range allocations {
+		if _, found := sc.Nodes[nodeName]; !found {
+			continue
+		}
+
+		numaInfo := sc.Nodes[nodeName].NumaSchedulerInfo
+		if numaInfo == nil {
+			continue
+		}
+
+		for taskID, sets := range taskSets {
+			numaInfo.AllocateDevices(taskID, sets)
+		}
+	}
+	return nil
+}
+
 // EventRecorder returns the Event Recorder
 func (sc *SchedulerCache) EventRecorder() record.EventRecorder {
 	return sc.Recorder
@@ -1227,6 +1256,14 @@ func (sc *SchedulerCache) AddBindTask(bindContext *BindContext) error {
 			task.UID, bindContext.TaskInfo.NodeName)
 	}
 
+	// The job or node may have moved to another scheduler shard since the snapshot,
+	// the task will be scheduled by its new owner.
+	if !sc.responsibleForJobShard(job) || !sc.responsibleForNodeShard(node) {
+		metrics.RegisterShardBindConflict()
+		return fmt.Errorf("failed to bind Task <%s/%s> to Node <%s>, it is not in the shard of current scheduler anymore",
+			task.Namespace, task.Name, node.Name)
+	}
+
 	originalStatus := task.Status
 	if err := job.UpdateTaskStatus(task, schedulingapi.Binding); err != nil {
 		return err
@@ -1240,6 +1277,13 @@ func (sc *SchedulerCache) AddBindTask(bindContext *BindContext) error {
 
 	// Add task to the node.
 	if err := node.AddTask(task); err != nil {
+		// The shared node may have been taken by another scheduler shard since the snapshot,
+		// the task will be scheduled again in the next session.
+		if sc.nodesShared() {
+			metrics.RegisterShardBindConflict()
+			klog.Warningf("Bind conflict of Task <%s/%s> on shared Node <%s>: %v",
+				task.Namespace, task.Name, node.Name, err)
+		}
 		// After failing to update task to a node we need to revert task status from Releasing,
 		// otherwise task might be stuck in the Releasing state indefinitely.
 		if err := job.UpdateTaskStatus(task, originalStatus); err != nil {
@@ -1384,6 +1428,10 @@ func (sc *SchedulerCache) Snapshot() *schedulingapi.ClusterInfo {
 			continue
 		}
 
+		if !sc.responsibleForNodeShard(value) {
+			continue
+		}
+
 		snapshot.Nodes[value.Name] = value.Clone()
 
 		if value.RevocableZone != "" {
@@ -1399,7 +1447,11 @@ func (sc *SchedulerCache) Snapshot() *schedulingapi.ClusterInfo {
 	snapshot.HyperNodesReadyToSchedule = sc.HyperNodesInfo.Ready()
 	sc.HyperNodesInfo.Unlock()
 
+	shardQueues := sc.shardQueues(sc.Queues)
 	for _, value := range sc.Queues {
+		if shardQueues != nil && !shardQueues[value.UID] {
+			continue
+		}
 		snapshot.Queues[value.UID] = value.Clone()
 	}
 
@@ -1441,12 +1493,24 @@ func (sc *SchedulerCache) Snapshot() *schedulingapi.ClusterInfo {
 			continue
 		}
 
+		// The resources of suspended jobs are released back to the queue.
+		if value.PodGroup.Annotations[schedulingapi.PodGroupSuspendedAnnotationKey] == "true" {
+			klog.V(4).Infof("The Job <%v/%v> is suspended, ignore it.", value.Namespace, value.Name)
+			continue
+		}
+
 		if _, found := snapshot.Queues[value.Queue]; !found {
 			klog.V(3).Infof("The Queue <%v> of Job <%v/%v> does not exist, ignore it.",
 				value.Queue, value.Namespace, value.Name)
 			continue
 		}
 
+		if !sc.responsibleForJobShard(value) {
+			klog.V(4).Infof("The Job <%v/%v> is not in the shard of current scheduler, ignore it.",
+				value.Namespace, value.Name)
+			continue
+		}
+
 		wg.Add(1)
 		go cloneJob(value)
 	}
@@ -1589,6 +1653,11 @@ func (sc *SchedulerCache) UpdateQueueStatus(queue *schedulingapi.QueueInfo) erro
 	return sc.StatusUpdater.UpdateQueueStatus(queue)
 }
 
+// ResponsibleForQueueStatus returns whether the current scheduler updates the status of the queue.
+func (sc *SchedulerCache) ResponsibleForQueueStatus(queue schedulingapi.QueueID) bool {
+	return sc.responsibleForQueueStatus(queue)
+}
+
 func (sc *SchedulerCache) recordPodGroupEvent(podGroup *schedulingapi.PodGroup, eventType, reason, msg string) {
 	if podGroup == nil {
 		return
@@ -67,6 +67,10 @@ type Cache interface {
 	// UpdateQueueStatus update queue status.
 	UpdateQueueStatus(queue *api.QueueInfo) error
 
+	// ResponsibleForQueueStatus returns whether the scheduler updates the status of the queue, the queues
+	// are shared by the active schedulers in sharding mode.
+	ResponsibleForQueueStatus(queue api.QueueID) bool
+
 	// Client returns the kubernetes clientSet, which can be used by plugins
 	Client() kubernetes.Interface
 
@@ -78,6 +82,9 @@ type Cache interface {
 
 	UpdateSchedulerNumaInfo(sets map[string]api.ResNumaSets) error
 
+	// UpdateSchedulerNumaDevices records the devices allocated to tasks, indexed by node name and task ID
+	UpdateSchedulerNumaDevices(allocations map[string]map[api.TaskID]api.ResNumaSets) error
+
 	// SharedInformerFactory return scheduler SharedInformerFactory
 	SharedInformerFactory() informers.SharedInformerFactory
 
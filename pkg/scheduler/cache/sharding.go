@@ -0,0 +1,141 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/cmd/scheduler/app/options"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/sharding"
+)
+
+const rootQueueID schedulingapi.QueueID = "root"
+
+// shardInfo holds the shard of the current scheduler when several schedulers are active at once.
+// In queue mode, each scheduler only schedules the jobs in its queues and all nodes are shared, so binding
+// to a node may conflict with another scheduler; in node mode, each scheduler only schedules its jobs to
+// the nodes in its node pools.
+//
+// As there is no leader in sharding mode, the binds are checked against the live cache before they are sent:
+// a bind is rejected if the job or node has moved to another scheduler since the snapshot, e.g. a scheduler
+// joined or left the shard group, or if a shared node no longer has enough idle resources for the task because
+// of the binds of other schedulers. The window left before the binds of other schedulers are observed is covered
+// by the API server, which rejects binding a pod twice, and by the kubelet, which rejects pods not fitting the node.
+type shardInfo struct {
+	shardingMode     string
+	shardNodeLabel   string
+	shardCoordinator shardOwner
+}
+
+// shardOwner assigns the keys of a shard group to the active schedulers, it is implemented by sharding.Coordinator.
+type shardOwner interface {
+	Owns(key string) bool
+	Run(stopCh <-chan struct{})
+}
+
+func newShardInfo(kubeClient kubernetes.Interface) shardInfo {
+	opt := options.ServerOpts
+	if opt == nil || opt.ShardingMode == sharding.ModeNone {
+		return shardInfo{}
+	}
+
+	coordinator := sharding.NewCoordinator(kubeClient, opt.LeaderElection.ResourceNamespace,
+		opt.LeaderElection.ResourceName, "", opt.ShardingLeaseDuration)
+	klog.Infof("Scheduler <%s> works in %s sharding mode", coordinator.Identity(), opt.ShardingMode)
+	return shardInfo{
+		shardingMode:     opt.ShardingMode,
+		shardNodeLabel:   opt.ShardingNodeLabel,
+		shardCoordinator: coordinator,
+	}
+}
+
+// nodesShared returns whether the nodes are shared by several active schedulers.
+func (si *shardInfo) nodesShared() bool {
+	return si.shardingMode == sharding.ModeQueue
+}
+
+// responsibleForJobShard returns whether the job is in the shard of current scheduler.
+func (si *shardInfo) responsibleForJobShard(job *schedulingapi.JobInfo) bool {
+	switch si.shardingMode {
+	case sharding.ModeQueue:
+		return si.shardCoordinator.Owns(string(job.Queue))
+	case sharding.ModeNode:
+		return si.shardCoordinator.Owns(job.Namespace + "/" + job.Name)
+	default:
+		return true
+	}
+}
+
+// responsibleForNodeShard returns whether the node is in the shard of current scheduler.
+func (si *shardInfo) responsibleForNodeShard(node *schedulingapi.NodeInfo) bool {
+	if si.shardingMode != sharding.ModeNode {
+		return true
+	}
+
+	key := node.Name
+	if si.shardNodeLabel != "" && node.Node != nil {
+		if pool, found := node.Node.Labels[si.shardNodeLabel]; found {
+			key = pool
+		}
+	}
+	return si.shardCoordinator.Owns(key)
+}
+
+// shardQueues returns the queues to be snapshotted by the current scheduler, nil means all queues. In queue mode,
+// they are the queues in its shard and their ancestors, which are kept for hierarchical queues only.
+func (si *shardInfo) shardQueues(queues map[schedulingapi.QueueID]*schedulingapi.QueueInfo) map[schedulingapi.QueueID]bool {
+	if si.shardingMode != sharding.ModeQueue {
+		return nil
+	}
+
+	selected := map[schedulingapi.QueueID]bool{}
+	for id := range queues {
+		if !si.shardCoordinator.Owns(string(id)) {
+			continue
+		}
+		for queue, found := queues[id]; found && !selected[queue.UID]; queue, found = queues[parentQueue(queue)] {
+			selected[queue.UID] = true
+		}
+	}
+	return selected
+}
+
+func parentQueue(queue *schedulingapi.QueueInfo) schedulingapi.QueueID {
+	if queue.UID == rootQueueID {
+		return ""
+	}
+	if queue.Queue != nil && queue.Queue.Spec.Parent != "" {
+		return schedulingapi.QueueID(queue.Queue.Spec.Parent)
+	}
+	return rootQueueID
+}
+
+// responsibleForQueueStatus returns whether the current scheduler updates the status of the queue. In queue mode,
+// only the queues in its shard are updated; in node mode, no scheduler sees all jobs of a queue, so none of them
+// updates the queue status.
+func (si *shardInfo) responsibleForQueueStatus(queue schedulingapi.QueueID) bool {
+	switch si.shardingMode {
+	case sharding.ModeQueue:
+		return si.shardCoordinator.Owns(string(queue))
+	case sharding.ModeNode:
+		return false
+	default:
+		return true
+	}
+}
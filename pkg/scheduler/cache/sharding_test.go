@@ -0,0 +1,142 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/apis/pkg/apis/scheduling"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/sharding"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+type fakeShardOwner struct {
+	owned map[string]bool
+}
+
+func (f *fakeShardOwner) Owns(key string) bool {
+	return f.owned[key]
+}
+
+func (f *fakeShardOwner) Run(stopCh <-chan struct{}) {}
+
+func buildQueueInfo(name, parent string) *api.QueueInfo {
+	return api.NewQueueInfo(&scheduling.Queue{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       scheduling.QueueSpec{Parent: parent},
+	})
+}
+
+func TestShardQueues(t *testing.T) {
+	queues := map[api.QueueID]*api.QueueInfo{}
+	for _, queue := range []*api.QueueInfo{
+		buildQueueInfo("root", ""),
+		buildQueueInfo("dev", "root"),
+		buildQueueInfo("dev-a", "dev"),
+		buildQueueInfo("prod", ""),
+	} {
+		queues[queue.UID] = queue
+	}
+	owner := &fakeShardOwner{owned: map[string]bool{"dev-a": true}}
+
+	tests := []struct {
+		name           string
+		mode           string
+		expectedQueues map[api.QueueID]bool
+		statusUpdated  map[api.QueueID]bool
+	}{
+		{
+			name:          "no sharding",
+			mode:          sharding.ModeNone,
+			statusUpdated: map[api.QueueID]bool{"root": true, "dev": true, "dev-a": true, "prod": true},
+		},
+		{
+			name:           "queue mode keeps the queues in shard and their ancestors",
+			mode:           sharding.ModeQueue,
+			expectedQueues: map[api.QueueID]bool{"root": true, "dev": true, "dev-a": true},
+			statusUpdated:  map[api.QueueID]bool{"dev-a": true},
+		},
+		{
+			name:          "node mode does not update queue status",
+			mode:          sharding.ModeNode,
+			statusUpdated: map[api.QueueID]bool{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			si := &shardInfo{shardingMode: tt.mode, shardCoordinator: owner}
+			if got := si.shardQueues(queues); !reflect.DeepEqual(got, tt.expectedQueues) {
+				t.Errorf("expected queues %v, got %v", tt.expectedQueues, got)
+			}
+			for id := range queues {
+				if got := si.responsibleForQueueStatus(id); got != tt.statusUpdated[id] {
+					t.Errorf("expected status of queue %s updated %v, got %v", id, tt.statusUpdated[id], got)
+				}
+			}
+		})
+	}
+}
+
+func TestBindTaskAfterShardChange(t *testing.T) {
+	owner := &fakeShardOwner{owned: map[string]bool{"q1": true}}
+	cache := &SchedulerCache{
+		Jobs:            make(map[api.JobID]*api.JobInfo),
+		Nodes:           make(map[string]*api.NodeInfo),
+		Binder:          util.NewFakeBinder(0),
+		BindFlowChannel: make(chan *BindContext, 5000),
+		shardInfo:       shardInfo{shardingMode: sharding.ModeQueue, shardCoordinator: owner},
+	}
+	cache.AddOrUpdateNode(buildNode("n1", api.BuildResourceList("4000m", "10G", []api.ScalarResource{{Name: "pods", Value: "10"}}...)))
+
+	var tasks []*api.TaskInfo
+	for _, name := range []string{"p1", "p2"} {
+		pod := buildPod("c1", name, "", v1.PodPending, api.BuildResourceList("1000m", "1G"),
+			[]metav1.OwnerReference{buildOwnerReference("j1")}, make(map[string]string))
+		cache.AddPod(pod)
+		task := api.NewTaskInfo(pod)
+		task.Job = "j1"
+		if err := cache.addTask(task); err != nil {
+			t.Fatalf("failed to add task %v", err)
+		}
+		task.NodeName = "n1"
+		tasks = append(tasks, task)
+	}
+	cache.Jobs["j1"].Queue = "q1"
+
+	if err := cache.AddBindTask(&BindContext{TaskInfo: tasks[0]}); err != nil {
+		t.Fatalf("expected the task in shard to be bound, got %v", err)
+	}
+
+	// Another scheduler joins the shard group and takes over the queue while the second task is being bound.
+	delete(owner.owned, "q1")
+	nodeBeforeBind := cache.Nodes["n1"].Clone()
+	if err := cache.AddBindTask(&BindContext{TaskInfo: tasks[1]}); err == nil {
+		t.Errorf("expected the bind of the task moved to another shard to be rejected")
+	}
+	_, task, err := cache.findJobAndTask(tasks[1])
+	if err != nil || task.Status != api.Pending {
+		t.Errorf("expected the task to stay pending, got %v, %v", task, err)
+	}
+	if !reflect.DeepEqual(nodeBeforeBind, cache.Nodes["n1"].Clone()) {
+		t.Errorf("expected the node to remain the same after the rejected bind")
+	}
+}
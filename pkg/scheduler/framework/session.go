@@ -282,6 +282,10 @@ func updateQueueStatus(ssn *Session) {
 
 	// update queue status
 	for queueID := range ssn.Queues {
+		if !ssn.cache.ResponsibleForQueueStatus(queueID) {
+			klog.V(5).Infof("Queue <%s> status is not updated by the current scheduler shard.", queueID)
+			continue
+		}
 		// convert api.Resource to v1.ResourceList
 		var queueStatus = util.ConvertRes2ResList(allocatedResources[queueID]).DeepCopy()
 		if queueID == rootQueue {
@@ -750,6 +754,11 @@ func (ssn *Session) UpdateSchedulerNumaInfo(AllocatedSets map[string]api.ResNuma
 	ssn.cache.UpdateSchedulerNumaInfo(AllocatedSets)
 }
 
+// UpdateSchedulerNumaDevices update the devices allocated to tasks in SchedulerNumaInfo
+func (ssn *Session) UpdateSchedulerNumaDevices(allocations map[string]map[api.TaskID]api.ResNumaSets) {
+	ssn.cache.UpdateSchedulerNumaDevices(allocations)
+}
+
 // KubeClient returns the kubernetes client
 func (ssn *Session) KubeClient() kubernetes.Interface {
 	return ssn.kubeClient
@@ -784,6 +793,27 @@ func (ssn *Session) RecordPodGroupEvent(podGroup *api.PodGroup, eventType, reaso
 	ssn.recorder.Eventf(pg, eventType, reason, msg)
 }
 
+// JobGroupMembers returns all jobs in the session which belong to the same job group as the given job,
+// including the job itself, sorted by job UID. It returns nil if the job is not in any job group.
+func (ssn *Session) JobGroupMembers(job *api.JobInfo) []*api.JobInfo {
+	groupKey := job.JobGroupKey()
+	if len(groupKey) == 0 {
+		return nil
+	}
+
+	var members []*api.JobInfo
+	for _, j := range ssn.Jobs {
+		if j.JobGroupKey() == groupKey {
+			members = append(members, j)
+		}
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].UID < members[j].UID
+	})
+
+	return members
+}
+
 // SharedDRAManager returns the shared DRAManager from cache
 func (ssn *Session) SharedDRAManager() k8sframework.SharedDRAManager {
 	return ssn.cache.SharedDRAManager()
@@ -141,6 +141,14 @@ var (
 		}, []string{"job_id"},
 	)
 
+	shardBindConflicts = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "shard_bind_conflicts_total",
+			Help:      "Number of tasks failed to bind because the shared node was taken by another scheduler shard",
+		},
+	)
+
 	unscheduleJobCount = promauto.NewGauge(
 		prometheus.GaugeOpts{
 			Subsystem: VolcanoSubSystemName,
@@ -224,6 +232,11 @@ func UpdateUnscheduleJobCount(jobCount int) {
 	unscheduleJobCount.Set(float64(jobCount))
 }
 
+// RegisterShardBindConflict records a bind conflict with another scheduler shard
+func RegisterShardBindConflict() {
+	shardBindConflicts.Inc()
+}
+
 // DurationInMicroseconds gets the time in microseconds.
 func DurationInMicroseconds(duration time.Duration) float64 {
 	return float64(duration.Nanoseconds()) / float64(time.Microsecond.Nanoseconds())
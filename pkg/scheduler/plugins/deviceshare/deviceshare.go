@@ -20,6 +20,7 @@ import (
 	"context"
 	"math"
 	"reflect"
+	"strings"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
@@ -28,6 +29,7 @@ import (
 	"volcano.sh/volcano/pkg/scheduler/api"
 	"volcano.sh/volcano/pkg/scheduler/api/devices"
 	"volcano.sh/volcano/pkg/scheduler/api/devices/config"
+	"volcano.sh/volcano/pkg/scheduler/api/devices/generic"
 	"volcano.sh/volcano/pkg/scheduler/api/devices/nvidia/gpushare"
 	"volcano.sh/volcano/pkg/scheduler/api/devices/nvidia/vgpu"
 	"volcano.sh/volcano/pkg/scheduler/framework"
@@ -48,6 +50,10 @@ const (
 
 	KnownGeometriesCMName      = "deviceshare.KnownGeometriesCMName"
 	KnownGeometriesCMNamespace = "deviceshare.KnownGeometriesCMNamespace"
+
+	// GenericDeviceResources is the comma separated resource names of the devices, e.g. amd.com/gpu,huawei.com/npu,
+	// which are published in node annotation and scheduled by the generic device backend
+	GenericDeviceResources = "deviceshare.GenericDeviceResources"
 )
 
 type deviceSharePlugin struct {
@@ -83,6 +89,16 @@ func enablePredicate(dsp *deviceSharePlugin) {
 	args.GetString(&dsp.schedulePolicy, SchedulePolicyArgument)
 	args.GetInt(&dsp.scheduleWeight, ScheduleWeight)
 
+	genericDeviceResources := ""
+	args.GetString(&genericDeviceResources, GenericDeviceResources)
+	generic.EnabledResources = nil
+	for _, resourceName := range strings.Split(genericDeviceResources, ",") {
+		if resourceName = strings.TrimSpace(resourceName); resourceName != "" {
+			generic.EnabledResources = append(generic.EnabledResources, resourceName)
+		}
+	}
+	generic.SchedulePolicy = dsp.schedulePolicy
+
 	if gpushare.GpuSharingEnable && gpushare.GpuNumberEnable {
 		klog.Fatal("can not define true in both gpu sharing and gpu number")
 	}
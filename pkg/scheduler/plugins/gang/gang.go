@@ -40,6 +40,13 @@ import (
 // PluginName indicates name of volcano scheduler plugin.
 const PluginName = "gang"
 
+const (
+	// NotEnoughJobsOfGroupReason is the reason when not all jobs of a job group are present.
+	NotEnoughJobsOfGroupReason = "NotEnoughJobsOfGroup"
+	// JobGroupMemberInvalidReason is the reason when another job of the same job group is invalid.
+	JobGroupMemberInvalidReason = "JobGroupMemberInvalid"
+)
+
 type gangPlugin struct {
 	// Arguments given for the plugin
 	pluginArguments framework.Arguments
@@ -64,21 +71,35 @@ func (gp *gangPlugin) OnSessionOpen(ssn *framework.Session) {
 			}
 		}
 
-		if valid := job.CheckTaskValid(); !valid {
-			return &api.ValidateResult{
-				Pass:    false,
-				Reason:  v1beta1.NotEnoughPodsOfTaskReason,
-				Message: "Not enough valid pods of each task for gang-scheduling",
-			}
+		if vr := validJob(job); vr != nil {
+			return vr
 		}
 
-		vtn := job.ValidTaskNum()
-		if vtn < job.MinAvailable {
+		// All jobs in a job group are treated as a single gang: the job is valid only when
+		// all expected members are present and each of them is valid on its own.
+		members := ssn.JobGroupMembers(job)
+		if len(members) == 0 {
+			return nil
+		}
+		if int32(len(members)) < job.JobGroupMinMember {
 			return &api.ValidateResult{
 				Pass:   false,
-				Reason: v1beta1.NotEnoughPodsReason,
-				Message: fmt.Sprintf("Not enough valid tasks for gang-scheduling, valid: %d, min: %d",
-					vtn, job.MinAvailable),
+				Reason: NotEnoughJobsOfGroupReason,
+				Message: fmt.Sprintf("Not enough jobs of group <%s> for gang-scheduling, present: %d, min: %d",
+					job.JobGroup, len(members), job.JobGroupMinMember),
+			}
+		}
+		for _, member := range members {
+			if member.UID == job.UID {
+				continue
+			}
+			if vr := validJob(member); vr != nil {
+				return &api.ValidateResult{
+					Pass:   false,
+					Reason: JobGroupMemberInvalidReason,
+					Message: fmt.Sprintf("Job <%s/%s> of group <%s> is not valid: %s",
+						member.Namespace, member.Name, job.JobGroup, vr.Message),
+				}
 			}
 		}
 		return nil
@@ -165,6 +186,28 @@ func (gp *gangPlugin) OnSessionOpen(ssn *framework.Session) {
 	ssn.AddJobStarvingFns(gp.Name(), jobStarvingFn)
 }
 
+// validJob checks whether the job itself satisfies gang-scheduling, regardless of its job group.
+func validJob(job *api.JobInfo) *api.ValidateResult {
+	if valid := job.CheckTaskValid(); !valid {
+		return &api.ValidateResult{
+			Pass:    false,
+			Reason:  v1beta1.NotEnoughPodsOfTaskReason,
+			Message: "Not enough valid pods of each task for gang-scheduling",
+		}
+	}
+
+	vtn := job.ValidTaskNum()
+	if vtn < job.MinAvailable {
+		return &api.ValidateResult{
+			Pass:   false,
+			Reason: v1beta1.NotEnoughPodsReason,
+			Message: fmt.Sprintf("Not enough valid tasks for gang-scheduling, valid: %d, min: %d",
+				vtn, job.MinAvailable),
+		}
+	}
+	return nil
+}
+
 func (gp *gangPlugin) OnSessionClose(ssn *framework.Session) {
 	var unreadyTaskCount int32
 	var unScheduleJobCount int
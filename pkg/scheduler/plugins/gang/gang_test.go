@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gang
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+
+	schedulingv1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/uthelper"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func buildJobGroupPodGroup(name string, minMember int32, group, groupMinMember string) *schedulingv1.PodGroup {
+	pg := util.BuildPodGroup(name, "c1", "c1", minMember, nil, schedulingv1.PodGroupInqueue)
+	pg.Annotations = map[string]string{
+		api.JobGroupAnnotationKey:          group,
+		api.JobGroupMinMemberAnnotationKey: groupMinMember,
+	}
+	return pg
+}
+
+func TestJobGroupValid(t *testing.T) {
+	resource := api.BuildResourceList("1", "1G")
+	p1 := util.BuildPod("c1", "p1", "", v1.PodPending, resource, "pg1", nil, nil)
+	p2 := util.BuildPod("c1", "p2", "", v1.PodPending, resource, "pg2", nil, nil)
+
+	tests := []struct {
+		uthelper.TestCommonStruct
+		// expectedReasons are the reasons of the validation results of jobs, empty means valid
+		expectedReasons map[api.JobID]string
+	}{
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "jobs are valid when all members of the job group are present and valid",
+				PodGroups: []*schedulingv1.PodGroup{
+					buildJobGroupPodGroup("pg1", 1, "rl", "2"),
+					buildJobGroupPodGroup("pg2", 1, "rl", "2"),
+				},
+				Pods: []*v1.Pod{p1, p2},
+			},
+			expectedReasons: map[api.JobID]string{"c1/pg1": "", "c1/pg2": ""},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "job is invalid when the job group has not enough jobs",
+				PodGroups: []*schedulingv1.PodGroup{
+					buildJobGroupPodGroup("pg1", 1, "rl", "2"),
+				},
+				Pods: []*v1.Pod{p1},
+			},
+			expectedReasons: map[api.JobID]string{"c1/pg1": NotEnoughJobsOfGroupReason},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "job is invalid when another member of the job group is invalid",
+				PodGroups: []*schedulingv1.PodGroup{
+					buildJobGroupPodGroup("pg1", 1, "rl", "2"),
+					buildJobGroupPodGroup("pg2", 2, "rl", "2"),
+				},
+				Pods: []*v1.Pod{p1, p2},
+			},
+			expectedReasons: map[api.JobID]string{
+				"c1/pg1": JobGroupMemberInvalidReason,
+				"c1/pg2": string(schedulingv1.NotEnoughPodsReason),
+			},
+		},
+	}
+
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name: PluginName,
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			test.Plugins = map[string]framework.PluginBuilder{PluginName: New}
+			test.Queues = []*schedulingv1.Queue{util.BuildQueue("c1", 1, nil)}
+			ssn := test.RegisterSession(tiers, nil)
+			defer test.Close()
+			for jobID, expected := range test.expectedReasons {
+				job, found := ssn.Jobs[jobID]
+				if !found {
+					t.Fatalf("job %s is not found in session", jobID)
+				}
+				var reason string
+				if vr := ssn.JobValid(job); vr != nil && !vr.Pass {
+					reason = vr.Reason
+				}
+				if reason != expected {
+					t.Errorf("expect reason of job %s to be %q, but got %q", jobID, expected, reason)
+				}
+			}
+		})
+	}
+}
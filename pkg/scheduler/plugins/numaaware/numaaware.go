@@ -35,6 +35,7 @@ import (
 	"volcano.sh/volcano/pkg/scheduler/framework"
 	"volcano.sh/volcano/pkg/scheduler/plugins/numaaware/policy"
 	"volcano.sh/volcano/pkg/scheduler/plugins/numaaware/provider/cpumanager"
+	"volcano.sh/volcano/pkg/scheduler/plugins/numaaware/provider/devicemanager"
 	"volcano.sh/volcano/pkg/scheduler/plugins/util"
 )
 
@@ -43,6 +44,13 @@ const (
 	PluginName = "numa-aware"
 	// NumaTopoWeight indicates the weight of numa-aware plugin.
 	NumaTopoWeight = "weight"
+	// NumaGPUResourceName indicates the resource name of GPU aligned by numa-aware plugin.
+	NumaGPUResourceName = "gpu-resource-name"
+	// NumaNICResourceName indicates the resource name of RDMA NIC aligned by numa-aware plugin.
+	NumaNICResourceName = "nic-resource-name"
+
+	defaultGPUResourceName = "nvidia.com/gpu"
+	defaultNICResourceName = "rdma/hca"
 )
 
 type numaPlugin struct {
@@ -63,7 +71,16 @@ func New(arguments framework.Arguments) framework.Plugin {
 		taskBindNodeMap: make(map[api.TaskID]string),
 	}
 
-	plugin.hintProviders = append(plugin.hintProviders, cpumanager.NewProvider())
+	gpuResourceName := defaultGPUResourceName
+	arguments.GetString(&gpuResourceName, NumaGPUResourceName)
+	nicResourceName := defaultNICResourceName
+	arguments.GetString(&nicResourceName, NumaNICResourceName)
+
+	plugin.hintProviders = append(plugin.hintProviders,
+		cpumanager.NewProvider(),
+		devicemanager.NewProvider("gpuMng", v1.ResourceName(gpuResourceName)),
+		devicemanager.NewProvider("nicMng", v1.ResourceName(nicResourceName)),
+	)
 	return plugin
 }
 
@@ -236,9 +253,17 @@ func getNodeNumaNumForTask(nodeInfo []*api.NodeInfo, resAssignMap map[string]api
 	workqueue.ParallelizeUntil(context.TODO(), 16, len(nodeInfo), func(index int) {
 		node := nodeInfo[index]
 		assignCpus := resAssignMap[node.Name][string(v1.ResourceCPU)]
+		mask := getNumaNodeMaskForCPUID(assignCpus, node.NumaSchedulerInfo.CPUDetail)
+		for resName, affinity := range node.NumaSchedulerInfo.DeviceNumaAffinity {
+			for _, deviceID := range resAssignMap[node.Name][resName].List() {
+				if numaID, ok := affinity[deviceID]; ok {
+					mask.Add(numaID)
+				}
+			}
+		}
 		nodeNumaCnts[index] = api.ScoredNode{
 			NodeName: node.Name,
-			Score:    int64(getNumaNodeCntForCPUID(assignCpus, node.NumaSchedulerInfo.CPUDetail)),
+			Score:    int64(mask.Count()),
 		}
 	})
 
@@ -246,6 +271,10 @@ func getNodeNumaNumForTask(nodeInfo []*api.NodeInfo, resAssignMap map[string]api
 }
 
 func getNumaNodeCntForCPUID(cpus cpuset.CPUSet, cpuDetails topology.CPUDetails) int {
+	return getNumaNodeMaskForCPUID(cpus, cpuDetails).Count()
+}
+
+func getNumaNodeMaskForCPUID(cpus cpuset.CPUSet, cpuDetails topology.CPUDetails) bitmask.BitMask {
 	mask, _ := bitmask.NewBitMask()
 	s := cpus.List()
 
@@ -253,7 +282,7 @@ func getNumaNodeCntForCPUID(cpus cpuset.CPUSet, cpuDetails topology.CPUDetails)
 		mask.Add(cpuDetails[cpuID].NUMANodeID)
 	}
 
-	return mask.Count()
+	return mask
 }
 
 func (pp *numaPlugin) OnSessionClose(ssn *framework.Session) {
@@ -262,6 +291,7 @@ func (pp *numaPlugin) OnSessionClose(ssn *framework.Session) {
 	}
 
 	allocatedResSet := make(map[string]api.ResNumaSets)
+	allocatedDevices := make(map[string]map[api.TaskID]api.ResNumaSets)
 	for taskID, nodeName := range pp.taskBindNodeMap {
 		if _, existed := pp.assignRes[taskID]; !existed {
 			continue
@@ -276,6 +306,10 @@ func (pp *numaPlugin) OnSessionClose(ssn *framework.Session) {
 		}
 
 		resSet := pp.assignRes[taskID][nodeName]
+		if _, existed := allocatedDevices[nodeName]; !existed {
+			allocatedDevices[nodeName] = make(map[api.TaskID]api.ResNumaSets)
+		}
+		allocatedDevices[nodeName][taskID] = resSet
 		for resName, set := range resSet {
 			if _, existed := allocatedResSet[nodeName][resName]; !existed {
 				allocatedResSet[nodeName][resName] = cpuset.New()
@@ -287,4 +321,5 @@ func (pp *numaPlugin) OnSessionClose(ssn *framework.Session) {
 
 	klog.V(4).Infof("[numaPlugin]allocatedResSet: %v", allocatedResSet)
 	ssn.UpdateSchedulerNumaInfo(allocatedResSet)
+	ssn.UpdateSchedulerNumaDevices(allocatedDevices)
 }
@@ -0,0 +1,174 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package devicemanager
+
+import (
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager/bitmask"
+	"k8s.io/utils/cpuset"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/plugins/numaaware/policy"
+)
+
+type deviceMng struct {
+	name         string
+	resourceName v1.ResourceName
+}
+
+// NewProvider return a new provider for devices of the resource, e.g. GPU or RDMA NIC,
+// whose NUMA affinity is published in the node annotation.
+func NewProvider(name string, resourceName v1.ResourceName) policy.HintProvider {
+	return &deviceMng{
+		name:         name,
+		resourceName: resourceName,
+	}
+}
+
+// Name return the device manager name
+func (mng *deviceMng) Name() string {
+	return mng.name
+}
+
+// requestedDevices return the integer num of requested devices
+func (mng *deviceMng) requestedDevices(container *v1.Container) int {
+	quantity, ok := container.Resources.Requests[mng.resourceName]
+	if !ok {
+		quantity, ok = container.Resources.Limits[mng.resourceName]
+		if !ok {
+			return 0
+		}
+	}
+
+	return int(quantity.Value())
+}
+
+// availableDevices return the devices which are not allocated yet
+func (mng *deviceMng) availableDevices(resNumaSets api.ResNumaSets) cpuset.CPUSet {
+	return resNumaSets[string(mng.resourceName)]
+}
+
+// numaNodes return all NUMA nodes on the node, including the ones only known from device affinity
+func numaNodes(topoInfo *api.NumatopoInfo, affinity map[int]int) []int {
+	nodes := topoInfo.CPUDetail.NUMANodes()
+	for _, numaID := range affinity {
+		nodes = nodes.Union(cpuset.New(numaID))
+	}
+	return nodes.List()
+}
+
+// generateDeviceTopologyHints return the numa topology hints based on the available devices
+func generateDeviceTopologyHints(available cpuset.CPUSet, affinity map[int]int, numaIDs []int, request int) []policy.TopologyHint {
+	minAffinitySize := len(numaIDs)
+	hints := []policy.TopologyHint{}
+	bitmask.IterateBitMasks(numaIDs, func(mask bitmask.BitMask) {
+		// First, update minAffinitySize for the current request size.
+		devicesInMask := 0
+		for _, numaID := range affinity {
+			if mask.IsSet(numaID) {
+				devicesInMask++
+			}
+		}
+		if devicesInMask >= request && mask.Count() < minAffinitySize {
+			minAffinitySize = mask.Count()
+		}
+
+		// Then check to see if enough available devices remain on the current
+		// NUMA node combination to satisfy the device request.
+		numMatching := 0
+		for _, deviceID := range available.List() {
+			if numaID, ok := affinity[deviceID]; ok && mask.IsSet(numaID) {
+				numMatching++
+			}
+		}
+		if numMatching < request {
+			return
+		}
+
+		hints = append(hints, policy.TopologyHint{
+			NUMANodeAffinity: mask,
+			Preferred:        false,
+		})
+	})
+
+	// Only the hints with a minimal set of numa nodes are preferred.
+	for i := range hints {
+		if hints[i].NUMANodeAffinity.Count() == minAffinitySize {
+			hints[i].Preferred = true
+		}
+	}
+
+	return hints
+}
+
+func (mng *deviceMng) GetTopologyHints(container *v1.Container,
+	topoInfo *api.NumatopoInfo, resNumaSets api.ResNumaSets) map[string][]policy.TopologyHint {
+	request := mng.requestedDevices(container)
+	if request == 0 {
+		return nil
+	}
+
+	affinity := topoInfo.DeviceNumaAffinity[string(mng.resourceName)]
+	if len(affinity) == 0 {
+		klog.V(4).Infof("no numa affinity of %s is published, container %s has no preference", mng.resourceName, container.Name)
+		return nil
+	}
+
+	available := mng.availableDevices(resNumaSets)
+	klog.V(4).Infof("%s requested: %d, available devices: %v", mng.resourceName, request, available)
+	return map[string][]policy.TopologyHint{
+		string(mng.resourceName): generateDeviceTopologyHints(available, affinity, numaNodes(topoInfo, affinity), request),
+	}
+}
+
+func (mng *deviceMng) Allocate(container *v1.Container, bestHit *policy.TopologyHint,
+	topoInfo *api.NumatopoInfo, resNumaSets api.ResNumaSets) map[string]cpuset.CPUSet {
+	request := mng.requestedDevices(container)
+	affinity := topoInfo.DeviceNumaAffinity[string(mng.resourceName)]
+	if request == 0 || len(affinity) == 0 {
+		return nil
+	}
+
+	// Take the devices aligned with the best hint first, then any remaining ones.
+	devices := mng.availableDevices(resNumaSets).List()
+	sort.SliceStable(devices, func(i, j int) bool {
+		return aligned(bestHit, affinity, devices[i]) && !aligned(bestHit, affinity, devices[j])
+	})
+	if len(devices) < request {
+		klog.Warningf("not enough %s for container %s, requested: %d, available: %v",
+			mng.resourceName, container.Name, request, devices)
+		return map[string]cpuset.CPUSet{
+			string(mng.resourceName): cpuset.New(),
+		}
+	}
+
+	return map[string]cpuset.CPUSet{
+		string(mng.resourceName): cpuset.New(devices[:request]...),
+	}
+}
+
+// aligned return whether the device is on the NUMA nodes of the hint
+func aligned(hint *policy.TopologyHint, affinity map[int]int, deviceID int) bool {
+	if hint == nil || hint.NUMANodeAffinity == nil {
+		return false
+	}
+	numaID, ok := affinity[deviceID]
+	return ok && hint.NUMANodeAffinity.IsSet(numaID)
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package devicemanager
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager/topology"
+	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager/bitmask"
+	"k8s.io/utils/cpuset"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/plugins/numaaware/policy"
+)
+
+const gpuResource = "nvidia.com/gpu"
+
+var numaInfo = api.NumatopoInfo{
+	CPUDetail: topology.CPUDetails{
+		0: {NUMANodeID: 0, CoreID: 0, SocketID: 0},
+		1: {NUMANodeID: 0, CoreID: 0, SocketID: 0},
+		2: {NUMANodeID: 1, CoreID: 1, SocketID: 1},
+		3: {NUMANodeID: 1, CoreID: 1, SocketID: 1},
+	},
+	DeviceNumaAffinity: map[string]map[int]int{
+		gpuResource: {0: 0, 1: 0, 2: 1, 3: 1},
+	},
+}
+
+func gpuContainer(num int64) v1.Container {
+	return v1.Container{
+		Resources: v1.ResourceRequirements{
+			Limits: v1.ResourceList{
+				gpuResource: *resource.NewQuantity(num, ""),
+			},
+		},
+	}
+}
+
+func newMask(bits ...int) bitmask.BitMask {
+	mask, _ := bitmask.NewBitMask(bits...)
+	return mask
+}
+
+func Test_GetTopologyHints(t *testing.T) {
+	testCases := []struct {
+		name        string
+		container   v1.Container
+		resNumaSets api.ResNumaSets
+		expect      []policy.TopologyHint
+	}{
+		{
+			name:        "no gpu requested",
+			container:   v1.Container{},
+			resNumaSets: api.ResNumaSets{},
+			expect:      nil,
+		},
+		{
+			name:      "all gpus available",
+			container: gpuContainer(2),
+			resNumaSets: api.ResNumaSets{
+				gpuResource: cpuset.New(0, 1, 2, 3),
+			},
+			expect: []policy.TopologyHint{
+				{NUMANodeAffinity: newMask(0), Preferred: true},
+				{NUMANodeAffinity: newMask(1), Preferred: true},
+				{NUMANodeAffinity: newMask(0, 1), Preferred: false},
+			},
+		},
+		{
+			name:      "gpus on numa 0 partly allocated",
+			container: gpuContainer(2),
+			resNumaSets: api.ResNumaSets{
+				gpuResource: cpuset.New(1, 2, 3),
+			},
+			expect: []policy.TopologyHint{
+				{NUMANodeAffinity: newMask(1), Preferred: true},
+				{NUMANodeAffinity: newMask(0, 1), Preferred: false},
+			},
+		},
+		{
+			name:      "gpus across numa nodes required",
+			container: gpuContainer(3),
+			resNumaSets: api.ResNumaSets{
+				gpuResource: cpuset.New(0, 1, 2, 3),
+			},
+			expect: []policy.TopologyHint{
+				{NUMANodeAffinity: newMask(0, 1), Preferred: true},
+			},
+		},
+	}
+
+	for _, testcase := range testCases {
+		provider := NewProvider("gpuMng", gpuResource)
+		hints := provider.GetTopologyHints(&testcase.container, &numaInfo, testcase.resNumaSets)
+		if testcase.expect == nil {
+			if hints != nil {
+				t.Errorf("%s failed, expect no hints, got %v", testcase.name, hints)
+			}
+			continue
+		}
+		if !equality.Semantic.DeepEqual(hints[gpuResource], testcase.expect) {
+			t.Errorf("%s failed, expect %v, got %v", testcase.name, testcase.expect, hints[gpuResource])
+		}
+	}
+}
+
+func Test_Allocate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		container   v1.Container
+		bestHit     *policy.TopologyHint
+		resNumaSets api.ResNumaSets
+		expect      cpuset.CPUSet
+	}{
+		{
+			name:      "allocate gpus aligned with numa 1",
+			container: gpuContainer(2),
+			bestHit:   &policy.TopologyHint{NUMANodeAffinity: newMask(1), Preferred: true},
+			resNumaSets: api.ResNumaSets{
+				gpuResource: cpuset.New(0, 1, 2, 3),
+			},
+			expect: cpuset.New(2, 3),
+		},
+		{
+			name:      "allocate aligned gpus first",
+			container: gpuContainer(3),
+			bestHit:   &policy.TopologyHint{NUMANodeAffinity: newMask(1), Preferred: false},
+			resNumaSets: api.ResNumaSets{
+				gpuResource: cpuset.New(0, 2, 3),
+			},
+			expect: cpuset.New(0, 2, 3),
+		},
+		{
+			name:      "not enough gpus",
+			container: gpuContainer(2),
+			bestHit:   &policy.TopologyHint{NUMANodeAffinity: newMask(0, 1), Preferred: false},
+			resNumaSets: api.ResNumaSets{
+				gpuResource: cpuset.New(3),
+			},
+			expect: cpuset.New(),
+		},
+	}
+
+	for _, testcase := range testCases {
+		provider := NewProvider("gpuMng", gpuResource)
+		result := provider.Allocate(&testcase.container, testcase.bestHit, &numaInfo, testcase.resNumaSets)
+		if !result[gpuResource].Equals(testcase.expect) {
+			t.Errorf("%s failed, expect %v, got %v", testcase.name, testcase.expect, result[gpuResource])
+		}
+	}
+}
+
+func Test_HintsAfterAllocation(t *testing.T) {
+	topoInfo := &api.NumatopoInfo{
+		NumaResMap: map[string]*api.ResourceInfo{},
+		CPUDetail:  numaInfo.CPUDetail,
+	}
+	topoInfo.SetDeviceNumaAffinity(numaInfo.DeviceNumaAffinity, func(api.TaskID) bool { return true })
+	resNumaSets := api.ResNumaSets{gpuResource: topoInfo.NumaResMap[gpuResource].Allocatable.Clone()}
+
+	provider := NewProvider("gpuMng", gpuResource)
+	container := gpuContainer(2)
+	hints := provider.GetTopologyHints(&container, topoInfo, resNumaSets)[gpuResource]
+	expect := []policy.TopologyHint{
+		{NUMANodeAffinity: newMask(0), Preferred: true},
+		{NUMANodeAffinity: newMask(1), Preferred: true},
+		{NUMANodeAffinity: newMask(0, 1), Preferred: false},
+	}
+	if !equality.Semantic.DeepEqual(hints, expect) {
+		t.Fatalf("expect hints %v for the first pod, got %v", expect, hints)
+	}
+
+	assigned := provider.Allocate(&container, &hints[0], topoInfo, resNumaSets)
+	resNumaSets.Allocate(assigned)
+	topoInfo.AllocateDevices("pod1", assigned)
+
+	// the gpus on numa 0 are taken by the first pod
+	hints = provider.GetTopologyHints(&container, topoInfo, resNumaSets)[gpuResource]
+	expect = []policy.TopologyHint{
+		{NUMANodeAffinity: newMask(1), Preferred: true},
+		{NUMANodeAffinity: newMask(0, 1), Preferred: false},
+	}
+	if !equality.Semantic.DeepEqual(hints, expect) {
+		t.Errorf("expect hints %v for the second pod, got %v", expect, hints)
+	}
+	if allocatable := topoInfo.NumaResMap[gpuResource].Allocatable; !allocatable.Equals(cpuset.New(2, 3)) {
+		t.Errorf("expect gpus 2,3 allocatable in numa info, got %v", allocatable)
+	}
+
+	// the gpus are released once the first pod no longer holds them
+	topoInfo.SetDeviceNumaAffinity(numaInfo.DeviceNumaAffinity, func(api.TaskID) bool { return false })
+	if allocatable := topoInfo.NumaResMap[gpuResource].Allocatable; !allocatable.Equals(cpuset.New(0, 1, 2, 3)) {
+		t.Errorf("expect all gpus allocatable after release, got %v", allocatable)
+	}
+}
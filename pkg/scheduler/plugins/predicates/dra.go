@@ -0,0 +1,215 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predicates
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	resourceapi "k8s.io/api/resource/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/component-helpers/scheduling/corev1/nodeaffinity"
+	"k8s.io/dynamic-resource-allocation/cel"
+	"k8s.io/dynamic-resource-allocation/resourceclaim"
+	"k8s.io/dynamic-resource-allocation/structured"
+	k8sframework "k8s.io/kubernetes/pkg/scheduler/framework"
+	"k8s.io/kubernetes/pkg/scheduler/framework/plugins/feature"
+	"k8s.io/utils/ptr"
+)
+
+// draSimulationStateKey is the key of the pods removed from the node in preemption simulation.
+const draSimulationStateKey k8sframework.StateKey = PluginName + "/DynamicResourcesSimulation"
+
+// draSimulationState records the victims removed from the node in preemption simulation,
+// the devices allocated to their resource claims are treated as free.
+type draSimulationState struct {
+	removedPods sets.Set[types.UID]
+}
+
+// Clone the simulation state, the cycle state is cloned for each node in preemption simulation.
+func (s *draSimulationState) Clone() k8sframework.StateData {
+	return &draSimulationState{removedPods: s.removedPods.Clone()}
+}
+
+// draSimulator checks whether the resource claims of a pod can be allocated on a node once the
+// resource claims of victims are released. The DynamicResources plugin computes the allocated
+// devices only once in PreFilter, so it can not be used to simulate preemption and reclaim.
+type draSimulator struct {
+	draManager k8sframework.SharedDRAManager
+	features   structured.Features
+	celCache   *cel.Cache
+}
+
+func newDRASimulator(draManager k8sframework.SharedDRAManager, fts feature.Features) *draSimulator {
+	return &draSimulator{
+		draManager: draManager,
+		features: structured.Features{
+			AdminAccess:          fts.EnableDRAAdminAccess,
+			PrioritizedList:      fts.EnableDRAPrioritizedList,
+			PartitionableDevices: fts.EnablePartitionableDevices,
+			DeviceTaints:         fts.EnableDRADeviceTaints,
+		},
+		celCache: cel.NewCache(10),
+	}
+}
+
+func getDRASimulationState(state *k8sframework.CycleState) *draSimulationState {
+	data, err := state.Read(draSimulationStateKey)
+	if err == nil {
+		if s, ok := data.(*draSimulationState); ok {
+			return s
+		}
+	}
+	s := &draSimulationState{removedPods: sets.New[types.UID]()}
+	state.Write(draSimulationStateKey, s)
+	return s
+}
+
+// removePod releases the resource claims of pod in the simulation.
+func (d *draSimulator) removePod(state *k8sframework.CycleState, pod *v1.Pod) {
+	if len(pod.Spec.ResourceClaims) == 0 {
+		return
+	}
+	getDRASimulationState(state).removedPods.Insert(pod.UID)
+}
+
+// addPod takes back the resource claims of pod in the simulation.
+func (d *draSimulator) addPod(state *k8sframework.CycleState, pod *v1.Pod) {
+	if len(pod.Spec.ResourceClaims) == 0 {
+		return
+	}
+	getDRASimulationState(state).removedPods.Delete(pod.UID)
+}
+
+// simulateFilter checks the resource claims of pod on node with the pods removed in the simulation.
+func (d *draSimulator) simulateFilter(ctx context.Context, state *k8sframework.CycleState, pod *v1.Pod, node *v1.Node) error {
+	return d.filter(ctx, pod, node, getDRASimulationState(state).removedPods)
+}
+
+// filterWithoutPods checks the resource claims of pod on node as if all the pods were evicted,
+// it tells whether preempting or reclaiming the pods could make room for the pod.
+func (d *draSimulator) filterWithoutPods(ctx context.Context, pod *v1.Pod, node *v1.Node, pods []*k8sframework.PodInfo) error {
+	removedPods := sets.New[types.UID]()
+	for _, podInfo := range pods {
+		if len(podInfo.Pod.Spec.ResourceClaims) > 0 {
+			removedPods.Insert(podInfo.Pod.UID)
+		}
+	}
+	if removedPods.Len() == 0 {
+		return fmt.Errorf("no pod with resource claims on node %s", node.Name)
+	}
+	return d.filter(ctx, pod, node, removedPods)
+}
+
+func (d *draSimulator) filter(ctx context.Context, pod *v1.Pod, node *v1.Node, removedPods sets.Set[types.UID]) error {
+	var claimsToAllocate []*resourceapi.ResourceClaim
+	for i := range pod.Spec.ResourceClaims {
+		claimName, _, err := resourceclaim.Name(pod, &pod.Spec.ResourceClaims[i])
+		if err != nil {
+			return err
+		}
+		if claimName == nil {
+			continue
+		}
+		claim, err := d.draManager.ResourceClaims().Get(pod.Namespace, *claimName)
+		if err != nil {
+			return err
+		}
+
+		if claim.Status.Allocation == nil {
+			claimsToAllocate = append(claimsToAllocate, claim)
+			continue
+		}
+		if claim.Status.Allocation.NodeSelector != nil {
+			nodeSelector, err := nodeaffinity.NewNodeSelector(claim.Status.Allocation.NodeSelector)
+			if err != nil {
+				return err
+			}
+			if !nodeSelector.Match(node) {
+				return fmt.Errorf("resourceclaim %s/%s is not available on node %s", claim.Namespace, claim.Name, node.Name)
+			}
+		}
+		if !resourceclaim.CanBeReserved(claim) && !resourceclaim.IsReservedForPod(pod, claim) && !releasedBy(claim, removedPods) {
+			return fmt.Errorf("resourceclaim %s/%s is in use", claim.Namespace, claim.Name)
+		}
+	}
+	if len(claimsToAllocate) == 0 {
+		return nil
+	}
+
+	allocatedDevices, err := d.draManager.ResourceClaims().ListAllAllocatedDevices()
+	if err != nil {
+		return err
+	}
+	if removedPods.Len() > 0 {
+		claims, err := d.draManager.ResourceClaims().List()
+		if err != nil {
+			return err
+		}
+		allocatedDevices = allocatedDevices.Clone()
+		for _, claim := range claims {
+			if releasedBy(claim, removedPods) {
+				foreachAllocatedDevice(claim, func(deviceID structured.DeviceID) {
+					allocatedDevices.Delete(deviceID)
+				})
+			}
+		}
+	}
+
+	slices, err := d.draManager.ResourceSlices().ListWithDeviceTaintRules()
+	if err != nil {
+		return err
+	}
+	allocator, err := structured.NewAllocator(ctx, d.features, claimsToAllocate, allocatedDevices, d.draManager.DeviceClasses(), slices, d.celCache)
+	if err != nil {
+		return err
+	}
+	allocations, err := allocator.Allocate(ctx, node)
+	if err != nil {
+		return err
+	}
+	if len(allocations) != len(claimsToAllocate) {
+		return fmt.Errorf("cannot allocate all claims on node %s", node.Name)
+	}
+	return nil
+}
+
+// releasedBy returns whether the claim is only reserved for the removed pods, so its devices are freed with them.
+func releasedBy(claim *resourceapi.ResourceClaim, removedPods sets.Set[types.UID]) bool {
+	if claim.Status.Allocation == nil || len(claim.Status.ReservedFor) == 0 {
+		return false
+	}
+	for _, consumer := range claim.Status.ReservedFor {
+		if consumer.Resource != "pods" || !removedPods.Has(consumer.UID) {
+			return false
+		}
+	}
+	return true
+}
+
+// foreachAllocatedDevice invokes the callback for each device allocated exclusively for the claim,
+// devices allocated with admin access are shared and skipped.
+func foreachAllocatedDevice(claim *resourceapi.ResourceClaim, cb func(deviceID structured.DeviceID)) {
+	for _, result := range claim.Status.Allocation.Devices.Results {
+		if ptr.Deref(result.AdminAccess, false) {
+			continue
+		}
+		cb(structured.MakeDeviceID(result.Driver, result.Pool, result.Device))
+	}
+}
@@ -397,6 +397,8 @@ func (pp *predicatesPlugin) OnSessionOpen(ssn *framework.Session) {
 	}
 	// 10. DRA
 	var dynamicResourceAllocationPlugin *dynamicresources.DynamicResources
+	// draSimulator releases the resource claims of victims in preemption and reclaim simulation
+	var draSimulator *draSimulator
 	if predicate.dynamicResourceAllocationEnable {
 		var err error
 		plugin, err = dynamicresources.New(context.TODO(), nil, handle, features)
@@ -405,6 +407,9 @@ func (pp *predicatesPlugin) OnSessionOpen(ssn *framework.Session) {
 		}
 		dynamicResourceAllocationPlugin = plugin.(*dynamicresources.DynamicResources)
 		pp.dynamicResourceAllocationPlugin = dynamicResourceAllocationPlugin
+		if draManager := ssn.SharedDRAManager(); draManager != nil {
+			draSimulator = newDRASimulator(draManager, features)
+		}
 	}
 
 	ssn.AddPrePredicateFn(pp.Name(), func(task *api.TaskInfo) error {
@@ -677,6 +682,13 @@ func (pp *predicatesPlugin) OnSessionOpen(ssn *framework.Session) {
 			if !isSkipDRA {
 				status := pp.dynamicResourceAllocationPlugin.Filter(context.TODO(), state, task.Pod, nodeInfo)
 				dynamicResourceAllocationStatus := api.ConvertPredicateStatus(status)
+				// The devices may be held by the resource claims of other pods on the node, the node is resolvable
+				// by preemption or reclaim if the claims of task can be allocated once those pods are evicted.
+				if dynamicResourceAllocationStatus.Code == api.UnschedulableAndUnresolvable && draSimulator != nil {
+					if err := draSimulator.filterWithoutPods(context.TODO(), task.Pod, node.Node, nodeInfo.Pods); err == nil {
+						dynamicResourceAllocationStatus.Code = api.Unschedulable
+					}
+				}
 				if dynamicResourceAllocationStatus.Code != api.Success {
 					predicateStatus = append(predicateStatus, dynamicResourceAllocationStatus)
 					if ShouldAbort(dynamicResourceAllocationStatus) {
@@ -736,6 +748,10 @@ func (pp *predicatesPlugin) OnSessionOpen(ssn *framework.Session) {
 			}
 		}
 
+		if draSimulator != nil && !handleSkipPredicatePlugin(cycleState, dynamicResourceAllocationPlugin.Name()) {
+			draSimulator.addPod(cycleState, taskToAdd.Pod)
+		}
+
 		return nil
 	})
 
@@ -758,6 +774,10 @@ func (pp *predicatesPlugin) OnSessionOpen(ssn *framework.Session) {
 				}
 			}
 		}
+
+		if draSimulator != nil && !handleSkipPredicatePlugin(cycleState, dynamicResourceAllocationPlugin.Name()) {
+			draSimulator.removePod(cycleState, taskToRemove.Pod)
+		}
 		return nil
 	})
 
@@ -778,6 +798,12 @@ func (pp *predicatesPlugin) OnSessionOpen(ssn *framework.Session) {
 				}
 			}
 		}
+
+		if draSimulator != nil && !handleSkipPredicatePlugin(cycleState, dynamicResourceAllocationPlugin.Name()) {
+			if err := draSimulator.simulateFilter(ctx, cycleState, task.Pod, node.Node); err != nil {
+				return fmt.Errorf("failed to allocate resource claims on node %s: %w", node.Name, err)
+			}
+		}
 		return nil
 	})
 }
@@ -794,8 +820,9 @@ func (pp *predicatesPlugin) runReservePlugins(ssn *framework.Session, event *fra
 		}
 	}
 
-	// DRA Reserve
-	if pp.dynamicResourceAllocationPlugin != nil {
+	// DRA Reserve, the devices of pipelined task are still held by the victims of preemption or reclaim,
+	// so they are allocated when the task is allocated in later sessions.
+	if pp.dynamicResourceAllocationPlugin != nil && event.Task.Status != api.Pipelined {
 		status := pp.dynamicResourceAllocationPlugin.Reserve(context.TODO(), state, event.Task.Pod, event.Task.Pod.Spec.NodeName)
 		if !status.IsSuccess() {
 			event.Err = status.AsError()
@@ -17,10 +17,14 @@ limitations under the License.
 package sla
 
 import (
+	"fmt"
 	"time"
 
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
 
+	"volcano.sh/apis/pkg/apis/scheduling"
 	"volcano.sh/volcano/pkg/scheduler/api"
 	"volcano.sh/volcano/pkg/scheduler/framework"
 	"volcano.sh/volcano/pkg/scheduler/plugins/util"
@@ -33,20 +37,97 @@ const (
 	// when job waits longer than waiting time, it should be enqueue at once, and cluster should reserve resources for it
 	// Valid time units are “ns”, “us” (or “µs”), “ms”, “s”, “m”, “h”
 	JobWaitingTime = "sla-waiting-time"
+	// JobDeadline is the time by which a job should be completed, in RFC3339 format, e.g. "2006-01-02T15:04:05Z"
+	JobDeadline = "sla-deadline"
+	// JobExpectedRuntime is how long a job is expected to run once it is scheduled,
+	// together with JobDeadline it decides the latest time the job should be started
+	JobExpectedRuntime = "sla-expected-runtime"
+	// DeadlinePreemptionEnable enables preemption of lower priority jobs for jobs which are about to miss their deadline
+	DeadlinePreemptionEnable = "sla-deadline-preemption.enable"
+	// DeadlinePreemptionSlack is the slack under which a job with deadline is allowed to preempt, default is 30m
+	DeadlinePreemptionSlack = "sla-deadline-preemption-slack"
+
+	// PodGroupDeadlineUnreachable is the podgroup condition type set when a job can no longer meet its deadline
+	PodGroupDeadlineUnreachable scheduling.PodGroupConditionType = "DeadlineUnreachable"
+	// DeadlineUnreachableReason is the reason of event and condition when a job can no longer meet its deadline
+	DeadlineUnreachableReason = "DeadlineUnreachable"
+
+	defaultDeadlinePreemptionSlack = 30 * time.Minute
 )
 
 type slaPlugin struct {
 	// Arguments given for sla plugin
 	pluginArguments framework.Arguments
 	jobWaitingTime  *time.Duration
+
+	deadlinePreemptionEnable bool
+	deadlinePreemptionSlack  time.Duration
+	// jobDeadlines are the valid deadlines of jobs in the session, parsed once when the session is opened
+	jobDeadlines map[api.JobID]*jobDeadline
 }
 
 // New function returns sla plugin object
 func New(arguments framework.Arguments) framework.Plugin {
 	return &slaPlugin{
-		pluginArguments: arguments,
-		jobWaitingTime:  nil,
+		pluginArguments:         arguments,
+		jobWaitingTime:          nil,
+		deadlinePreemptionSlack: defaultDeadlinePreemptionSlack,
+	}
+}
+
+// jobDeadline is the deadline setting of one job
+type jobDeadline struct {
+	deadline        time.Time
+	expectedRuntime time.Duration
+}
+
+// latestStartTime returns the latest time at which the job could be started and still meets its deadline
+func (jd *jobDeadline) latestStartTime() time.Time {
+	return jd.deadline.Add(-jd.expectedRuntime)
+}
+
+// slack returns how long the start of the job could still be delayed until it misses its deadline
+func (jd *jobDeadline) slack(now time.Time) time.Duration {
+	return jd.latestStartTime().Sub(now)
+}
+
+// readJobDeadline read job deadline and expected runtime from podgroup annotations,
+// it returns nil if the job has no valid deadline
+func readJobDeadline(job *api.JobInfo) *jobDeadline {
+	if job.PodGroup == nil {
+		return nil
+	}
+	value, found := job.PodGroup.Annotations[JobDeadline]
+	if !found {
+		return nil
+	}
+	deadline, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		klog.Warningf("Invalid %s <%s> for job <%s/%s>: %v", JobDeadline, value, job.Namespace, job.Name, err)
+		return nil
+	}
+
+	jd := &jobDeadline{deadline: deadline}
+	if value, found := job.PodGroup.Annotations[JobExpectedRuntime]; found {
+		runtime, err := time.ParseDuration(value)
+		if err != nil || runtime < 0 {
+			klog.Warningf("Invalid %s <%s> for job <%s/%s>, ignore it", JobExpectedRuntime, value, job.Namespace, job.Name)
+		} else {
+			jd.expectedRuntime = runtime
+		}
+	}
+	return jd
+}
+
+// readJobDeadlines read the deadlines of all jobs, jobs without valid deadline are not in the result
+func readJobDeadlines(jobs map[api.JobID]*api.JobInfo) map[api.JobID]*jobDeadline {
+	jobDeadlines := make(map[api.JobID]*jobDeadline)
+	for _, job := range jobs {
+		if jd := readJobDeadline(job); jd != nil {
+			jobDeadlines[job.UID] = jd
+		}
 	}
+	return jobDeadlines
 }
 
 func (sp *slaPlugin) Name() string {
@@ -72,6 +153,8 @@ tiers:
   - name: sla
     arguments:
     sla-waiting-time: 1h2m3s4ms5µs6ns
+    sla-deadline-preemption.enable: true
+    sla-deadline-preemption-slack: 30m
 
 Meanwhile, user can give individual job waiting time settings for one job via job annotations:
 apiVersion: batch.volcano.sh/v1alpha1
@@ -80,6 +163,16 @@ metadata:
 
 	annotations:
 	  sla-waiting-time: 1h2m3s4ms5us6ns
+
+Jobs with a completion deadline are ordered by their latest start time (deadline minus expected runtime),
+and are marked with the DeadlineUnreachable condition when they can no longer meet it:
+apiVersion: batch.volcano.sh/v1alpha1
+kind: Job
+metadata:
+
+	annotations:
+	  sla-deadline: "2006-01-02T15:04:05Z"
+	  sla-expected-runtime: 2h
 */
 func (sp *slaPlugin) OnSessionOpen(ssn *framework.Session) {
 	klog.V(4).Infof("Enter sla plugin ...")
@@ -104,11 +197,18 @@ func (sp *slaPlugin) OnSessionOpen(ssn *framework.Session) {
 			klog.V(4).Infof("Global job waiting time is %s.", sp.jobWaitingTime.String())
 		}
 	}
+	sp.parseDeadlineArguments()
+	sp.jobDeadlines = readJobDeadlines(ssn.Jobs)
 
 	jobOrderFn := func(l, r interface{}) int {
 		lv := l.(*api.JobInfo)
 		rv := r.(*api.JobInfo)
 
+		// earliest-deadline-first, the job which has to be started earlier goes first
+		if result := compareDeadline(sp.jobDeadlines[lv.UID], sp.jobDeadlines[rv.UID]); result != 0 {
+			return result
+		}
+
 		var lJobWaitingTime = sp.readJobWaitingTime(lv.WaitingTime)
 		var rJobWaitingTime = sp.readJobWaitingTime(rv.WaitingTime)
 
@@ -151,6 +251,121 @@ func (sp *slaPlugin) OnSessionOpen(ssn *framework.Session) {
 	ssn.AddJobEnqueueableFn(sp.Name(), permitableFn)
 	// if job waiting time is over, turn job to be pipelined in allocate action
 	ssn.AddJobPipelinedFn(sp.Name(), permitableFn)
+
+	if sp.deadlinePreemptionEnable {
+		preemptableFn := func(preemptor *api.TaskInfo, preemptees []*api.TaskInfo) ([]*api.TaskInfo, int) {
+			preemptorJob, found := ssn.Jobs[preemptor.Job]
+			if !found {
+				return nil, util.Abstain
+			}
+			preemptorDeadline := sp.jobDeadlines[preemptorJob.UID]
+			if preemptorDeadline == nil {
+				return nil, util.Abstain
+			}
+			now := time.Now()
+			slack := preemptorDeadline.slack(now)
+			// a job which has enough slack does not need to preempt, and it is useless to preempt for
+			// a job which already missed its deadline
+			if slack < 0 || slack > sp.deadlinePreemptionSlack {
+				return nil, util.Abstain
+			}
+
+			var victims []*api.TaskInfo
+			for _, preemptee := range preemptees {
+				preempteeJob, found := ssn.Jobs[preemptee.Job]
+				if !found || preempteeJob.UID == preemptorJob.UID {
+					continue
+				}
+				// only lower priority work is preempted
+				if preempteeJob.Priority >= preemptorJob.Priority {
+					continue
+				}
+				// never preempt a job which is more urgent than the preemptor
+				if jd := sp.jobDeadlines[preempteeJob.UID]; jd != nil && jd.slack(now) >= 0 && jd.slack(now) <= slack {
+					continue
+				}
+				victims = append(victims, preemptee)
+			}
+
+			klog.V(4).Infof("Victims from sla plugin for job <%s/%s> with slack %v are %+v",
+				preemptorJob.Namespace, preemptorJob.Name, slack, victims)
+			return victims, util.Permit
+		}
+		ssn.AddPreemptableFn(sp.Name(), preemptableFn)
+	}
+}
+
+// parseDeadlineArguments read deadline preemption settings from sla plugin arguments
+func (sp *slaPlugin) parseDeadlineArguments() {
+	sp.pluginArguments.GetBool(&sp.deadlinePreemptionEnable, DeadlinePreemptionEnable)
+	if value, exist := sp.pluginArguments[DeadlinePreemptionSlack]; exist {
+		slackStr, _ := value.(string)
+		slack, err := time.ParseDuration(slackStr)
+		if err != nil || slack < 0 {
+			klog.Warningf("Invalid %s setting: %v in sla plugin, use default %v.", DeadlinePreemptionSlack, value, defaultDeadlinePreemptionSlack)
+		} else {
+			sp.deadlinePreemptionSlack = slack
+		}
+	}
 }
 
-func (sp *slaPlugin) OnSessionClose(ssn *framework.Session) {}
+// compareDeadline compares two jobs by their latest start time, jobs without deadline go after jobs with deadline
+func compareDeadline(l, r *jobDeadline) int {
+	if l == nil {
+		if r == nil {
+			return 0
+		}
+		return 1
+	}
+	if r == nil {
+		return -1
+	}
+
+	lStart, rStart := l.latestStartTime(), r.latestStartTime()
+	if lStart.Before(rStart) {
+		return -1
+	} else if lStart.After(rStart) {
+		return 1
+	}
+	return 0
+}
+
+func (sp *slaPlugin) OnSessionClose(ssn *framework.Session) {
+	now := time.Now()
+	for _, job := range ssn.Jobs {
+		jd := sp.jobDeadlines[job.UID]
+		if jd == nil || job.IsReady() {
+			continue
+		}
+		if slack := jd.slack(now); slack >= 0 {
+			continue
+		}
+
+		msg := fmt.Sprintf("Job can not be completed before deadline %s, expected runtime %v, latest start time was %s",
+			jd.deadline.Format(time.RFC3339), jd.expectedRuntime, jd.latestStartTime().Format(time.RFC3339))
+		if !hasPodGroupCondition(job, PodGroupDeadlineUnreachable) {
+			ssn.RecordPodGroupEvent(job.PodGroup, v1.EventTypeWarning, DeadlineUnreachableReason, msg)
+		}
+		jc := &scheduling.PodGroupCondition{
+			Type:               PodGroupDeadlineUnreachable,
+			Status:             v1.ConditionTrue,
+			LastTransitionTime: metav1.Now(),
+			TransitionID:       string(ssn.UID),
+			Reason:             DeadlineUnreachableReason,
+			Message:            msg,
+		}
+		if err := ssn.UpdatePodGroupCondition(job, jc); err != nil {
+			klog.Errorf("Failed to update job <%s/%s> condition: %v", job.Namespace, job.Name, err)
+		}
+	}
+}
+
+// hasPodGroupCondition checks whether the podgroup of job already has the condition with given type
+func hasPodGroupCondition(job *api.JobInfo, condType scheduling.PodGroupConditionType) bool {
+	for _, c := range job.PodGroup.Status.Conditions {
+		if c.Type == condType && c.Status == v1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
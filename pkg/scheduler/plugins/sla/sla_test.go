@@ -17,17 +17,24 @@ limitations under the License.
 package sla
 
 import (
+	"strings"
 	"testing"
 	"time"
 
+	v1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 
 	"volcano.sh/apis/pkg/apis/scheduling"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/cache"
 	"volcano.sh/volcano/pkg/scheduler/conf"
 	"volcano.sh/volcano/pkg/scheduler/framework"
 	"volcano.sh/volcano/pkg/scheduler/uthelper"
+	"volcano.sh/volcano/pkg/scheduler/util"
 )
 
 func TestSlaPlugin(t *testing.T) {
@@ -146,3 +153,180 @@ func TestSlaPlugin(t *testing.T) {
 	}
 
 }
+
+func TestSlaDeadline(t *testing.T) {
+	now := time.Now()
+	buildJob := func(name string, priority int32, annotations map[string]string) *api.JobInfo {
+		return &api.JobInfo{
+			UID:       api.JobID(name),
+			Name:      name,
+			Namespace: "default",
+			Priority:  priority,
+			PodGroup: &api.PodGroup{
+				PodGroup: scheduling.PodGroup{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        name,
+						Namespace:   "default",
+						Annotations: annotations,
+					},
+				},
+			},
+		}
+	}
+	urgent := buildJob("urgent", 1, map[string]string{
+		JobDeadline:        now.Add(2 * time.Hour).Format(time.RFC3339),
+		JobExpectedRuntime: "110m",
+	})
+	relaxed := buildJob("relaxed", 1, map[string]string{
+		JobDeadline:        now.Add(time.Hour).Format(time.RFC3339),
+		JobExpectedRuntime: "10m",
+	})
+	missed := buildJob("missed", 1, map[string]string{
+		JobDeadline:        now.Add(time.Hour).Format(time.RFC3339),
+		JobExpectedRuntime: "2h",
+	})
+	noDeadline := buildJob("no-deadline", 0, nil)
+	invalid := buildJob("invalid", 0, map[string]string{JobDeadline: "tomorrow"})
+
+	tests := []struct {
+		name     string
+		l, r     *api.JobInfo
+		expected int
+	}{
+		{name: "earlier latest start time goes first", l: urgent, r: relaxed, expected: -1},
+		{name: "later latest start time goes after", l: relaxed, r: urgent, expected: 1},
+		{name: "job with deadline goes before job without deadline", l: noDeadline, r: relaxed, expected: 1},
+		{name: "invalid deadline is ignored", l: invalid, r: noDeadline, expected: 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := compareDeadline(readJobDeadline(test.l), readJobDeadline(test.r)); got != test.expected {
+				t.Errorf("expect %d, but got %d", test.expected, got)
+			}
+		})
+	}
+
+	jobDeadlines := readJobDeadlines(map[api.JobID]*api.JobInfo{
+		urgent.UID: urgent, relaxed.UID: relaxed, missed.UID: missed, noDeadline.UID: noDeadline, invalid.UID: invalid,
+	})
+	if len(jobDeadlines) != 3 || jobDeadlines[noDeadline.UID] != nil || jobDeadlines[invalid.UID] != nil {
+		t.Errorf("expect only the valid deadlines to be read, but got %v", jobDeadlines)
+	}
+	if jd := jobDeadlines[missed.UID]; jd == nil || jd.slack(now) >= 0 {
+		t.Errorf("expect job <%s> can not meet its deadline", missed.Name)
+	}
+
+	sp := New(framework.Arguments{
+		DeadlinePreemptionEnable: true,
+		DeadlinePreemptionSlack:  "15m",
+	}).(*slaPlugin)
+	sp.parseDeadlineArguments()
+	if !sp.deadlinePreemptionEnable || sp.deadlinePreemptionSlack != 15*time.Minute {
+		t.Errorf("unexpected deadline preemption settings, enable: %v, slack: %v",
+			sp.deadlinePreemptionEnable, sp.deadlinePreemptionSlack)
+	}
+}
+
+func TestSlaDeadlinePreemptionAndSessionClose(t *testing.T) {
+	now := time.Now()
+	withDeadline := func(pg *schedulingv1beta1.PodGroup, deadline time.Time, runtime string) *schedulingv1beta1.PodGroup {
+		pg.Annotations = map[string]string{
+			JobDeadline:        deadline.Format(time.RFC3339),
+			JobExpectedRuntime: runtime,
+		}
+		return pg
+	}
+	resource := api.BuildResourceList("1", "1Gi")
+
+	// the urgent job has 10m left to be started, the missed job can not meet its deadline anymore
+	pgs := []*schedulingv1beta1.PodGroup{
+		withDeadline(util.BuildPodGroupWithPrio("urgent", "c1", "c1", 1, nil, schedulingv1beta1.PodGroupInqueue, "mid"), now.Add(40*time.Minute), "30m"),
+		withDeadline(util.BuildPodGroupWithPrio("missed", "c1", "c1", 1, nil, schedulingv1beta1.PodGroupInqueue, "low"), now.Add(time.Hour), "2h"),
+		util.BuildPodGroupWithPrio("low", "c1", "c1", 1, nil, schedulingv1beta1.PodGroupRunning, "low"),
+		util.BuildPodGroupWithPrio("same", "c1", "c1", 1, nil, schedulingv1beta1.PodGroupRunning, "mid"),
+		util.BuildPodGroupWithPrio("high", "c1", "c1", 1, nil, schedulingv1beta1.PodGroupRunning, "high"),
+	}
+	pods := []*v1.Pod{
+		util.BuildPod("c1", "urgent-0", "", v1.PodPending, resource, "urgent", nil, nil),
+		util.BuildPod("c1", "missed-0", "", v1.PodPending, resource, "missed", nil, nil),
+		util.BuildPod("c1", "low-0", "n1", v1.PodRunning, resource, "low", nil, nil),
+		util.BuildPod("c1", "same-0", "n1", v1.PodRunning, resource, "same", nil, nil),
+		util.BuildPod("c1", "high-0", "n1", v1.PodRunning, resource, "high", nil, nil),
+	}
+
+	recorder := record.NewFakeRecorder(100)
+	schedulerCache := cache.NewCustomMockSchedulerCache("mock-test", util.NewFakeBinder(0), util.NewFakeEvictor(0), &util.FakeStatusUpdater{}, nil, recorder)
+	schedulerCache.AddOrUpdateNode(util.BuildNode("n1", api.BuildResourceList("4", "4Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil))
+	for _, pc := range []*schedulingv1.PriorityClass{
+		util.BuildPriorityClass("low", 10), util.BuildPriorityClass("mid", 100), util.BuildPriorityClass("high", 1000),
+	} {
+		schedulerCache.AddPriorityClass(pc)
+	}
+	for _, pod := range pods {
+		schedulerCache.AddPod(pod)
+	}
+	for _, pg := range pgs {
+		schedulerCache.AddPodGroupV1beta1(pg)
+	}
+	schedulerCache.AddQueueV1beta1(util.BuildQueue("c1", 1, nil))
+
+	framework.RegisterPluginBuilder(PluginName, New)
+	trueValue := true
+	ssn := framework.OpenSession(schedulerCache, []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:                PluginName,
+					EnabledPreemptable:  &trueValue,
+					EnabledJobOrder:     &trueValue,
+					EnabledJobEnqueued:  &trueValue,
+					EnabledJobPipelined: &trueValue,
+					Arguments:           framework.Arguments{DeadlinePreemptionEnable: true},
+				},
+			},
+		},
+	}, nil)
+	defer framework.CloseSession(ssn)
+
+	tasks := map[string]*api.TaskInfo{}
+	for _, job := range ssn.Jobs {
+		for _, task := range job.Tasks {
+			tasks[task.Name] = task
+		}
+	}
+	preemptees := []*api.TaskInfo{tasks["low-0"], tasks["same-0"], tasks["high-0"]}
+	victims := ssn.Preemptable(tasks["urgent-0"], preemptees)
+	if len(victims) != 1 || victims[0].Name != "low-0" {
+		t.Errorf("expect only the lower priority task low-0 to be preempted, but got %v", victims)
+	}
+	if victims := ssn.Preemptable(tasks["missed-0"], preemptees); len(victims) != 0 {
+		t.Errorf("expect no victims for a job which missed its deadline, but got %v", victims)
+	}
+
+	sp := New(framework.Arguments{})
+	sp.OnSessionOpen(ssn)
+	sp.OnSessionClose(ssn)
+	missed := ssn.Jobs[api.JobID("c1/missed")]
+	if !hasPodGroupCondition(missed, PodGroupDeadlineUnreachable) {
+		t.Errorf("expect condition %s on podgroup of missed job, got %v", PodGroupDeadlineUnreachable, missed.PodGroup.Status.Conditions)
+	}
+	if urgent := ssn.Jobs[api.JobID("c1/urgent")]; hasPodGroupCondition(urgent, PodGroupDeadlineUnreachable) {
+		t.Errorf("expect no condition %s on podgroup of urgent job", PodGroupDeadlineUnreachable)
+	}
+	select {
+	case event := <-recorder.Events:
+		if !strings.Contains(event, DeadlineUnreachableReason) {
+			t.Errorf("expect event with reason %s, but got %s", DeadlineUnreachableReason, event)
+		}
+	default:
+		t.Errorf("expect event %s to be recorded", DeadlineUnreachableReason)
+	}
+
+	// the event is recorded once, the condition is kept
+	sp.OnSessionClose(ssn)
+	select {
+	case event := <-recorder.Events:
+		t.Errorf("expect no more events, but got %s", event)
+	default:
+	}
+}
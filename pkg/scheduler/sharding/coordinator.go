@@ -0,0 +1,260 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	"stathat.com/c/consistent"
+)
+
+const (
+	// ModeNone disables sharding, only the leader scheduler is active.
+	ModeNone = ""
+	// ModeQueue shards the queues among the active schedulers, nodes are shared by all of them.
+	ModeQueue = "queue"
+	// ModeNode shards the node pools among the active schedulers, and jobs are hashed among them as well.
+	ModeNode = "node"
+
+	// ShardGroupLabelKey is the label of the leases held by the schedulers of the same shard group.
+	ShardGroupLabelKey = "volcano.sh/scheduler-shard-group"
+)
+
+// ValidMode returns whether the sharding mode is supported.
+func ValidMode(mode string) bool {
+	return mode == ModeNone || mode == ModeQueue || mode == ModeNode
+}
+
+// Coordinator maintains the membership of the active schedulers of a shard group. Each scheduler holds a lease
+// labelled with the group and renews it periodically; the schedulers whose lease is not expired are the members,
+// and the keys (queues, node pools or jobs) are assigned to the members by consistent hashing. As all members see
+// the same leases, they agree on the assignment without any extra communication once the membership is stable.
+type Coordinator struct {
+	client        kubernetes.Interface
+	namespace     string
+	group         string
+	identity      string
+	leaseDuration time.Duration
+
+	mutex     sync.RWMutex
+	ring      *consistent.Consistent
+	members   []string
+	lastRenew time.Time
+}
+
+// NewCoordinator returns a coordinator of the shard group, the hostname is used as identity if it is empty.
+func NewCoordinator(client kubernetes.Interface, namespace, group, identity string, leaseDuration time.Duration) *Coordinator {
+	if identity == "" {
+		identity = defaultIdentity()
+	}
+
+	return &Coordinator{
+		client:        client,
+		namespace:     namespace,
+		group:         group,
+		identity:      identity,
+		leaseDuration: leaseDuration,
+	}
+}
+
+// defaultIdentity returns the hostname, which is the pod name of the scheduler. It is stable across restarts
+// of the scheduler, so that a restarted scheduler takes over its own lease instead of creating a new one.
+func defaultIdentity() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		klog.Errorf("Failed to get hostname for scheduler shard identity: %v", err)
+		hostname = "vc-scheduler"
+	}
+	return strings.ToLower(hostname)
+}
+
+// Identity returns the identity of the scheduler in the shard group.
+func (c *Coordinator) Identity() string {
+	return c.identity
+}
+
+// Members returns the identities of the active schedulers in the shard group.
+func (c *Coordinator) Members() []string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return append([]string(nil), c.members...)
+}
+
+// Owns returns whether the key is assigned to the current scheduler. Nothing is owned if the lease of the current
+// scheduler can not be renewed in time, because the other members will take over its keys once the lease expires.
+func (c *Coordinator) Owns(key string) bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if len(c.members) == 0 || time.Since(c.lastRenew) > c.leaseDuration {
+		return false
+	}
+
+	owner, err := c.ring.Get(key)
+	if err != nil {
+		klog.Errorf("Failed to get the scheduler shard of <%s>: %v", key, err)
+		return false
+	}
+	return owner == c.identity
+}
+
+// Run joins the shard group and keeps the membership up to date until stopCh is closed, the lease is released
+// at last so that the other members take over the keys immediately.
+func (c *Coordinator) Run(stopCh <-chan struct{}) {
+	c.sync()
+	go func() {
+		wait.Until(c.sync, c.leaseDuration/3, stopCh)
+		c.release()
+	}()
+}
+
+func (c *Coordinator) leaseName() string {
+	return c.group + "-" + c.identity
+}
+
+func (c *Coordinator) sync() {
+	now := time.Now()
+	if err := c.renew(now); err != nil {
+		klog.Errorf("Failed to renew the lease of scheduler shard <%s>: %v", c.identity, err)
+	} else {
+		c.mutex.Lock()
+		c.lastRenew = now
+		c.mutex.Unlock()
+	}
+
+	members, err := c.liveMembers(now)
+	if err != nil {
+		klog.Errorf("Failed to list the members of scheduler shard group <%s>: %v", c.group, err)
+		return
+	}
+	c.setMembers(members)
+}
+
+// renew creates or refreshes the lease of the current scheduler.
+func (c *Coordinator) renew(now time.Time) error {
+	leases := c.client.CoordinationV1().Leases(c.namespace)
+	renewTime := metav1.NewMicroTime(now)
+	durationSeconds := int32(c.leaseDuration / time.Second)
+
+	lease, err := leases.Get(context.TODO(), c.leaseName(), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      c.leaseName(),
+				Namespace: c.namespace,
+				Labels:    map[string]string{ShardGroupLabelKey: c.group},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       &c.identity,
+				LeaseDurationSeconds: &durationSeconds,
+				AcquireTime:          &renewTime,
+				RenewTime:            &renewTime,
+			},
+		}
+		_, err = leases.Create(context.TODO(), lease, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	lease = lease.DeepCopy()
+	lease.Spec.HolderIdentity = &c.identity
+	lease.Spec.LeaseDurationSeconds = &durationSeconds
+	lease.Spec.RenewTime = &renewTime
+	_, err = leases.Update(context.TODO(), lease, metav1.UpdateOptions{})
+	return err
+}
+
+// release deletes the lease of the current scheduler.
+func (c *Coordinator) release() {
+	err := c.client.CoordinationV1().Leases(c.namespace).Delete(context.TODO(), c.leaseName(), metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		klog.Errorf("Failed to release the lease of scheduler shard <%s>: %v", c.identity, err)
+	}
+}
+
+// liveMembers returns the holders of the leases in the shard group which are not expired, the expired leases
+// are deleted so that the leases of the schedulers which did not stop gracefully do not pile up.
+func (c *Coordinator) liveMembers(now time.Time) ([]string, error) {
+	leaseList, err := c.client.CoordinationV1().Leases(c.namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", ShardGroupLabelKey, c.group),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var members []string
+	for _, lease := range leaseList.Items {
+		if leaseExpired(&lease, now) {
+			klog.V(4).Infof("The lease <%s/%s> of scheduler shard group <%s> is expired.", lease.Namespace, lease.Name, c.group)
+			c.deleteExpiredLease(&lease)
+			continue
+		}
+		members = append(members, *lease.Spec.HolderIdentity)
+	}
+	sort.Strings(members)
+	return members, nil
+}
+
+// deleteExpiredLease deletes the lease if it is not renewed in the meantime.
+func (c *Coordinator) deleteExpiredLease(lease *coordinationv1.Lease) {
+	err := c.client.CoordinationV1().Leases(lease.Namespace).Delete(context.TODO(), lease.Name, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{ResourceVersion: &lease.ResourceVersion},
+	})
+	if err != nil && !apierrors.IsNotFound(err) && !apierrors.IsConflict(err) {
+		klog.Errorf("Failed to delete the expired lease <%s/%s> of scheduler shard group <%s>: %v",
+			lease.Namespace, lease.Name, c.group, err)
+	}
+}
+
+func leaseExpired(lease *coordinationv1.Lease, now time.Time) bool {
+	if lease.Spec.HolderIdentity == nil || lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return true
+	}
+	expireTime := lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second)
+	return now.After(expireTime)
+}
+
+func (c *Coordinator) setMembers(members []string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.ring != nil && slices.Equal(c.members, members) {
+		return
+	}
+
+	klog.V(2).Infof("Members of scheduler shard group <%s> changed from %v to %v", c.group, c.members, members)
+	ring := consistent.New()
+	ring.Set(members)
+	c.ring = ring
+	c.members = members
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCoordinatorOwns(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	coordinators := []*Coordinator{
+		NewCoordinator(client, "volcano-system", "vc-scheduler", "scheduler-a", 15*time.Second),
+		NewCoordinator(client, "volcano-system", "vc-scheduler", "scheduler-b", 15*time.Second),
+		NewCoordinator(client, "volcano-system", "vc-scheduler", "scheduler-c", 15*time.Second),
+	}
+	for _, c := range coordinators {
+		c.sync()
+	}
+	// sync again so that the members joined later are seen by all
+	for _, c := range coordinators {
+		c.sync()
+	}
+
+	for _, c := range coordinators {
+		if members := c.Members(); len(members) != 3 {
+			t.Fatalf("coordinator %s expects 3 members, got %v", c.Identity(), members)
+		}
+	}
+
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("queue-%d", i)
+		owners := 0
+		for _, c := range coordinators {
+			if c.Owns(key) {
+				owners++
+			}
+		}
+		if owners != 1 {
+			t.Errorf("key %s expects exactly one owner, got %d", key, owners)
+		}
+	}
+
+	// The keys of the released scheduler are taken over by the others.
+	coordinators[2].release()
+	for _, c := range coordinators[:2] {
+		c.sync()
+	}
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("queue-%d", i)
+		if coordinators[0].Owns(key) == coordinators[1].Owns(key) {
+			t.Errorf("key %s expects exactly one owner after scheduler-c left", key)
+		}
+	}
+}
+
+func TestCoordinatorExpiredLease(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	renewTime := metav1.NewMicroTime(time.Now().Add(-time.Minute))
+	holder := "scheduler-stale"
+	duration := int32(15)
+	stale := &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "vc-scheduler-" + holder,
+			Namespace: "volcano-system",
+			Labels:    map[string]string{ShardGroupLabelKey: "vc-scheduler"},
+		},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       &holder,
+			LeaseDurationSeconds: &duration,
+			RenewTime:            &renewTime,
+		},
+	}
+	if _, err := client.CoordinationV1().Leases("volcano-system").Create(context.TODO(), stale, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create lease: %v", err)
+	}
+
+	c := NewCoordinator(client, "volcano-system", "vc-scheduler", "scheduler-a", 15*time.Second)
+	c.sync()
+	if members := c.Members(); len(members) != 1 || members[0] != "scheduler-a" {
+		t.Fatalf("expects only scheduler-a as member, got %v", members)
+	}
+	if !c.Owns("queue-1") {
+		t.Errorf("the only member expects to own all keys")
+	}
+	if _, err := client.CoordinationV1().Leases("volcano-system").Get(context.TODO(), stale.Name, metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expects the expired lease to be deleted, got %v", err)
+	}
+
+	// Nothing is owned once the lease of the current scheduler is not renewed in time.
+	c.lastRenew = time.Now().Add(-time.Minute)
+	if c.Owns("queue-1") {
+		t.Errorf("expects to own nothing with an expired lease")
+	}
+}
@@ -17,7 +17,10 @@ limitations under the License.
 package validate
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"slices"
 	"strings"
 
 	admissionv1 "k8s.io/api/admission/v1"
@@ -26,6 +29,8 @@ import (
 	"k8s.io/klog/v2"
 
 	flowv1alpha1 "volcano.sh/apis/pkg/apis/flow/v1alpha1"
+	"volcano.sh/volcano/pkg/controllers/jobflow/flowpolicy"
+	"volcano.sh/volcano/pkg/controllers/jobflow/parameter"
 	"volcano.sh/volcano/pkg/webhooks/router"
 	"volcano.sh/volcano/pkg/webhooks/schema"
 	"volcano.sh/volcano/pkg/webhooks/util"
@@ -80,6 +85,12 @@ func AdmitJobFlows(ar admissionv1.AdmissionReview) *admissionv1.AdmissionRespons
 	switch ar.Request.Operation {
 	case admissionv1.Create, admissionv1.Update:
 		msg = validateJobFlowDAG(jobFlow, &reviewResponse)
+		if reviewResponse.Allowed {
+			msg = validateJobFlowParameters(jobFlow, &reviewResponse)
+		}
+		if reviewResponse.Allowed {
+			msg = validateJobFlowPolicies(jobFlow, &reviewResponse)
+		}
 	default:
 		err := OperationNotCreateOrUpdate
 		return util.ToAdmissionResponse(err)
@@ -120,3 +131,90 @@ func validateJobFlowDAG(jobflow *flowv1alpha1.JobFlow, reviewResponse *admission
 	}
 	return msg
 }
+
+// validateJobFlowPolicies checks the fan-out, retry and when condition of the flows.
+func validateJobFlowPolicies(jobflow *flowv1alpha1.JobFlow, reviewResponse *admissionv1.AdmissionResponse) string {
+	policies, err := flowpolicy.Policies(jobflow)
+	if err != nil {
+		reviewResponse.Allowed = false
+		return err.Error()
+	}
+	for flowName := range policies {
+		if !slices.ContainsFunc(jobflow.Spec.Flows, func(flow flowv1alpha1.Flow) bool { return flow.Name == flowName }) {
+			reviewResponse.Allowed = false
+			return fmt.Sprintf("policy is set for undefined flow %s", flowName)
+		}
+	}
+	return ""
+}
+
+// validateJobFlowParameters checks the parameter values passed to the JobTemplates, the outputs of upstream
+// jobs can only be referenced by the flows depending on them.
+func validateJobFlowParameters(jobflow *flowv1alpha1.JobFlow, reviewResponse *admissionv1.AdmissionResponse) string {
+	flowValues, err := parameter.FlowParameters(jobflow)
+	if err != nil {
+		reviewResponse.Allowed = false
+		return err.Error()
+	}
+
+	flows := make(map[string]flowv1alpha1.Flow, len(jobflow.Spec.Flows))
+	for _, flow := range jobflow.Spec.Flows {
+		flows[flow.Name] = flow
+	}
+	for flowName, values := range flowValues {
+		flow, found := flows[flowName]
+		if !found {
+			reviewResponse.Allowed = false
+			return fmt.Sprintf("parameters are set for undefined flow %s", flowName)
+		}
+		for name, value := range values {
+			for _, ref := range parameter.References(value) {
+				if flow.DependsOn == nil || !slices.Contains(flow.DependsOn.Targets, ref.Flow) {
+					reviewResponse.Allowed = false
+					return fmt.Sprintf("parameter %s of flow %s references job of flow %s, which is not in its dependsOn targets",
+						name, flowName, ref.Flow)
+				}
+			}
+		}
+	}
+
+	if config.VolcanoClient == nil {
+		return ""
+	}
+	for _, flow := range jobflow.Spec.Flows {
+		// the JobTemplate may be created after the JobFlow, it is checked by the controller then
+		jobTemplate, err := config.VolcanoClient.FlowV1alpha1().JobTemplates(jobflow.Namespace).Get(context.TODO(), flow.Name, metav1.GetOptions{})
+		if err != nil {
+			continue
+		}
+		if msg := validateTemplateParameters(jobTemplate, flowValues[flow.Name]); msg != "" {
+			reviewResponse.Allowed = false
+			return fmt.Sprintf("invalid parameters of flow %s: %s", flow.Name, msg)
+		}
+	}
+	return ""
+}
+
+func validateTemplateParameters(jobTemplate *flowv1alpha1.JobTemplate, values map[string]string) string {
+	params, err := parameter.TemplateParameters(jobTemplate)
+	if err != nil {
+		return err.Error()
+	}
+	if len(params) == 0 && len(values) == 0 {
+		return ""
+	}
+	if _, err := parameter.Values(params, values); err != nil {
+		return err.Error()
+	}
+
+	placeholders, err := parameter.Placeholders(&jobTemplate.Spec)
+	if err != nil {
+		return err.Error()
+	}
+	for _, name := range placeholders {
+		if !slices.ContainsFunc(params, func(p parameter.Parameter) bool { return p.Name == name }) {
+			return fmt.Sprintf("parameter %s used by JobTemplate %s is not declared", name, jobTemplate.Name)
+		}
+	}
+	return ""
+}
@@ -22,12 +22,15 @@ import (
 	"testing"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	batchv1alpha1 "volcano.sh/apis/pkg/apis/batch/v1alpha1"
 	flowv1alpha1 "volcano.sh/apis/pkg/apis/flow/v1alpha1"
 	schedulingv1beta2 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 	fakeclient "volcano.sh/apis/pkg/client/clientset/versioned/fake"
 	informers "volcano.sh/apis/pkg/client/informers/externalversions"
+	"volcano.sh/volcano/pkg/controllers/jobflow/parameter"
 )
 
 func TestValidateJobFlowCreate(t *testing.T) {
@@ -313,3 +316,94 @@ func TestValidateJobFlowCreate(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateJobFlowParameters(t *testing.T) {
+	namespace := "test"
+	jobTemplate := &flowv1alpha1.JobTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "train",
+			Namespace: namespace,
+			Annotations: map[string]string{
+				parameter.TemplateParametersAnnotation: `[{"name":"model"},{"name":"epochs","type":"int","default":"10"}]`,
+			},
+		},
+		Spec: batchv1alpha1.JobSpec{
+			Queue: "default",
+			Tasks: []batchv1alpha1.TaskSpec{{
+				Name: "worker",
+				Template: v1.PodTemplateSpec{
+					Spec: v1.PodSpec{
+						Containers: []v1.Container{{Name: "worker", Args: []string{"--model={{params.model}}", "--epochs={{params.epochs}}"}}},
+					},
+				},
+			}},
+		},
+	}
+	config.VolcanoClient = fakeclient.NewSimpleClientset(jobTemplate)
+
+	newJobFlow := func(parameters string) *flowv1alpha1.JobFlow {
+		return &flowv1alpha1.JobFlow{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "flow",
+				Namespace:   namespace,
+				Annotations: map[string]string{parameter.FlowParametersAnnotation: parameters},
+			},
+			Spec: flowv1alpha1.JobFlowSpec{
+				Flows: []flowv1alpha1.Flow{
+					{Name: "prepare"},
+					{Name: "train", DependsOn: &flowv1alpha1.DependsOn{Targets: []string{"prepare"}}},
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name       string
+		parameters string
+		expectErr  bool
+	}{
+		{
+			name:       "valid parameters",
+			parameters: `{"train":{"model":"resnet","epochs":"20"}}`,
+		},
+		{
+			name:       "reference to upstream output",
+			parameters: `{"train":{"model":"{{jobs.prepare.annotations.example.com/model}}"}}`,
+		},
+		{
+			name:       "reference to job not depended on",
+			parameters: `{"prepare":{"model":"{{jobs.train.annotations.example.com/model}}"}}`,
+			expectErr:  true,
+		},
+		{
+			name:       "undefined flow",
+			parameters: `{"evaluate":{"model":"resnet"}}`,
+			expectErr:  true,
+		},
+		{
+			name:       "required parameter is missing",
+			parameters: `{"train":{"epochs":"20"}}`,
+			expectErr:  true,
+		},
+		{
+			name:       "mismatched type",
+			parameters: `{"train":{"model":"resnet","epochs":"many"}}`,
+			expectErr:  true,
+		},
+		{
+			name:       "invalid annotation",
+			parameters: `{"train":`,
+			expectErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reviewResponse := admissionv1.AdmissionResponse{Allowed: true}
+			msg := validateJobFlowParameters(newJobFlow(tc.parameters), &reviewResponse)
+			if tc.expectErr == reviewResponse.Allowed {
+				t.Errorf("expected error %v, got allowed %v with message %q", tc.expectErr, reviewResponse.Allowed, msg)
+			}
+		})
+	}
+}
@@ -0,0 +1,277 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutate
+
+import (
+	"encoding/json"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	webhookconfig "volcano.sh/volcano/pkg/webhooks/config"
+)
+
+// InjectedDefaultsAnnotation records the fields injected by each default profile, e.g. {"gpu-team":["tolerations","plugins.ssh"]}
+const InjectedDefaultsAnnotation = "volcano.sh/injected-defaults"
+
+// injectedDefaults records the fields injected into the job by profile name
+type injectedDefaults struct {
+	fields       map[string][]string
+	tasks        bool
+	priorityName bool
+	ttl          bool
+}
+
+func (in *injectedDefaults) record(profile, field string) {
+	if in.fields == nil {
+		in.fields = map[string][]string{}
+	}
+	in.fields[profile] = append(in.fields[profile], field)
+}
+
+// matchedJobDefaults returns the profiles matching the job, ordered by precedence.
+func matchedJobDefaults(conf *webhookconfig.AdmissionConfiguration, job *v1alpha1.Job) []webhookconfig.JobDefaultProfile {
+	if conf == nil {
+		return nil
+	}
+	conf.Lock()
+	profiles := conf.JobDefaults
+	conf.Unlock()
+
+	queue := job.Spec.Queue
+	if queue == "" {
+		queue = DefaultQueue
+	}
+
+	type matched struct {
+		profile     webhookconfig.JobDefaultProfile
+		specificity int
+	}
+	var matches []matched
+	for _, profile := range profiles {
+		specificity := 0
+		if len(profile.Queues) > 0 {
+			if !contains(profile.Queues, queue) {
+				continue
+			}
+			specificity += 2
+		}
+		if len(profile.Namespaces) > 0 {
+			if !contains(profile.Namespaces, job.Namespace) {
+				continue
+			}
+			specificity++
+		}
+		matches = append(matches, matched{profile: profile, specificity: specificity})
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].specificity > matches[j].specificity
+	})
+
+	result := make([]webhookconfig.JobDefaultProfile, 0, len(matches))
+	for _, m := range matches {
+		result = append(result, m.profile)
+	}
+	return result
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// applyJobDefaults injects the defaults of the profiles into the job in order of precedence,
+// a field is only injected if it is neither set by the job nor by a profile with higher precedence.
+func applyJobDefaults(job *v1alpha1.Job, profiles []webhookconfig.JobDefaultProfile) *injectedDefaults {
+	injected := &injectedDefaults{}
+	for _, profile := range profiles {
+		if job.Spec.PriorityClassName == "" && profile.PriorityClassName != "" {
+			job.Spec.PriorityClassName = profile.PriorityClassName
+			injected.priorityName = true
+			injected.record(profile.Name, "priorityClassName")
+		}
+
+		if job.Spec.TTLSecondsAfterFinished == nil && profile.TTLSecondsAfterFinished != nil {
+			ttl := *profile.TTLSecondsAfterFinished
+			job.Spec.TTLSecondsAfterFinished = &ttl
+			injected.ttl = true
+			injected.record(profile.Name, "ttlSecondsAfterFinished")
+		}
+
+		for name, args := range profile.Plugins {
+			if _, found := job.Spec.Plugins[name]; found {
+				continue
+			}
+			if job.Spec.Plugins == nil {
+				job.Spec.Plugins = map[string][]string{}
+			}
+			job.Spec.Plugins[name] = append([]string{}, args...)
+			injected.record(profile.Name, "plugins."+name)
+		}
+
+		if injectTolerations(job, profile.Tolerations) {
+			injected.tasks = true
+			injected.record(profile.Name, "tolerations")
+		}
+		if injectNodeSelector(job, profile.NodeSelector) {
+			injected.tasks = true
+			injected.record(profile.Name, "nodeSelector")
+		}
+		if injectResources(job, profile) {
+			injected.tasks = true
+			injected.record(profile.Name, "resources")
+		}
+	}
+	for _, fields := range injected.fields {
+		sort.Strings(fields)
+	}
+	return injected
+}
+
+func injectTolerations(job *v1alpha1.Job, tolerations []v1.Toleration) bool {
+	injected := false
+	for index := range job.Spec.Tasks {
+		spec := &job.Spec.Tasks[index].Template.Spec
+		for _, toleration := range tolerations {
+			if hasToleration(spec.Tolerations, toleration) {
+				continue
+			}
+			spec.Tolerations = append(spec.Tolerations, toleration)
+			injected = true
+		}
+	}
+	return injected
+}
+
+func hasToleration(tolerations []v1.Toleration, toleration v1.Toleration) bool {
+	for _, t := range tolerations {
+		if t.Key == toleration.Key && t.Effect == toleration.Effect {
+			return true
+		}
+	}
+	return false
+}
+
+func injectNodeSelector(job *v1alpha1.Job, nodeSelector map[string]string) bool {
+	injected := false
+	for index := range job.Spec.Tasks {
+		spec := &job.Spec.Tasks[index].Template.Spec
+		for key, value := range nodeSelector {
+			if _, found := spec.NodeSelector[key]; found {
+				continue
+			}
+			if spec.NodeSelector == nil {
+				spec.NodeSelector = map[string]string{}
+			}
+			spec.NodeSelector[key] = value
+			injected = true
+		}
+	}
+	return injected
+}
+
+func injectResources(job *v1alpha1.Job, profile webhookconfig.JobDefaultProfile) bool {
+	requests := parseResourceList(profile.Name, profile.Requests)
+	limits := parseResourceList(profile.Name, profile.Limits)
+	if len(requests) == 0 && len(limits) == 0 {
+		return false
+	}
+
+	injected := false
+	for index := range job.Spec.Tasks {
+		containers := job.Spec.Tasks[index].Template.Spec.Containers
+		for i := range containers {
+			before := containers[i].Resources.DeepCopy()
+			containers[i].Resources.Requests = fillResourceList(containers[i].Resources.Requests, requests)
+			containers[i].Resources.Limits = fillResourceList(containers[i].Resources.Limits, limits)
+			if !equality.Semantic.DeepEqual(before, &containers[i].Resources) {
+				injected = true
+			}
+		}
+	}
+	return injected
+}
+
+func parseResourceList(profile string, values map[string]string) v1.ResourceList {
+	list := v1.ResourceList{}
+	for name, value := range values {
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			klog.Errorf("Invalid quantity %s=%s in job default profile <%s>: %v", name, value, profile, err)
+			continue
+		}
+		list[v1.ResourceName(name)] = quantity
+	}
+	return list
+}
+
+func fillResourceList(list, defaults v1.ResourceList) v1.ResourceList {
+	for name, quantity := range defaults {
+		if _, found := list[name]; found {
+			continue
+		}
+		if list == nil {
+			list = v1.ResourceList{}
+		}
+		list[name] = quantity.DeepCopy()
+	}
+	return list
+}
+
+// patchJobDefaults injects the default profiles matching the job, the job is updated in place.
+func patchJobDefaults(job *v1alpha1.Job) (*injectedDefaults, []patchOperation) {
+	profiles := matchedJobDefaults(config.ConfigData, job)
+	if len(profiles) == 0 {
+		return nil, nil
+	}
+
+	injected := applyJobDefaults(job, profiles)
+	if len(injected.fields) == 0 {
+		return nil, nil
+	}
+
+	var patch []patchOperation
+	if injected.priorityName {
+		patch = append(patch, patchOperation{Op: "add", Path: "/spec/priorityClassName", Value: job.Spec.PriorityClassName})
+	}
+	if injected.ttl {
+		patch = append(patch, patchOperation{Op: "add", Path: "/spec/ttlSecondsAfterFinished", Value: *job.Spec.TTLSecondsAfterFinished})
+	}
+
+	explanation, err := json.Marshal(injected.fields)
+	if err != nil {
+		klog.Errorf("Failed to marshal injected defaults of job <%s/%s>: %v", job.Namespace, job.Name, err)
+		return injected, patch
+	}
+	if job.Annotations == nil {
+		patch = append(patch, patchOperation{Op: "add", Path: "/metadata/annotations",
+			Value: map[string]string{InjectedDefaultsAnnotation: string(explanation)}})
+	} else {
+		patch = append(patch, patchOperation{Op: "add", Path: "/metadata/annotations/volcano.sh~1injected-defaults",
+			Value: string(explanation)})
+	}
+	klog.V(3).Infof("Injected defaults %s into job <%s/%s>", explanation, job.Namespace, job.Name)
+	return injected, patch
+}
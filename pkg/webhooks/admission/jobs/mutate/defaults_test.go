@@ -0,0 +1,164 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutate
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	webhookconfig "volcano.sh/volcano/pkg/webhooks/config"
+)
+
+func TestPatchJobDefaults(t *testing.T) {
+	ttl := int32(600)
+	clusterTTL := int32(3600)
+	profiles := []webhookconfig.JobDefaultProfile{
+		{
+			Name:                    "cluster",
+			TTLSecondsAfterFinished: &clusterTTL,
+			PriorityClassName:       "low",
+			Limits:                  map[string]string{"cpu": "2", "memory": "4Gi"},
+		},
+		{
+			Name:       "team-a",
+			Namespaces: []string{"team-a"},
+			Plugins:    map[string][]string{"ssh": {}, "svc": {}},
+			NodeSelector: map[string]string{
+				"volcano.sh/nodetype": "cpu",
+			},
+		},
+		{
+			Name:                    "gpu",
+			Queues:                  []string{"gpu"},
+			PriorityClassName:       "high",
+			TTLSecondsAfterFinished: &ttl,
+			Tolerations: []v1.Toleration{
+				{Key: "nvidia.com/gpu", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule},
+			},
+			NodeSelector: map[string]string{
+				"volcano.sh/nodetype": "gpu",
+			},
+		},
+		{
+			Name:       "team-b",
+			Namespaces: []string{"team-b"},
+			Plugins:    map[string][]string{"env": {}},
+		},
+	}
+
+	newJob := func(namespace, queue string) *v1alpha1.Job {
+		return &v1alpha1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: namespace},
+			Spec: v1alpha1.JobSpec{
+				Queue:   queue,
+				Plugins: map[string][]string{"svc": {"--disable-network-policy=true"}},
+				Tasks: []v1alpha1.TaskSpec{{
+					Name:     "worker",
+					Replicas: 2,
+					Template: v1.PodTemplateSpec{
+						Spec: v1.PodSpec{
+							Containers: []v1.Container{{
+								Name: "worker",
+								Resources: v1.ResourceRequirements{
+									Limits: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+								},
+							}},
+						},
+					},
+				}},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name             string
+		job              *v1alpha1.Job
+		expectedInjected map[string][]string
+		check            func(t *testing.T, job *v1alpha1.Job)
+	}{
+		{
+			name: "queue profile takes precedence over namespace and cluster profiles",
+			job:  newJob("team-a", "gpu"),
+			expectedInjected: map[string][]string{
+				"gpu":     {"nodeSelector", "priorityClassName", "tolerations", "ttlSecondsAfterFinished"},
+				"team-a":  {"plugins.ssh"},
+				"cluster": {"resources"},
+			},
+			check: func(t *testing.T, job *v1alpha1.Job) {
+				spec := job.Spec.Tasks[0].Template.Spec
+				if job.Spec.PriorityClassName != "high" || *job.Spec.TTLSecondsAfterFinished != ttl {
+					t.Errorf("expected the defaults of queue profile, got %s, %d", job.Spec.PriorityClassName, *job.Spec.TTLSecondsAfterFinished)
+				}
+				if spec.NodeSelector["volcano.sh/nodetype"] != "gpu" || len(spec.Tolerations) != 1 {
+					t.Errorf("expected the node selector and tolerations of queue profile, got %v, %v", spec.NodeSelector, spec.Tolerations)
+				}
+				if !reflect.DeepEqual(job.Spec.Plugins["svc"], []string{"--disable-network-policy=true"}) {
+					t.Errorf("expected the plugin arguments of job to be kept, got %v", job.Spec.Plugins["svc"])
+				}
+				limits := spec.Containers[0].Resources.Limits
+				if limits.Cpu().String() != "1" || limits.Memory().String() != "4Gi" {
+					t.Errorf("expected only the missing limits to be injected, got %v", limits)
+				}
+			},
+		},
+		{
+			name: "profiles of other namespaces are ignored",
+			job:  newJob("team-c", ""),
+			expectedInjected: map[string][]string{
+				"cluster": {"priorityClassName", "resources", "ttlSecondsAfterFinished"},
+			},
+			check: func(t *testing.T, job *v1alpha1.Job) {
+				if _, found := job.Spec.Plugins["env"]; found {
+					t.Errorf("expected no plugin injected, got %v", job.Spec.Plugins)
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config.ConfigData = &webhookconfig.AdmissionConfiguration{JobDefaults: profiles}
+			defer func() { config.ConfigData = nil }()
+
+			injected, patch := patchJobDefaults(tc.job)
+			if injected == nil {
+				t.Fatalf("expected defaults to be injected")
+			}
+			if !reflect.DeepEqual(injected.fields, tc.expectedInjected) {
+				t.Errorf("expected injected %v, got %v", tc.expectedInjected, injected.fields)
+			}
+
+			var explanation string
+			for _, p := range patch {
+				if p.Path == "/metadata/annotations" {
+					explanation = p.Value.(map[string]string)[InjectedDefaultsAnnotation]
+				}
+			}
+			expected, _ := json.Marshal(tc.expectedInjected)
+			if explanation != string(expected) {
+				t.Errorf("expected annotation %s, got %s", expected, explanation)
+			}
+			tc.check(t, tc.job)
+		})
+	}
+}
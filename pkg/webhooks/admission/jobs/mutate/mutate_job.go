@@ -27,8 +27,12 @@ import (
 	"k8s.io/klog/v2"
 
 	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/deepspeed"
+	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/jax"
 	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/mpi"
+	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/paddle"
 	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/pytorch"
+	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/ray"
 	"volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/tensorflow"
 	commonutil "volcano.sh/volcano/pkg/util"
 	"volcano.sh/volcano/pkg/webhooks/router"
@@ -113,6 +117,9 @@ func Jobs(ar admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
 
 func createPatch(job *v1alpha1.Job) ([]byte, error) {
 	var patch []patchOperation
+	// Inject the operator defined defaults first, so that the built-in defaults are derived from them
+	injected, patchDefaults := patchJobDefaults(job)
+	patch = append(patch, patchDefaults...)
 	pathQueue := patchDefaultQueue(job)
 	if pathQueue != nil {
 		patch = append(patch, *pathQueue)
@@ -128,6 +135,8 @@ func createPatch(job *v1alpha1.Job) ([]byte, error) {
 	pathSpec := mutateSpec(job.Spec.Tasks, "/spec/tasks", job)
 	if pathSpec != nil {
 		patch = append(patch, *pathSpec)
+	} else if injected != nil && injected.tasks {
+		patch = append(patch, patchOperation{Op: "replace", Path: "/spec/tasks", Value: job.Spec.Tasks})
 	}
 	pathMinAvailable := patchDefaultMinAvailable(job)
 	if pathMinAvailable != nil {
@@ -231,25 +240,30 @@ func patchDefaultPlugins(job *v1alpha1.Job) *patchOperation {
 		plugins[k] = v
 	}
 
-	// Because the tensorflow-plugin, mpi-plugin and pytorch-plugin depend on svc-plugin.
+	// Because the distributed-framework plugins depend on svc-plugin.
 	// If the svc-plugin is not defined, we should add it.
 	_, hasTf := job.Spec.Plugins[tensorflow.TFPluginName]
 	_, hasMPI := job.Spec.Plugins[mpi.MPIPluginName]
 	_, hasPytorch := job.Spec.Plugins[pytorch.PytorchPluginName]
-	if hasTf || hasMPI || hasPytorch {
+	_, hasRay := job.Spec.Plugins[ray.RayPluginName]
+	_, hasJax := job.Spec.Plugins[jax.JaxPluginName]
+	_, hasDeepSpeed := job.Spec.Plugins[deepspeed.DeepSpeedPluginName]
+	_, hasPaddle := job.Spec.Plugins[paddle.PaddlePluginName]
+	if hasTf || hasMPI || hasPytorch || hasRay || hasJax || hasDeepSpeed || hasPaddle {
 		if _, ok := plugins["svc"]; !ok {
 			plugins["svc"] = []string{}
 		}
 	}
 
-	if _, ok := job.Spec.Plugins["mpi"]; ok {
+	// The DeepSpeed launcher starts the processes on the hosts through ssh as mpirun does.
+	if hasMPI || hasDeepSpeed {
 		if _, ok := plugins["ssh"]; !ok {
 			plugins["ssh"] = []string{}
 		}
 	}
 
 	return &patchOperation{
-		Op:    "replace",
+		Op:    "add",
 		Path:  "/spec/plugins",
 		Value: plugins,
 	}
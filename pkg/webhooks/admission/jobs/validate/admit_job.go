@@ -17,11 +17,14 @@ limitations under the License.
 package validate
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	whv1 "k8s.io/api/admissionregistration/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	v1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -39,6 +42,12 @@ import (
 	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
 	"volcano.sh/volcano/pkg/controllers/job/plugins"
 	controllerMpi "volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/mpi"
+	controllerRay "volcano.sh/volcano/pkg/controllers/job/plugins/distributed-framework/ray"
+	"volcano.sh/volcano/pkg/controllers/job/plugins/external"
+	"volcano.sh/volcano/pkg/controllers/job/plugins/ssh"
+	"volcano.sh/volcano/pkg/controllers/job/plugins/svc"
+	"volcano.sh/volcano/pkg/controllers/job/state"
+	"volcano.sh/volcano/pkg/webhooks/policy"
 	"volcano.sh/volcano/pkg/webhooks/router"
 	"volcano.sh/volcano/pkg/webhooks/schema"
 	"volcano.sh/volcano/pkg/webhooks/util"
@@ -96,6 +105,12 @@ func AdmitJobs(ar admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
 	switch ar.Request.Operation {
 	case admissionv1.Create:
 		msg = validateJobCreate(job, &reviewResponse)
+		if reviewResponse.Allowed {
+			if err := validateSSHKeySecret(job, ar.Request.UserInfo); err != nil {
+				reviewResponse.Allowed = false
+				msg = err.Error()
+			}
+		}
 	case admissionv1.Update:
 		oldJob, err := schema.DecodeJob(ar.Request.OldObject, ar.Request.Resource)
 		if err != nil {
@@ -110,6 +125,15 @@ func AdmitJobs(ar admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
 		return util.ToAdmissionResponse(err)
 	}
 
+	if reviewResponse.Allowed {
+		warnings, err := policy.Validate(config.ConfigData, ar.Request)
+		reviewResponse.Warnings = warnings
+		if err != nil {
+			reviewResponse.Allowed = false
+			msg = err.Error()
+		}
+	}
+
 	if !reviewResponse.Allowed {
 		reviewResponse.Result = &metav1.Status{Message: strings.TrimSpace(msg)}
 	}
@@ -155,6 +179,32 @@ func validateJobCreate(job *v1alpha1.Job, reviewResponse *admissionv1.AdmissionR
 		}
 	}
 
+	if _, ok := job.Spec.Plugins[controllerRay.RayPluginName]; ok {
+		if msg := validateRayTasks(job); msg != "" {
+			reviewResponse.Allowed = false
+			return msg
+		}
+	}
+
+	if arguments, ok := job.Spec.Plugins[external.ExternalPluginName]; ok && len(external.EndpointNames(arguments)) == 0 {
+		reviewResponse.Allowed = false
+		return fmt.Sprintf("The %s plugin requires at least one endpoint set by --endpoint", external.ExternalPluginName)
+	}
+
+	if arguments, ok := job.Spec.Plugins["svc"]; ok {
+		if err := svc.ValidateArguments(arguments, job); err != nil {
+			reviewResponse.Allowed = false
+			return fmt.Sprintf("Invalid arguments of svc plugin: %v", err)
+		}
+	}
+
+	if arguments, ok := job.Spec.Plugins["ssh"]; ok {
+		if err := ssh.ValidateArguments(arguments, job); err != nil {
+			reviewResponse.Allowed = false
+			return fmt.Sprintf("Invalid arguments of ssh plugin: %v", err)
+		}
+	}
+
 	hasDependenciesBetweenTasks := false
 	for index, task := range job.Spec.Tasks {
 		if task.DependsOn != nil {
@@ -209,6 +259,18 @@ func validateJobCreate(job *v1alpha1.Job, reviewResponse *admissionv1.AdmissionR
 			getValidEvents(), getValidActions())
 	}
 
+	if err := validateRestartBudget(job, field.NewPath("metadata.annotations").Key(state.RestartBudgetAnnotationKey)); err != nil {
+		msg += err.Error() + ";"
+	}
+
+	if err := validateStartupPolicy(job, field.NewPath("metadata.annotations").Key(state.StartupPolicyAnnotationKey)); err != nil {
+		msg += err.Error() + ";"
+	}
+
+	if err := validateTaskRoles(job, field.NewPath("spec.tasks")); err != nil {
+		msg += err.Error() + ";"
+	}
+
 	// invalid job plugins
 	if len(job.Spec.Plugins) != 0 {
 		for name := range job.Spec.Plugins {
@@ -265,6 +327,46 @@ func validateJobCreate(job *v1alpha1.Job, reviewResponse *admissionv1.AdmissionR
 	return msg
 }
 
+// validateSSHKeySecret checks that the user creating the job is allowed to get the secret holding the
+// ssh private key, as the job controller copies the key into the secret of the job.
+func validateSSHKeySecret(job *v1alpha1.Job, userInfo authenticationv1.UserInfo) error {
+	arguments, ok := job.Spec.Plugins["ssh"]
+	if !ok {
+		return nil
+	}
+	secretName := ssh.KeySecretName(arguments)
+	if secretName == "" {
+		return nil
+	}
+
+	extra := make(map[string]authorizationv1.ExtraValue, len(userInfo.Extra))
+	for key, value := range userInfo.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   userInfo.Username,
+			UID:    userInfo.UID,
+			Groups: userInfo.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: job.Namespace,
+				Verb:      "get",
+				Resource:  "secrets",
+				Name:      secretName,
+			},
+		},
+	}
+	review, err := config.KubeClient.AuthorizationV1().SubjectAccessReviews().Create(context.TODO(), review, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to check the access to ssh key secret %s: %v", secretName, err)
+	}
+	if !review.Status.Allowed {
+		return fmt.Errorf("user %s is not allowed to get ssh key secret %s", userInfo.Username, secretName)
+	}
+	return nil
+}
+
 func validateJobUpdate(old, new *v1alpha1.Job) error {
 	var totalReplicas int32
 	for _, task := range new.Spec.Tasks {
@@ -371,6 +473,22 @@ func validateJobName(job *v1alpha1.Job) string {
 	return ""
 }
 
+// validateRayTasks checks that a ray job has exactly one head pod, the worker task is optional.
+func validateRayTasks(job *v1alpha1.Job) string {
+	rp := controllerRay.NewInstance(job.Spec.Plugins[controllerRay.RayPluginName])
+	if rp.GetHeadName() == rp.GetWorkerName() {
+		return fmt.Sprintf("The ray head and worker task can not be the same task %s", rp.GetHeadName())
+	}
+	headIndex := jobhelpers.GetTaskIndexUnderJob(rp.GetHeadName(), job)
+	if headIndex == -1 {
+		return "The specified ray head task was not found"
+	}
+	if replicas := job.Spec.Tasks[headIndex].Replicas; replicas != 1 {
+		return fmt.Sprintf("The ray head task %s must have exactly 1 replica, got %d", rp.GetHeadName(), replicas)
+	}
+	return ""
+}
+
 func validateTaskTopoPolicy(task v1alpha1.TaskSpec, index int) string {
 	if task.TopologyPolicy == "" || task.TopologyPolicy == v1alpha1.None {
 		return ""
@@ -22,10 +22,15 @@ import (
 	"testing"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 
 	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
 	busv1alpha1 "volcano.sh/apis/pkg/apis/bus/v1alpha1"
@@ -1688,3 +1693,104 @@ func TestValidateTaskTopoPolicy(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateRayTasks(t *testing.T) {
+	testCases := []struct {
+		name    string
+		plugins []string
+		tasks   []v1alpha1.TaskSpec
+		expect  string
+	}{
+		{
+			name:    "head and workers",
+			plugins: []string{},
+			tasks:   []v1alpha1.TaskSpec{{Name: "head", Replicas: 1}, {Name: "worker", Replicas: 3}},
+			expect:  "",
+		},
+		{
+			name:    "head only with custom name",
+			plugins: []string{"--head=ray-head"},
+			tasks:   []v1alpha1.TaskSpec{{Name: "ray-head", Replicas: 1}},
+			expect:  "",
+		},
+		{
+			name:    "head not found",
+			plugins: []string{},
+			tasks:   []v1alpha1.TaskSpec{{Name: "worker", Replicas: 3}},
+			expect:  "ray head task was not found",
+		},
+		{
+			name:    "head with multiple replicas",
+			plugins: []string{},
+			tasks:   []v1alpha1.TaskSpec{{Name: "head", Replicas: 2}},
+			expect:  "must have exactly 1 replica",
+		},
+		{
+			name:    "head is worker",
+			plugins: []string{"--head=node", "--worker=node"},
+			tasks:   []v1alpha1.TaskSpec{{Name: "node", Replicas: 1}},
+			expect:  "can not be the same task",
+		},
+	}
+
+	for _, testcase := range testCases {
+		job := &v1alpha1.Job{
+			Spec: v1alpha1.JobSpec{
+				Plugins: map[string][]string{"ray": testcase.plugins},
+				Tasks:   testcase.tasks,
+			},
+		}
+		msg := validateRayTasks(job)
+		if testcase.expect == "" && msg != "" || !strings.Contains(msg, testcase.expect) {
+			t.Errorf("%s failed, got %q", testcase.name, msg)
+		}
+	}
+}
+
+func TestValidateSSHKeySecret(t *testing.T) {
+	kubeClient := kubefake.NewSimpleClientset()
+	kubeClient.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		attributes := review.Spec.ResourceAttributes
+		review.Status.Allowed = review.Spec.User == "alice" && attributes.Verb == "get" &&
+			attributes.Resource == "secrets" && attributes.Namespace == "ns1" && attributes.Name == "alice-key"
+		return true, review, nil
+	})
+	config.KubeClient = kubeClient
+	defer func() { config.KubeClient = nil }()
+
+	testCases := []struct {
+		name    string
+		plugins map[string][]string
+		user    string
+		expect  string
+	}{
+		{
+			name:    "generated key",
+			plugins: map[string][]string{"ssh": {}},
+			user:    "bob",
+		},
+		{
+			name:    "user can get the secret",
+			plugins: map[string][]string{"ssh": {"--ssh-key-secret=alice-key"}},
+			user:    "alice",
+		},
+		{
+			name:    "user can not get the secret",
+			plugins: map[string][]string{"ssh": {"--ssh-key-secret=alice-key"}},
+			user:    "bob",
+			expect:  "user bob is not allowed to get ssh key secret alice-key",
+		},
+	}
+
+	for _, testcase := range testCases {
+		job := &v1alpha1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "job1", Namespace: "ns1"},
+			Spec:       v1alpha1.JobSpec{Plugins: testcase.plugins},
+		}
+		err := validateSSHKeySecret(job, authenticationv1.UserInfo{Username: testcase.user})
+		if testcase.expect == "" && err != nil || testcase.expect != "" && (err == nil || !strings.Contains(err.Error(), testcase.expect)) {
+			t.Errorf("%s failed, got %v", testcase.name, err)
+		}
+	}
+}
@@ -25,6 +25,7 @@ import (
 
 	batchv1alpha1 "volcano.sh/apis/pkg/apis/batch/v1alpha1"
 	busv1alpha1 "volcano.sh/apis/pkg/apis/bus/v1alpha1"
+	"volcano.sh/volcano/pkg/controllers/job/state"
 )
 
 // policyEventMap defines all policy events and whether to allow external use.
@@ -121,6 +122,126 @@ func validatePolicies(policies []batchv1alpha1.LifecyclePolicy, fldPath *field.P
 	return err
 }
 
+// validateRestartBudget validates the restart budget of the RestartTask and RestartPod policy actions.
+func validateRestartBudget(job *batchv1alpha1.Job, fldPath *field.Path) error {
+	budget, err := state.GetRestartBudget(job)
+	if err != nil {
+		return field.Invalid(fldPath, job.Annotations[state.RestartBudgetAnnotationKey], err.Error())
+	}
+	if budget == nil {
+		return nil
+	}
+
+	var errs error
+	if err := validateTaskRestartBudget(budget.TaskRestartBudget, fldPath); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+	taskNames := map[string]struct{}{}
+	for _, task := range job.Spec.Tasks {
+		taskNames[task.Name] = struct{}{}
+	}
+	for name, taskBudget := range budget.Tasks {
+		if _, found := taskNames[name]; !found {
+			errs = multierror.Append(errs, field.NotFound(fldPath.Child("tasks"), name))
+			continue
+		}
+		if err := validateTaskRestartBudget(taskBudget, fldPath.Child("tasks", name)); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	return errs
+}
+
+func validateTaskRestartBudget(budget state.TaskRestartBudget, fldPath *field.Path) error {
+	var errs error
+	if budget.MaxRestarts != nil && *budget.MaxRestarts < 0 {
+		errs = multierror.Append(errs, field.Invalid(fldPath.Child("maxRestarts"), *budget.MaxRestarts, "must not be negative"))
+	}
+	if backoff := budget.Backoff; backoff != nil {
+		if backoff.InitialDelay.Duration <= 0 {
+			errs = multierror.Append(errs, field.Invalid(fldPath.Child("backoff", "initialDelay"), backoff.InitialDelay.Duration.String(), "must be positive"))
+		}
+		if backoff.MaxDelay != nil && backoff.MaxDelay.Duration < backoff.InitialDelay.Duration {
+			errs = multierror.Append(errs, field.Invalid(fldPath.Child("backoff", "maxDelay"), backoff.MaxDelay.Duration.String(), "must not be less than initialDelay"))
+		}
+		if backoff.Factor != nil && *backoff.Factor < 1 {
+			errs = multierror.Append(errs, field.Invalid(fldPath.Child("backoff", "factor"), *backoff.Factor, "must not be less than 1"))
+		}
+	}
+	if crashLoop := budget.CrashLoop; crashLoop != nil {
+		if crashLoop.Restarts <= 0 {
+			errs = multierror.Append(errs, field.Invalid(fldPath.Child("crashLoop", "restarts"), crashLoop.Restarts, "must be positive"))
+		}
+		if crashLoop.Window.Duration <= 0 {
+			errs = multierror.Append(errs, field.Invalid(fldPath.Child("crashLoop", "window"), crashLoop.Window.Duration.String(), "must be positive"))
+		}
+	}
+	if budget.Action != "" {
+		// Escalating to a restart of the task would restart it again.
+		if allow, ok := policyActionMap[budget.Action]; !ok || !allow ||
+			budget.Action == busv1alpha1.RestartTaskAction || budget.Action == busv1alpha1.RestartPodAction {
+			errs = multierror.Append(errs, field.Invalid(fldPath.Child("action"), budget.Action, "invalid escalation action"))
+		}
+	}
+	return errs
+}
+
+// validateStartupPolicy validates the startup policy of the tasks with dependsOn.
+func validateStartupPolicy(job *batchv1alpha1.Job, fldPath *field.Path) error {
+	policy, err := state.GetStartupPolicy(job)
+	if err != nil {
+		return field.Invalid(fldPath, job.Annotations[state.StartupPolicyAnnotationKey], err.Error())
+	}
+	if policy == nil {
+		return nil
+	}
+
+	var errs error
+	if err := validateTaskStartupPolicy(policy.TaskStartupPolicy, fldPath); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+	taskNames := map[string]struct{}{}
+	for _, task := range job.Spec.Tasks {
+		taskNames[task.Name] = struct{}{}
+	}
+	for name, taskPolicy := range policy.Tasks {
+		if _, found := taskNames[name]; !found {
+			errs = multierror.Append(errs, field.NotFound(fldPath.Child("tasks"), name))
+			continue
+		}
+		if err := validateTaskStartupPolicy(taskPolicy, fldPath.Child("tasks", name)); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	return errs
+}
+
+func validateTaskStartupPolicy(policy state.TaskStartupPolicy, fldPath *field.Path) error {
+	var errs error
+	switch policy.Condition {
+	case "", state.StartupConditionReady, state.StartupConditionRunning:
+		if policy.ReadinessGate != "" {
+			errs = multierror.Append(errs, field.Invalid(fldPath.Child("readinessGate"), policy.ReadinessGate,
+				fmt.Sprintf("must only be set with condition %s", state.StartupConditionReadinessGate)))
+		}
+	case state.StartupConditionReadinessGate:
+		if policy.ReadinessGate == "" {
+			errs = multierror.Append(errs, field.Required(fldPath.Child("readinessGate"),
+				fmt.Sprintf("must be set with condition %s", state.StartupConditionReadinessGate)))
+		}
+	default:
+		errs = multierror.Append(errs, field.NotSupported(fldPath.Child("condition"), policy.Condition,
+			[]string{string(state.StartupConditionReady), string(state.StartupConditionRunning), string(state.StartupConditionReadinessGate)}))
+	}
+	if policy.MinPods != nil && *policy.MinPods < 0 {
+		errs = multierror.Append(errs, field.Invalid(fldPath.Child("minPods"), *policy.MinPods, "must not be negative"))
+	}
+	if policy.Timeout != nil && policy.Timeout.Duration <= 0 {
+		errs = multierror.Append(errs, field.Invalid(fldPath.Child("timeout"), policy.Timeout.Duration.String(), "must be positive"))
+	}
+	return errs
+}
+
 func getEventList(policy batchv1alpha1.LifecyclePolicy) []busv1alpha1.Event {
 	policyEventsList := policy.Events
 	if len(policy.Event) > 0 {
@@ -250,3 +371,24 @@ func makeGraph(job *batchv1alpha1.Job) (map[string]map[string]bool, map[string]i
 
 	return graph, inDegree, taskList
 }
+
+// validateTaskRoles validates the roles of the tasks, a job must have a task which is not a sidecar.
+func validateTaskRoles(job *batchv1alpha1.Job, fldPath *field.Path) error {
+	var errs error
+	hasMainTask := false
+	for index, task := range job.Spec.Tasks {
+		role, found := task.Template.Annotations[state.TaskRoleAnnotationKey]
+		if !found {
+			hasMainTask = true
+			continue
+		}
+		if role != state.TaskRoleSidecar {
+			errs = multierror.Append(errs, field.NotSupported(fldPath.Index(index).Child("template.metadata.annotations").Key(state.TaskRoleAnnotationKey),
+				role, []string{state.TaskRoleSidecar}))
+		}
+	}
+	if !hasMainTask {
+		errs = multierror.Append(errs, field.Invalid(fldPath, len(job.Spec.Tasks), "all tasks are sidecars, at least one task must not be a sidecar"))
+	}
+	return errs
+}
@@ -17,10 +17,16 @@ limitations under the License.
 package validate
 
 import (
+	"fmt"
 	"testing"
 
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+
+	"volcano.sh/volcano/pkg/controllers/job/state"
 )
 
 func TestTopoSort(t *testing.T) {
@@ -150,3 +156,161 @@ func TestTopoSort(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateRestartBudget(t *testing.T) {
+	testCases := []struct {
+		name      string
+		budget    string
+		expectErr bool
+	}{
+		{
+			name:   "valid budget",
+			budget: `{"maxRestarts":3,"backoff":{"initialDelay":"10s","maxDelay":"5m"},"crashLoop":{"restarts":3,"window":"10m"},"action":"TerminateJob","tasks":{"worker":{"maxRestarts":5}}}`,
+		},
+		{
+			name:      "invalid json",
+			budget:    `{"maxRestarts":`,
+			expectErr: true,
+		},
+		{
+			name:      "negative max restarts",
+			budget:    `{"maxRestarts":-1}`,
+			expectErr: true,
+		},
+		{
+			name:      "max delay less than initial delay",
+			budget:    `{"backoff":{"initialDelay":"1m","maxDelay":"10s"}}`,
+			expectErr: true,
+		},
+		{
+			name:      "empty crash loop window",
+			budget:    `{"crashLoop":{"restarts":3}}`,
+			expectErr: true,
+		},
+		{
+			name:      "escalate to restart task",
+			budget:    `{"action":"RestartTask"}`,
+			expectErr: true,
+		},
+		{
+			name:      "unknown task",
+			budget:    `{"tasks":{"ps":{"maxRestarts":1}}}`,
+			expectErr: true,
+		},
+	}
+
+	for _, testcase := range testCases {
+		job := &v1alpha1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{state.RestartBudgetAnnotationKey: testcase.budget},
+			},
+			Spec: v1alpha1.JobSpec{
+				Tasks: []v1alpha1.TaskSpec{{Name: "worker"}},
+			},
+		}
+		err := validateRestartBudget(job, field.NewPath("metadata.annotations").Key(state.RestartBudgetAnnotationKey))
+		if (err != nil) != testcase.expectErr {
+			t.Errorf("%s failed, expected error: %v, got: %v", testcase.name, testcase.expectErr, err)
+		}
+	}
+}
+
+func TestValidateStartupPolicy(t *testing.T) {
+	testCases := []struct {
+		Name      string
+		Policy    string
+		ExpectErr bool
+	}{
+		{
+			Name:   "valid policy",
+			Policy: `{"timeout":"5m","tasks":{"worker":{"condition":"ReadinessGate","readinessGate":"example.com/loaded","minPods":1}}}`,
+		},
+		{
+			Name:      "invalid json",
+			Policy:    `{"timeout":`,
+			ExpectErr: true,
+		},
+		{
+			Name:      "unsupported condition",
+			Policy:    `{"condition":"Completed"}`,
+			ExpectErr: true,
+		},
+		{
+			Name:      "readiness gate without condition type",
+			Policy:    `{"condition":"ReadinessGate"}`,
+			ExpectErr: true,
+		},
+		{
+			Name:      "readiness gate with another condition",
+			Policy:    `{"condition":"Running","readinessGate":"example.com/loaded"}`,
+			ExpectErr: true,
+		},
+		{
+			Name:      "non positive timeout",
+			Policy:    `{"timeout":"0s"}`,
+			ExpectErr: true,
+		},
+		{
+			Name:      "unknown task",
+			Policy:    `{"tasks":{"chief":{"minPods":1}}}`,
+			ExpectErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			job := &v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{state.StartupPolicyAnnotationKey: testCase.Policy},
+				},
+				Spec: v1alpha1.JobSpec{
+					Tasks: []v1alpha1.TaskSpec{{Name: "ps"}, {Name: "worker"}},
+				},
+			}
+			err := validateStartupPolicy(job, field.NewPath("metadata.annotations").Key(state.StartupPolicyAnnotationKey))
+			if (err != nil) != testCase.ExpectErr {
+				t.Errorf("expected error %v, got %v", testCase.ExpectErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateTaskRoles(t *testing.T) {
+	sidecar := map[string]string{state.TaskRoleAnnotationKey: state.TaskRoleSidecar}
+	testCases := []struct {
+		Name      string
+		Roles     []map[string]string
+		ExpectErr bool
+	}{
+		{
+			Name:  "sidecar task",
+			Roles: []map[string]string{nil, sidecar},
+		},
+		{
+			Name:      "unsupported role",
+			Roles:     []map[string]string{nil, {state.TaskRoleAnnotationKey: "proxy"}},
+			ExpectErr: true,
+		},
+		{
+			Name:      "all tasks are sidecars",
+			Roles:     []map[string]string{sidecar, sidecar},
+			ExpectErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			job := &v1alpha1.Job{}
+			for i, annotations := range testCase.Roles {
+				job.Spec.Tasks = append(job.Spec.Tasks, v1alpha1.TaskSpec{
+					Name:     fmt.Sprintf("task%d", i),
+					Template: v1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Annotations: annotations}},
+				})
+			}
+			err := validateTaskRoles(job, field.NewPath("spec.tasks"))
+			if (err != nil) != testCase.ExpectErr {
+				t.Errorf("expected error %v, got %v", testCase.ExpectErr, err)
+			}
+		})
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"k8s.io/klog/v2"
 
 	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/webhooks/policy"
 	"volcano.sh/volcano/pkg/webhooks/router"
 	"volcano.sh/volcano/pkg/webhooks/schema"
 	"volcano.sh/volcano/pkg/webhooks/util"
@@ -74,15 +75,22 @@ func Validate(ar admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
 		err = fmt.Errorf("unsupported operation %s", ar.Request.Operation)
 	}
 
+	var warnings []string
+	if err == nil {
+		warnings, err = policy.Validate(config.ConfigData, ar.Request)
+	}
+
 	if err != nil {
 		return &admissionv1.AdmissionResponse{
-			Allowed: false,
-			Result:  &metav1.Status{Message: err.Error()},
+			Allowed:  false,
+			Result:   &metav1.Status{Message: err.Error()},
+			Warnings: warnings,
 		}
 	}
 
 	return &admissionv1.AdmissionResponse{
-		Allowed: true,
+		Allowed:  true,
+		Warnings: warnings,
 	}
 }
 
@@ -31,6 +31,7 @@ import (
 
 	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/webhooks/policy"
 	"volcano.sh/volcano/pkg/webhooks/router"
 	"volcano.sh/volcano/pkg/webhooks/schema"
 	"volcano.sh/volcano/pkg/webhooks/util"
@@ -99,15 +100,22 @@ func AdmitQueues(ar admissionv1.AdmissionReview) *admissionv1.AdmissionResponse
 			"expect operation to be `CREATE`, `UPDATE` or `DELETE`", ar.Request.Operation))
 	}
 
+	var warnings []string
+	if err == nil {
+		warnings, err = policy.Validate(config.ConfigData, ar.Request)
+	}
+
 	if err != nil {
 		return &admissionv1.AdmissionResponse{
-			Allowed: false,
-			Result:  &metav1.Status{Message: err.Error()},
+			Allowed:  false,
+			Result:   &metav1.Status{Message: err.Error()},
+			Warnings: warnings,
 		}
 	}
 
 	return &admissionv1.AdmissionResponse{
-		Allowed: true,
+		Allowed:  true,
+		Warnings: warnings,
 	}
 }
 
@@ -45,10 +45,54 @@ type ResGroupConfig struct {
 	Affinity      string            `yaml:"affinity"`
 }
 
+const (
+	// ValidationRuleEnforce denies the admission request violating the rule
+	ValidationRuleEnforce = "Enforce"
+	// ValidationRuleDryRun only audits the admission request violating the rule by logs and warnings
+	ValidationRuleDryRun = "DryRun"
+)
+
+// ValidationRule defines a CEL expression evaluated against the admission requests of resources,
+// the request violates the rule if the expression is not evaluated to true.
+type ValidationRule struct {
+	Name string `yaml:"name"`
+	// Resources the rule applies to, e.g. jobs, queues and podgroups
+	Resources []string `yaml:"resources"`
+	// Operations the rule applies to, e.g. CREATE and UPDATE; all operations if empty
+	Operations []string `yaml:"operations"`
+	// Expression can access `object`, `oldObject` and `request` with operation, namespace, name and userInfo
+	Expression string `yaml:"expression"`
+	// Message is returned to the user when the rule is violated
+	Message string `yaml:"message"`
+	// Mode is either Enforce or DryRun, it is Enforce by default
+	Mode string `yaml:"mode"`
+}
+
+// JobDefaultProfile defines the defaults injected into the vcjobs of the namespaces or queues,
+// a profile without namespaces and queues applies to all vcjobs. The values set in the vcjob
+// are never overridden, and a queue specific profile takes precedence over a namespace specific
+// profile, which takes precedence over a cluster wide one.
+type JobDefaultProfile struct {
+	Name       string   `yaml:"name"`
+	Namespaces []string `yaml:"namespaces"`
+	Queues     []string `yaml:"queues"`
+
+	Tolerations       []v1.Toleration   `yaml:"tolerations"`
+	NodeSelector      map[string]string `yaml:"nodeSelector"`
+	PriorityClassName string            `yaml:"priorityClassName"`
+	// Requests and Limits are injected into the containers of all tasks, e.g. cpu: "1"
+	Requests                map[string]string   `yaml:"requests"`
+	Limits                  map[string]string   `yaml:"limits"`
+	Plugins                 map[string][]string `yaml:"plugins"`
+	TTLSecondsAfterFinished *int32              `yaml:"ttlSecondsAfterFinished"`
+}
+
 // AdmissionConfiguration defines the configuration of admission.
 type AdmissionConfiguration struct {
 	sync.Mutex
-	ResGroupsConfig []ResGroupConfig `yaml:"resourceGroups"`
+	ResGroupsConfig []ResGroupConfig    `yaml:"resourceGroups"`
+	ValidationRules []ValidationRule    `yaml:"validationRules"`
+	JobDefaults     []JobDefaultProfile `yaml:"jobDefaults"`
 }
 
 var admissionConf AdmissionConfiguration
@@ -70,9 +114,16 @@ func LoadAdmissionConf(confPath string) *AdmissionConfiguration {
 		klog.Errorf("Unmarshal admission file failed, err=%v", err)
 		return nil
 	}
+	// keep the previous configuration rather than swapping in broken validation rules
+	if err := validateRules(data.ValidationRules); err != nil {
+		klog.Errorf("Invalid admission file, keep the previous configuration, err=%v", err)
+		return nil
+	}
 
 	admissionConf.Lock()
 	admissionConf.ResGroupsConfig = data.ResGroupsConfig
+	admissionConf.ValidationRules = data.ValidationRules
+	admissionConf.JobDefaults = data.JobDefaults
 	admissionConf.Unlock()
 	return &admissionConf
 }
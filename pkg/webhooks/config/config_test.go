@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadAdmissionConfValidationRules(t *testing.T) {
+	valid := `
+validationRules:
+- name: no-default-queue
+  resources: ["jobs"]
+  expression: "object.spec.queue != 'default'"
+  mode: DryRun
+`
+	testCases := []struct {
+		name    string
+		content string
+	}{
+		{
+			name: "expression fails to compile",
+			content: `
+validationRules:
+- name: invalid
+  resources: ["jobs"]
+  expression: "object.spec.queue =="
+`,
+		},
+		{
+			name: "expression is not evaluated to bool",
+			content: `
+validationRules:
+- name: not-bool
+  resources: ["jobs"]
+  expression: "1 + 1"
+`,
+		},
+		{
+			name: "unknown mode",
+			content: `
+validationRules:
+- name: typo
+  resources: ["jobs"]
+  expression: "true"
+  mode: Dryrun
+`,
+		},
+		{
+			name: "no resources",
+			content: `
+validationRules:
+- name: no-resources
+  expression: "true"
+`,
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "admission.conf")
+	if err := os.WriteFile(path, []byte(valid), 0644); err != nil {
+		t.Fatal(err)
+	}
+	conf := LoadAdmissionConf(path)
+	if conf == nil || len(conf.ValidationRules) != 1 {
+		t.Fatalf("expected the valid configuration to be loaded, got %v", conf)
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := os.WriteFile(path, []byte(tc.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if got := LoadAdmissionConf(path); got != nil {
+				t.Errorf("expected the invalid configuration to be rejected, got %v", got.ValidationRules)
+			}
+			conf.Lock()
+			rules := conf.ValidationRules
+			conf.Unlock()
+			if len(rules) != 1 || rules[0].Name != "no-default-queue" {
+				t.Errorf("expected the previous configuration to be kept, got %v", rules)
+			}
+		})
+	}
+}
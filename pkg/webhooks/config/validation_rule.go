@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+)
+
+const (
+	// ObjectVar is the variable of the object in the admission request
+	ObjectVar = "object"
+	// OldObjectVar is the variable of the old object in the admission request
+	OldObjectVar = "oldObject"
+	// RequestVar is the variable of the operation, namespace, name and userInfo of the admission request
+	RequestVar = "request"
+)
+
+type compiled struct {
+	program cel.Program
+	err     error
+}
+
+var (
+	envOnce sync.Once
+	env     *cel.Env
+	envErr  error
+
+	// programs caches the compiled programs by expression, as rules are reloaded with the admission configuration
+	programs     = map[string]*compiled{}
+	programMutex sync.Mutex
+)
+
+func getEnv() (*cel.Env, error) {
+	envOnce.Do(func() {
+		env, envErr = cel.NewEnv(
+			cel.Variable(ObjectVar, cel.DynType),
+			cel.Variable(OldObjectVar, cel.DynType),
+			cel.Variable(RequestVar, cel.DynType),
+		)
+	})
+	return env, envErr
+}
+
+// CompileExpression compiles the expression of a validation rule, the expression must be evaluated to a bool.
+func CompileExpression(expression string) (cel.Program, error) {
+	programMutex.Lock()
+	defer programMutex.Unlock()
+
+	if c, found := programs[expression]; found {
+		return c.program, c.err
+	}
+
+	c := &compiled{}
+	c.program, c.err = compileExpression(expression)
+	programs[expression] = c
+	return c.program, c.err
+}
+
+func compileExpression(expression string) (cel.Program, error) {
+	e, err := getEnv()
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := e.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		return nil, fmt.Errorf("expression must be evaluated to bool, got %v", ast.OutputType())
+	}
+	return e.Program(ast)
+}
+
+// validateRules checks that every rule applies to some resources, has a known mode and a valid expression,
+// so that a broken rule is rejected on loading instead of being silently skipped on every request.
+func validateRules(rules []ValidationRule) error {
+	for _, rule := range rules {
+		if len(rule.Resources) == 0 {
+			return fmt.Errorf("validation rule <%s> has no resources", rule.Name)
+		}
+		if rule.Mode != "" && rule.Mode != ValidationRuleEnforce && rule.Mode != ValidationRuleDryRun {
+			return fmt.Errorf("validation rule <%s> has unknown mode %q, must be %s or %s",
+				rule.Name, rule.Mode, ValidationRuleEnforce, ValidationRuleDryRun)
+		}
+		if _, err := CompileExpression(rule.Expression); err != nil {
+			return fmt.Errorf("validation rule <%s> failed to compile: %v", rule.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/webhooks/config"
+)
+
+// Validate evaluates the validation rules configured for the resource and operation of the admission request.
+// Violated rules in Enforce mode are aggregated into the returned error, while violated rules in DryRun mode
+// are only logged and returned as warnings. Rules failed to compile or evaluate are treated as violated.
+func Validate(conf *config.AdmissionConfiguration, request *admissionv1.AdmissionRequest) ([]string, error) {
+	if conf == nil || request == nil {
+		return nil, nil
+	}
+
+	conf.Lock()
+	rules := conf.ValidationRules
+	conf.Unlock()
+
+	var vars map[string]interface{}
+	var warnings, denials []string
+	for _, rule := range rules {
+		if !matches(rule, request) {
+			continue
+		}
+		var msg string
+		violated := true
+		program, err := config.CompileExpression(rule.Expression)
+		if err != nil {
+			msg = fmt.Sprintf("<%s>: failed to compile expression: %v", rule.Name, err)
+		} else {
+			if vars == nil {
+				vars, err = activation(request)
+				if err != nil {
+					return nil, err
+				}
+			}
+			msg, violated = evaluate(rule, program, vars)
+		}
+		if !violated {
+			continue
+		}
+		if rule.Mode == config.ValidationRuleDryRun {
+			klog.Warningf("%s %s <%s/%s> violates dry-run validation rule %s", request.Operation,
+				request.Resource.Resource, request.Namespace, request.Name, msg)
+			warnings = append(warnings, msg)
+			continue
+		}
+		denials = append(denials, msg)
+	}
+
+	if len(denials) > 0 {
+		return warnings, fmt.Errorf("denied by validation rules: %s", strings.Join(denials, "; "))
+	}
+	return warnings, nil
+}
+
+func matches(rule config.ValidationRule, request *admissionv1.AdmissionRequest) bool {
+	if !contains(rule.Resources, request.Resource.Resource) {
+		return false
+	}
+	return len(rule.Operations) == 0 || contains(rule.Operations, string(request.Operation))
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func evaluate(rule config.ValidationRule, program cel.Program, vars map[string]interface{}) (string, bool) {
+	message := rule.Message
+	if message == "" {
+		message = fmt.Sprintf("failed expression '%s'", rule.Expression)
+	}
+
+	val, _, err := program.Eval(vars)
+	if err != nil {
+		return fmt.Sprintf("<%s>: %s (evaluation error: %v)", rule.Name, message, err), true
+	}
+	allowed, ok := val.Value().(bool)
+	if !ok {
+		return fmt.Sprintf("<%s>: %s (expression evaluated to %v instead of bool)", rule.Name, message, val.Value()), true
+	}
+	return fmt.Sprintf("<%s>: %s", rule.Name, message), !allowed
+}
+
+func activation(request *admissionv1.AdmissionRequest) (map[string]interface{}, error) {
+	object, err := decode(request.Object.Raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode object: %v", err)
+	}
+	oldObject, err := decode(request.OldObject.Raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode old object: %v", err)
+	}
+
+	return map[string]interface{}{
+		config.ObjectVar:    object,
+		config.OldObjectVar: oldObject,
+		config.RequestVar: map[string]interface{}{
+			"operation": string(request.Operation),
+			"namespace": request.Namespace,
+			"name":      request.Name,
+			"userInfo": map[string]interface{}{
+				"username": request.UserInfo.Username,
+				"groups":   request.UserInfo.Groups,
+			},
+		},
+	}, nil
+}
+
+func decode(raw []byte) (map[string]interface{}, error) {
+	object := map[string]interface{}{}
+	if len(raw) == 0 {
+		return object, nil
+	}
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return nil, err
+	}
+	return object, nil
+}
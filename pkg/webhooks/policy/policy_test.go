@@ -0,0 +1,139 @@
+/*
+Copyright 2025 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"strings"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"volcano.sh/volcano/pkg/webhooks/config"
+)
+
+func TestValidate(t *testing.T) {
+	job := `{"metadata":{"name":"job1","namespace":"ns1","labels":{"team":"ml"}},` +
+		`"spec":{"queue":"default","minAvailable":1,"tasks":[{"name":"worker","replicas":4}]}}`
+	oldJob := `{"metadata":{"name":"job1","namespace":"ns1"},"spec":{"queue":"q1","minAvailable":1}}`
+
+	request := func(operation admissionv1.Operation, object, oldObject string) *admissionv1.AdmissionRequest {
+		return &admissionv1.AdmissionRequest{
+			Resource:  metav1.GroupVersionResource{Group: "batch.volcano.sh", Version: "v1alpha1", Resource: "jobs"},
+			Operation: operation,
+			Namespace: "ns1",
+			Name:      "job1",
+			UserInfo:  authenticationv1.UserInfo{Username: "alice", Groups: []string{"ml-team"}},
+			Object:    runtime.RawExtension{Raw: []byte(object)},
+			OldObject: runtime.RawExtension{Raw: []byte(oldObject)},
+		}
+	}
+
+	testCases := []struct {
+		name         string
+		rules        []config.ValidationRule
+		request      *admissionv1.AdmissionRequest
+		wantErr      string
+		wantWarnings int
+	}{
+		{
+			name: "no rule matches the resource",
+			rules: []config.ValidationRule{
+				{Name: "queue-weight", Resources: []string{"queues"}, Expression: "false"},
+			},
+			request: request(admissionv1.Create, job, ""),
+		},
+		{
+			name: "all rules are satisfied",
+			rules: []config.ValidationRule{
+				{Name: "team-label", Resources: []string{"jobs"}, Expression: "has(object.metadata.labels.team)"},
+				{Name: "max-replicas", Resources: []string{"jobs"}, Expression: "object.spec.tasks.all(t, t.replicas <= 8)"},
+				{Name: "user", Resources: []string{"jobs"}, Expression: "'ml-team' in request.userInfo.groups"},
+			},
+			request: request(admissionv1.Create, job, ""),
+		},
+		{
+			name: "enforced rule is violated",
+			rules: []config.ValidationRule{
+				{Name: "no-default-queue", Resources: []string{"jobs"}, Expression: "object.spec.queue != 'default'",
+					Message: "jobs must not be submitted to the default queue"},
+			},
+			request: request(admissionv1.Create, job, ""),
+			wantErr: "<no-default-queue>: jobs must not be submitted to the default queue",
+		},
+		{
+			name: "dry-run rule is violated",
+			rules: []config.ValidationRule{
+				{Name: "no-default-queue", Resources: []string{"jobs"}, Expression: "object.spec.queue != 'default'",
+					Mode: config.ValidationRuleDryRun},
+			},
+			request:      request(admissionv1.Create, job, ""),
+			wantWarnings: 1,
+		},
+		{
+			name: "rule does not match the operation",
+			rules: []config.ValidationRule{
+				{Name: "immutable-queue", Resources: []string{"jobs"}, Operations: []string{"UPDATE"},
+					Expression: "object.spec.queue == oldObject.spec.queue"},
+			},
+			request: request(admissionv1.Create, job, ""),
+		},
+		{
+			name: "rule compares with old object",
+			rules: []config.ValidationRule{
+				{Name: "immutable-queue", Resources: []string{"jobs"}, Operations: []string{"UPDATE"},
+					Expression: "object.spec.queue == oldObject.spec.queue", Message: "queue is immutable"},
+			},
+			request: request(admissionv1.Update, job, oldJob),
+			wantErr: "<immutable-queue>: queue is immutable",
+		},
+		{
+			name: "evaluation error is a violation",
+			rules: []config.ValidationRule{
+				{Name: "priority", Resources: []string{"jobs"}, Expression: "object.spec.priorityClassName == 'high'"},
+			},
+			request: request(admissionv1.Create, job, ""),
+			wantErr: "evaluation error",
+		},
+		{
+			name: "invalid expression is a violation",
+			rules: []config.ValidationRule{
+				{Name: "invalid", Resources: []string{"jobs"}, Expression: "object.spec.queue =="},
+				{Name: "not-bool", Resources: []string{"jobs"}, Expression: "1 + 1"},
+			},
+			request: request(admissionv1.Create, job, ""),
+			wantErr: "<not-bool>: failed to compile expression",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			warnings, err := Validate(&config.AdmissionConfiguration{ValidationRules: tc.rules}, tc.request)
+			if tc.wantErr == "" && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+			if tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)) {
+				t.Errorf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+			if len(warnings) != tc.wantWarnings {
+				t.Errorf("expected %d warnings, got %v", tc.wantWarnings, warnings)
+			}
+		})
+	}
+}